@@ -1,5 +1,7 @@
 package common
 
+import "fmt"
+
 type Cub interface {
 Base
 	Slices() int
@@ -15,6 +17,17 @@ Base
 	ColumnZero() int
 
 	Index(int, int, int) int
+
+	Size() int // Returns the number of cells.
+
+	StringShort() string
+
+	VDice(axis0, axis1, axis2 int) error
+	VPart(slice, row, column, depth, height, width int) error
+	VSliceFlip()
+	VRowFlip()
+	VColumnFlip()
+	VStrides(sliceStride, rowStride, columnStride int) error
 }
 
 type CoreCub struct {
@@ -95,3 +108,105 @@ func (m *CoreCub) ColumnZero() int {
 func (m *CoreCub) Index(slice, row, column int) int {
 	return m.SliceZero() + slice*m.SliceStride() + m.RowZero() + row*m.RowStride() + m.ColumnZero() + column*m.ColumnStride()
 }
+
+// Returns a short string representation of the receiver's shape.
+func (m *CoreCub) StringShort() string {
+	return fmt.Sprintf("%d x %d x %d cube", m.slices, m.rows, m.columns)
+}
+
+// Returns the number of cells which is Slices()*Rows()*Columns().
+func (m *CoreCub) Size() int {
+	return m.slices * m.rows * m.columns
+}
+
+// Returns a copy of the receiver's shape, marked as a view, for a new
+// cube backend sharing the elements of the receiver.
+func (m *CoreCub) View() *CoreCub {
+	v := *m
+	v.Core = &Core{true}
+	return &v
+}
+
+func (m *CoreCub) CheckBox(slice, row, column, depth, height, width int) error {
+	if slice < 0 || depth < 0 || slice+depth > m.slices || row < 0 || height < 0 || row+height > m.rows || column < 0 || width < 0 || column+width > m.columns {
+		return fmt.Errorf("%s, slice:%d, row:%d, column:%d, depth:%d, height:%d, width:%d", m.StringShort(), slice, row, column, depth, height, width)
+	}
+	return nil
+}
+
+// Permutes the axes of the receiver; axis0, axis1 and axis2 give the
+// current axes (0 for slices, 1 for rows and 2 for columns) that become
+// the slices, rows and columns respectively.
+func (m *CoreCub) VDice(axis0, axis1, axis2 int) error {
+	if axis0 < 0 || axis0 > 2 || axis1 < 0 || axis1 > 2 || axis2 < 0 || axis2 > 2 || axis0 == axis1 || axis0 == axis2 || axis1 == axis2 {
+		return fmt.Errorf("Illegal axes: %d, %d, %d", axis0, axis1, axis2)
+	}
+	shape := [3]int{m.slices, m.rows, m.columns}
+	stride := [3]int{m.sliceStride, m.rowStride, m.columnStride}
+	zero := [3]int{m.sliceZero, m.rowZero, m.columnZero}
+
+	m.slices, m.rows, m.columns = shape[axis0], shape[axis1], shape[axis2]
+	m.sliceStride, m.rowStride, m.columnStride = stride[axis0], stride[axis1], stride[axis2]
+	m.sliceZero, m.rowZero, m.columnZero = zero[axis0], zero[axis1], zero[axis2]
+	m.isView = true
+	return nil
+}
+
+func (m *CoreCub) VPart(slice, row, column, depth, height, width int) error {
+	err := m.CheckBox(slice, row, column, depth, height, width)
+	if err != nil {
+		return err
+	}
+	m.sliceZero += m.sliceStride * slice
+	m.rowZero += m.rowStride * row
+	m.columnZero += m.columnStride * column
+	m.slices = depth
+	m.rows = height
+	m.columns = width
+	m.isView = true
+	return nil
+}
+
+func (m *CoreCub) VSliceFlip() {
+	if m.slices > 0 {
+		m.sliceZero += (m.slices - 1) * m.sliceStride
+		m.sliceStride = -m.sliceStride
+		m.isView = true
+	}
+}
+
+func (m *CoreCub) VRowFlip() {
+	if m.rows > 0 {
+		m.rowZero += (m.rows - 1) * m.rowStride
+		m.rowStride = -m.rowStride
+		m.isView = true
+	}
+}
+
+func (m *CoreCub) VColumnFlip() {
+	if m.columns > 0 {
+		m.columnZero += (m.columns - 1) * m.columnStride
+		m.columnStride = -m.columnStride
+		m.isView = true
+	}
+}
+
+func (m *CoreCub) VStrides(sliceStride, rowStride, columnStride int) error {
+	if sliceStride <= 0 || rowStride <= 0 || columnStride <= 0 {
+		return fmt.Errorf("illegal strides: %d, %d, %d", sliceStride, rowStride, columnStride)
+	}
+	m.sliceStride *= sliceStride
+	m.rowStride *= rowStride
+	m.columnStride *= columnStride
+	if m.slices != 0 {
+		m.slices = (m.slices-1)/sliceStride + 1
+	}
+	if m.rows != 0 {
+		m.rows = (m.rows-1)/rowStride + 1
+	}
+	if m.columns != 0 {
+		m.columns = (m.columns-1)/columnStride + 1
+	}
+	m.isView = true
+	return nil
+}
@@ -172,8 +172,7 @@ func (f *FormatterBase) AlignRow(row []string, maxColWidth, maxColLead []int) {
 			s.WriteString(c)
 			s.WriteString(f.Blanks(maxColWidth[column] - s.Len()))
 		} else {
-			fmt.Errorf("invalid alignment:%s", f.Alignment)
-			return
+			return // Unknown alignment; leave the row unaligned.
 		}
 		row[column] = s.String()
 	}
@@ -213,7 +212,8 @@ func (f *FormatterBase) ArrayToString(strings [][]string) string {
 				s.WriteString(f.ColumnSeparator)
 			}
 		}
-		total.WriteString(s.String())
+		// Alignment pads the last column too; drop the trailing blanks.
+		total.Write(bytes.TrimRight(s.Bytes(), " "))
 		if row < rows - 1 {
 			total.WriteString(f.RowSeparator)
 		}
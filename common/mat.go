@@ -18,9 +18,13 @@ type Mat interface {
 
 	Size() int // Returns the number of cells.
 
-	VFlip()
-	VPart(index, width int) error
-	VStrides(stride int) error
+	StringShort() string
+
+	VColumnFlip()
+	VDice()
+	VPart(row, column, height, width int) error
+	VRowFlip()
+	VStrides(rowStride, columnStride int) error
 }
 
 type CoreMat struct {
@@ -72,7 +76,7 @@ func (m *CoreMat) Index(row, column int) int {
 
 // Returns a short string representation of the receiver's shape.
 func (m *CoreMat) StringShort() string {
-	return MatrixShape(m)
+	return fmt.Sprintf("%d x %d matrix", m.rows, m.columns)
 }
 
 // Returns the number of cells which is Rows()*Columns().
@@ -80,6 +84,14 @@ func (m *CoreMat) Size() int {
 	return m.rows*m.columns
 }
 
+// Returns a copy of the receiver, marked as a view, whose shape may be
+// modified independently.
+func (m *CoreMat) View() *CoreMat {
+	v := *m
+	v.Core = &Core{true}
+	return &v
+}
+
 func (m *CoreMat) CheckShape(other Mat) error {
 	if m.rows != other.Rows() || m.columns != other.Columns() {
 		return fmt.Errorf("row sizes do not match: %d!=%d", m.rows, other.Rows())
@@ -114,7 +126,7 @@ func (m *CoreMat) CheckRow(row int) error {
 func (m *CoreMat) VColumnFlip() {
 	if m.columns > 0 {
 		m.columnZero += (m.columns - 1) * m.columnStride
-		m.columnStride = -columnStride
+		m.columnStride = -m.columnStride
 		m.isView = true
 	}
 }
@@ -159,7 +171,7 @@ func (m *CoreMat) VRowFlip() {
 }
 
 func (m *CoreMat) VStrides(rowStride, columnStride int) error {
-	if m.rowStride <= 0 || m.columnStride <= 0 {
+	if rowStride <= 0 || columnStride <= 0 {
 		return fmt.Errorf("illegal strides: %d, %d", rowStride, columnStride)
 	}
 	m.rowStride *= rowStride
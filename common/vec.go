@@ -7,6 +7,7 @@ type Vec interface {
 	Base
 
 	Size() int // Returns the number of cells.
+	StringShort() string
 	Zero() int
 	Stride() int
 	Index(int) int
@@ -35,7 +36,7 @@ func (v *CoreVec) Size() int {
 
 // Returns a short string representation of the receiver's shape.
 func (v *CoreVec) StringShort() string {
-	return VectorShape(v)
+	return fmt.Sprintf("%d vector", v.size)
 }
 
 // Returns the number of indexes between any two elements.
@@ -56,7 +57,7 @@ func (v *CoreVec) Index(rank int) int {
 func (v *CoreVec) CheckSize(other Vec) error {
 	if v.Size() != other.Size() {
 		return fmt.Errorf("Incompatible sizes: %s and %s",
-			VectorShape(v), VectorShape(other))
+			v.StringShort(), VectorShape(other))
 	}
 	return nil
 }
@@ -81,7 +82,7 @@ func (v *CoreVec) VFlip() {
 
 // Self modifying version of ViewPart().
 func (v *CoreVec) VPart(index, width int) error {
-	err := v.checkRange(index, width)
+	err := v.CheckRange(index, width)
 	if err != nil {
 		return err
 	}
@@ -94,7 +95,7 @@ func (v *CoreVec) VPart(index, width int) error {
 // Self modifying version of ViewStrides().
 func (v *CoreVec) VStrides(stride int) error {
 	if stride <= 0 {
-		return fmt.Errorf("illegal stride: %s", stride)
+		return fmt.Errorf("illegal stride: %d", stride)
 	}
 	v.stride *= stride
 	if v.size != 0 {
@@ -0,0 +1,194 @@
+package tbool
+
+import (
+	"fmt"
+
+	"github.com/rwl/goshawk/tfloat64"
+	"github.com/rwl/goshawk/tint"
+)
+
+// Sets each cell to whether cond holds for the corresponding cell of
+// other.
+func (v *Vector) AssignFloat64(other tfloat64.Vec, cond tfloat64.Float64Procedure) (*Vector, error) {
+	err := v.checkSize(other)
+	if err != nil {
+		return v, err
+	}
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, cond(other.GetQuick(i)))
+	}
+	return v, nil
+}
+
+// Returns a new float64 vector holding 1 for the true cells of the receiver
+// and 0 for the others. The vector is sparse if the receiver is.
+func (v *Vector) Float64() *tfloat64.Vector {
+	var x *tfloat64.Vector
+	if _, ok := v.Vec.(*SparseVec); ok {
+		x = tfloat64.NewSparseVector(v.Size())
+	} else {
+		x = tfloat64.NewVector(v.Size())
+	}
+	for i := 0; i < v.Size(); i++ {
+		if v.GetQuick(i) {
+			x.SetQuick(i, 1)
+		}
+	}
+	return x
+}
+
+// Sets each cell to whether cond holds for the corresponding cell of
+// other.
+func (m *Matrix) AssignFloat64(other tfloat64.Mat, cond tfloat64.Float64Procedure) (*Matrix, error) {
+	err := m.checkShape(other)
+	if err != nil {
+		return m, err
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, cond(other.GetQuick(r, c)))
+		}
+	}
+	return m, nil
+}
+
+// Returns a new float64 matrix holding 1 for the true cells of the receiver
+// and 0 for the others. The matrix is sparse if the receiver is.
+func (m *Matrix) Float64() *tfloat64.Matrix {
+	var A *tfloat64.Matrix
+	if _, ok := m.Mat.(*SparseMat); ok {
+		A = tfloat64.NewSparseMatrix(m.Rows(), m.Columns())
+	} else {
+		A = tfloat64.NewMatrix(m.Rows(), m.Columns())
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			if m.GetQuick(r, c) {
+				A.SetQuick(r, c, 1)
+			}
+		}
+	}
+	return A
+}
+
+// Sets each cell to whether cond holds for the corresponding cell of
+// other.
+func (m *Cube) AssignFloat64(other tfloat64.Cub, cond tfloat64.Float64Procedure) (*Cube, error) {
+	if m.Slices() != other.Slices() || m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return m, fmt.Errorf("Incompatible dimensions: %s and %s", m.StringShort(), other.StringShort())
+	}
+	m.forEach(func(s, r, c int) {
+		m.SetQuick(s, r, c, cond(other.GetQuick(s, r, c)))
+	})
+	return m, nil
+}
+
+// Returns a new float64 cube holding 1 for the true cells of the receiver
+// and 0 for the others. The cube is sparse if the receiver is.
+func (m *Cube) Float64() *tfloat64.Cube {
+	var A *tfloat64.Cube
+	if _, ok := m.Cub.(*SparseCub); ok {
+		A = tfloat64.NewSparseCube(m.Slices(), m.Rows(), m.Columns())
+	} else {
+		A = tfloat64.NewCube(m.Slices(), m.Rows(), m.Columns())
+	}
+	m.forEach(func(s, r, c int) {
+		if m.GetQuick(s, r, c) {
+			A.SetQuick(s, r, c, 1)
+		}
+	})
+	return A
+}
+
+// Sets each cell to whether cond holds for the corresponding cell of
+// other.
+func (v *Vector) AssignInt(other tint.Vec, cond tint.IntProcedure) (*Vector, error) {
+	err := v.checkSize(other)
+	if err != nil {
+		return v, err
+	}
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, cond(other.GetQuick(i)))
+	}
+	return v, nil
+}
+
+// Returns a new int vector holding 1 for the true cells of the receiver
+// and 0 for the others. The vector is sparse if the receiver is.
+func (v *Vector) Int() *tint.Vector {
+	var x *tint.Vector
+	if _, ok := v.Vec.(*SparseVec); ok {
+		x = tint.NewSparseVector(v.Size())
+	} else {
+		x = tint.NewVector(v.Size())
+	}
+	for i := 0; i < v.Size(); i++ {
+		if v.GetQuick(i) {
+			x.SetQuick(i, 1)
+		}
+	}
+	return x
+}
+
+// Sets each cell to whether cond holds for the corresponding cell of
+// other.
+func (m *Matrix) AssignInt(other tint.Mat, cond tint.IntProcedure) (*Matrix, error) {
+	err := m.checkShape(other)
+	if err != nil {
+		return m, err
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, cond(other.GetQuick(r, c)))
+		}
+	}
+	return m, nil
+}
+
+// Returns a new int matrix holding 1 for the true cells of the receiver
+// and 0 for the others. The matrix is sparse if the receiver is.
+func (m *Matrix) Int() *tint.Matrix {
+	var A *tint.Matrix
+	if _, ok := m.Mat.(*SparseMat); ok {
+		A = tint.NewSparseMatrix(m.Rows(), m.Columns())
+	} else {
+		A = tint.NewMatrix(m.Rows(), m.Columns())
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			if m.GetQuick(r, c) {
+				A.SetQuick(r, c, 1)
+			}
+		}
+	}
+	return A
+}
+
+// Sets each cell to whether cond holds for the corresponding cell of
+// other.
+func (m *Cube) AssignInt(other tint.Cub, cond tint.IntProcedure) (*Cube, error) {
+	if m.Slices() != other.Slices() || m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return m, fmt.Errorf("Incompatible dimensions: %s and %s", m.StringShort(), other.StringShort())
+	}
+	m.forEach(func(s, r, c int) {
+		m.SetQuick(s, r, c, cond(other.GetQuick(s, r, c)))
+	})
+	return m, nil
+}
+
+// Returns a new int cube holding 1 for the true cells of the receiver
+// and 0 for the others. The cube is sparse if the receiver is.
+func (m *Cube) Int() *tint.Cube {
+	var A *tint.Cube
+	if _, ok := m.Cub.(*SparseCub); ok {
+		A = tint.NewSparseCube(m.Slices(), m.Rows(), m.Columns())
+	} else {
+		A = tint.NewCube(m.Slices(), m.Rows(), m.Columns())
+	}
+	m.forEach(func(s, r, c int) {
+		if m.GetQuick(s, r, c) {
+			A.SetQuick(s, r, c, 1)
+		}
+	})
+	return A
+}
@@ -0,0 +1,102 @@
+package tbool
+
+import (
+	"github.com/rwl/goshawk/tfloat64"
+	"github.com/rwl/goshawk/tint"
+)
+
+// Returns a new dense vector mask whose cells are true where cond holds
+// for the corresponding cell of v.
+func NewVectorFloat64(v tfloat64.Vec, cond tfloat64.Float64Procedure) *Vector {
+	x := NewVector(v.Size())
+	x.AssignFloat64(v, cond)
+	return x
+}
+
+// Returns a new sparse vector mask whose cells are true where cond holds
+// for the corresponding cell of v.
+func NewSparseVectorFloat64(v tfloat64.Vec, cond tfloat64.Float64Procedure) *Vector {
+	x := NewSparseVector(v.Size())
+	x.AssignFloat64(v, cond)
+	return x
+}
+
+// Returns a new dense matrix mask whose cells are true where cond holds
+// for the corresponding cell of A.
+func NewMatrixFloat64(A tfloat64.Mat, cond tfloat64.Float64Procedure) *Matrix {
+	m := NewMatrix(A.Rows(), A.Columns())
+	m.AssignFloat64(A, cond)
+	return m
+}
+
+// Returns a new sparse matrix mask whose cells are true where cond holds
+// for the corresponding cell of A.
+func NewSparseMatrixFloat64(A tfloat64.Mat, cond tfloat64.Float64Procedure) *Matrix {
+	m := NewSparseMatrix(A.Rows(), A.Columns())
+	m.AssignFloat64(A, cond)
+	return m
+}
+
+// Returns a new dense cube mask whose cells are true where cond holds
+// for the corresponding cell of A.
+func NewCubeFloat64(A tfloat64.Cub, cond tfloat64.Float64Procedure) *Cube {
+	m := NewCube(A.Slices(), A.Rows(), A.Columns())
+	m.AssignFloat64(A, cond)
+	return m
+}
+
+// Returns a new sparse cube mask whose cells are true where cond holds
+// for the corresponding cell of A.
+func NewSparseCubeFloat64(A tfloat64.Cub, cond tfloat64.Float64Procedure) *Cube {
+	m := NewSparseCube(A.Slices(), A.Rows(), A.Columns())
+	m.AssignFloat64(A, cond)
+	return m
+}
+
+// Returns a new dense vector mask whose cells are true where cond holds
+// for the corresponding cell of v.
+func NewVectorInt(v tint.Vec, cond tint.IntProcedure) *Vector {
+	x := NewVector(v.Size())
+	x.AssignInt(v, cond)
+	return x
+}
+
+// Returns a new sparse vector mask whose cells are true where cond holds
+// for the corresponding cell of v.
+func NewSparseVectorInt(v tint.Vec, cond tint.IntProcedure) *Vector {
+	x := NewSparseVector(v.Size())
+	x.AssignInt(v, cond)
+	return x
+}
+
+// Returns a new dense matrix mask whose cells are true where cond holds
+// for the corresponding cell of A.
+func NewMatrixInt(A tint.Mat, cond tint.IntProcedure) *Matrix {
+	m := NewMatrix(A.Rows(), A.Columns())
+	m.AssignInt(A, cond)
+	return m
+}
+
+// Returns a new sparse matrix mask whose cells are true where cond holds
+// for the corresponding cell of A.
+func NewSparseMatrixInt(A tint.Mat, cond tint.IntProcedure) *Matrix {
+	m := NewSparseMatrix(A.Rows(), A.Columns())
+	m.AssignInt(A, cond)
+	return m
+}
+
+// Returns a new dense cube mask whose cells are true where cond holds
+// for the corresponding cell of A.
+func NewCubeInt(A tint.Cub, cond tint.IntProcedure) *Cube {
+	m := NewCube(A.Slices(), A.Rows(), A.Columns())
+	m.AssignInt(A, cond)
+	return m
+}
+
+// Returns a new sparse cube mask whose cells are true where cond holds
+// for the corresponding cell of A.
+func NewSparseCubeInt(A tint.Cub, cond tint.IntProcedure) *Cube {
+	m := NewSparseCube(A.Slices(), A.Rows(), A.Columns())
+	m.AssignInt(A, cond)
+	return m
+}
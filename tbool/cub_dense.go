@@ -0,0 +1,35 @@
+package tbool
+
+import "github.com/rwl/goshawk/common"
+
+type DenseCub struct {
+	*common.CoreCub
+	elements []bool // The elements of this cube.
+}
+
+func (m *DenseCub) GetQuick(slice, row, column int) bool {
+	return m.elements[m.Index(slice, row, column)]
+}
+
+func (m *DenseCub) SetQuick(slice, row, column int, value bool) {
+	m.elements[m.Index(slice, row, column)] = value
+}
+
+func (m *DenseCub) Elements() interface{} {
+	return m.elements
+}
+
+func (m *DenseCub) Like(slices, rows, columns int) Cub {
+	return NewCube(slices, rows, columns).Cub
+}
+
+func (m *DenseCub) Like2D(rows, columns, rowZero, columnZero, rowStride, columnStride int) Mat {
+	return &DenseMat{
+		common.NewCoreMat(true, rows, columns, rowStride, columnStride, rowZero, columnZero),
+		m.elements,
+	}
+}
+
+func (m *DenseCub) View() Cub {
+	return &DenseCub{m.CoreCub.View(), m.elements}
+}
@@ -0,0 +1,252 @@
+package tbool
+
+import "fmt"
+
+type Cube struct {
+	Cub
+}
+
+// Returns a string representation using default formatting.
+func (m *Cube) String() string {
+	return fmtr.CubeToString(m)
+}
+
+func (m *Cube) Get(slice, row, column int) (bool, error) {
+	if slice < 0 || slice >= m.Slices() || row < 0 || row >= m.Rows() || column < 0 || column >= m.Columns() {
+		return false, fmt.Errorf("slice:%d, row:%d, column:%d", slice, row, column)
+	}
+	return m.GetQuick(slice, row, column), nil
+}
+
+func (m *Cube) Set(slice, row, column int, value bool) error {
+	if slice < 0 || slice >= m.Slices() || row < 0 || row >= m.Rows() || column < 0 || column >= m.Columns() {
+		return fmt.Errorf("slice:%d, row:%d, column:%d", slice, row, column)
+	}
+	m.SetQuick(slice, row, column, value)
+	return nil
+}
+
+// Returns a deep copy of the receiver.
+func (m *Cube) Copy() *Cube {
+	copy := &Cube{m.Like(m.Slices(), m.Rows(), m.Columns())}
+	copy.AssignCube(m)
+	return copy
+}
+
+// Returns the number of true cells.
+func (m *Cube) Cardinality() int {
+	cardinality := 0
+	m.forEach(func(s, r, c int) {
+		if m.GetQuick(s, r, c) {
+			cardinality++
+		}
+	})
+	return cardinality
+}
+
+// Returns whether all cells are equal to the given value.
+func (m *Cube) Equals(value bool) bool {
+	equal := true
+	m.forEach(func(s, r, c int) {
+		if equal && !equals(value, m.GetQuick(s, r, c)) {
+			equal = false
+		}
+	})
+	return equal
+}
+
+// Returns whether the receiver has the same shape and the same values as
+// other.
+func (m *Cube) EqualsCube(other Cub) bool {
+	if m.checkShape(other) != nil {
+		return false
+	}
+	equal := true
+	m.forEach(func(s, r, c int) {
+		if equal && !equals(m.GetQuick(s, r, c), other.GetQuick(s, r, c)) {
+			equal = false
+		}
+	})
+	return equal
+}
+
+// Returns the cell values as a slices x rows x columns array.
+func (m *Cube) ToArray() [][][]bool {
+	values := make([][][]bool, m.Slices())
+	for s := range values {
+		values[s] = make([][]bool, m.Rows())
+		for r := range values[s] {
+			values[s][r] = make([]bool, m.Columns())
+		}
+	}
+	m.forEach(func(s, r, c int) {
+		values[s][r][c] = m.GetQuick(s, r, c)
+	})
+	return values
+}
+
+// Sets all cells to the given value.
+func (m *Cube) Assign(value bool) *Cube {
+	m.forEach(func(s, r, c int) {
+		m.SetQuick(s, r, c, value)
+	})
+	return m
+}
+
+// Assigns the result of a function to each cell; x[s,r,c] =
+// f(x[s,r,c]).
+func (m *Cube) AssignFunc(f BoolFunc) *Cube {
+	m.forEach(func(s, r, c int) {
+		m.SetQuick(s, r, c, f(m.GetQuick(s, r, c)))
+	})
+	return m
+}
+
+// Sets all cells to the values of the given array, indexed
+// [slice][row][column], which must have the same shape as the receiver.
+func (m *Cube) AssignArray(values [][][]bool) (*Cube, error) {
+	if len(values) != m.Slices() {
+		return m, fmt.Errorf("Must have same number of slices: slices=%d slices()=%d",
+			len(values), m.Slices())
+	}
+	for s, slice := range values {
+		if len(slice) != m.Rows() {
+			return m, fmt.Errorf("Must have same number of rows in every slice: rows=%d rows()=%d",
+				len(slice), m.Rows())
+		}
+		for r, row := range slice {
+			if len(row) != m.Columns() {
+				return m, fmt.Errorf("Must have same number of columns in every row: columns=%d columns()=%d",
+					len(row), m.Columns())
+			}
+			for c, value := range row {
+				m.SetQuick(s, r, c, value)
+			}
+		}
+	}
+	return m, nil
+}
+
+// Replaces all cell values of the receiver with the values of other,
+// which must have the same shape.
+func (m *Cube) AssignCube(other Cub) (*Cube, error) {
+	err := m.checkShape(other)
+	if err != nil {
+		return m, err
+	}
+	if o, ok := other.(*Cube); ok {
+		other = o.Cub
+	}
+	if other == m.Cub {
+		return m, nil
+	}
+	m.forEach(func(s, r, c int) {
+		m.SetQuick(s, r, c, other.GetQuick(s, r, c))
+	})
+	return m, nil
+}
+
+// Assigns the result of a function to each cell;
+// x[s,r,c] = f(x[s,r,c], y[s,r,c]).
+func (m *Cube) AssignCubeFunc(y Cub, f BoolBoolFunc) (*Cube, error) {
+	err := m.checkShape(y)
+	if err != nil {
+		return m, err
+	}
+	m.forEach(func(s, r, c int) {
+		m.SetQuick(s, r, c, f(m.GetQuick(s, r, c), y.GetQuick(s, r, c)))
+	})
+	return m, nil
+}
+
+// Applies a function to each cell and aggregates the results.
+func (m *Cube) Aggregate(aggr BoolBoolFunc, f BoolFunc) bool {
+	if m.Size() == 0 {
+		return false
+	}
+	var a bool
+	first := true
+	m.forEach(func(s, r, c int) {
+		if first {
+			a = f(m.GetQuick(s, r, c))
+			first = false
+		} else {
+			a = aggr(a, f(m.GetQuick(s, r, c)))
+		}
+	})
+	return a
+}
+
+// Returns a rows x columns matrix view of the given slice. The view
+// shares the cells of the cube.
+func (m *Cube) ViewSlice(slice int) (*Matrix, error) {
+	if slice < 0 || slice >= m.Slices() {
+		return nil, fmt.Errorf("Attempted to access %s at slice=%d", m.StringShort(), slice)
+	}
+	return &Matrix{m.Like2D(m.Rows(), m.Columns(),
+		m.SliceZero()+slice*m.SliceStride()+m.RowZero(), m.ColumnZero(),
+		m.RowStride(), m.ColumnStride())}, nil
+}
+
+// Returns a view with the axes permuted; axis0, axis1 and axis2 give the
+// axes of the receiver (0 for slices, 1 for rows and 2 for columns) that
+// become the slices, rows and columns of the view.
+func (m *Cube) ViewDice(axis0, axis1, axis2 int) (*Cube, error) {
+	v := m.View()
+	err := v.VDice(axis0, axis1, axis2)
+	if err != nil {
+		return nil, err
+	}
+	return &Cube{v}, nil
+}
+
+// Returns a depth x height x width view of the sub-range of cells
+// starting at [slice,row,column].
+func (m *Cube) ViewPart(slice, row, column, depth, height, width int) (*Cube, error) {
+	v := m.View()
+	err := v.VPart(slice, row, column, depth, height, width)
+	if err != nil {
+		return nil, err
+	}
+	return &Cube{v}, nil
+}
+
+// Returns a view of every sliceStride-th slice, rowStride-th row and
+// columnStride-th column. The strides must be positive.
+func (m *Cube) ViewStrides(sliceStride, rowStride, columnStride int) (*Cube, error) {
+	v := m.View()
+	err := v.VStrides(sliceStride, rowStride, columnStride)
+	if err != nil {
+		return nil, err
+	}
+	return &Cube{v}, nil
+}
+
+func (m *Cube) checkShape(other Cub) error {
+	if m.Slices() != other.Slices() || m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return fmt.Errorf("Incompatible dimensions: %s and %s", m.StringShort(), other.StringShort())
+	}
+	return nil
+}
+
+// Calls f for the coordinates of every cell, in slice, row, column
+// order.
+func (m *Cube) forEach(f func(s, r, c int)) {
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				f(s, r, c)
+			}
+		}
+	}
+}
+
+// Returns whether any cell is true.
+func (m *Cube) Any() bool {
+	return m.Cardinality() > 0
+}
+
+// Returns whether all cells are true. An empty cube is all true.
+func (m *Cube) All() bool {
+	return m.Cardinality() == m.Size()
+}
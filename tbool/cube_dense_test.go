@@ -0,0 +1,27 @@
+package tbool
+
+import "testing"
+
+func makeDenseCube() *Cube {
+	return fillCube(NewCube(nslices, nrows, ncols))
+}
+
+func TestDenseCubeGetSet(t *testing.T) {
+	testCubeGetSet(t, makeDenseCube())
+}
+
+func TestDenseCubeAssign(t *testing.T) {
+	testCubeAssign(t, makeDenseCube())
+}
+
+func TestDenseCubeView(t *testing.T) {
+	testCubeView(t, makeDenseCube())
+}
+
+func TestDenseCubeMask(t *testing.T) {
+	testCubeMask(t, makeDenseCube())
+}
+
+func TestDenseCubeConvert(t *testing.T) {
+	testCubeConvert(t, makeDenseCube())
+}
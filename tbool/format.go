@@ -0,0 +1,89 @@
+package tbool
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/rwl/goshawk/common"
+)
+
+// Flexible, well human readable matrix print formatting for boolean
+// vectors, matrices and cubes. Just call String() on a vector, matrix or
+// cube for the default formatting; this type is for advanced
+// requirements.
+type Formatter struct {
+	common.FormatterBase
+}
+
+// Constructs and returns a matrix formatter with format "%t".
+func NewFormatter() *Formatter {
+	return NewFormatterFormat("%t")
+}
+
+// Constructs and returns a matrix formatter with the given format used to
+// convert a single cell value.
+func NewFormatterFormat(format string) *Formatter {
+	f := &Formatter{*common.NewFormatter()}
+	f.Format = format
+	f.Alignment = common.RIGHT
+	return f
+}
+
+// Returns a string representations of all cells; no alignment
+// considered.
+func (f *Formatter) FormatMatrix(matrix Mat) [][]string {
+	strings := make([][]string, matrix.Rows())
+	for r := range strings {
+		strings[r] = make([]string, matrix.Columns())
+		for c := range strings[r] {
+			strings[r][c] = fmt.Sprintf(f.Format, matrix.GetQuick(r, c))
+		}
+	}
+	return strings
+}
+
+// Returns a string representation of the given vector.
+func (f *Formatter) VectorToString(v Vec) string {
+	strings := make([][]string, 1)
+	strings[0] = make([]string, v.Size())
+	for i := range strings[0] {
+		strings[0][i] = fmt.Sprintf(f.Format, v.GetQuick(i))
+	}
+	f.Align(strings)
+	total := f.ArrayToString(strings)
+	if f.PrintShape {
+		total = v.StringShort() + "\n" + total
+	}
+	return total
+}
+
+// Returns a string representation of the given matrix.
+func (f *Formatter) MatrixToString(matrix Mat) string {
+	strings := f.FormatMatrix(matrix)
+	f.Align(strings)
+	total := f.ArrayToString(strings)
+	if f.PrintShape {
+		total = matrix.StringShort() + "\n" + total
+	}
+	return total
+}
+
+// Returns a string representation of the given cube, formatting each
+// slice as a matrix.
+func (f *Formatter) CubeToString(cube *Cube) string {
+	var buf bytes.Buffer
+	oldPrintShape := f.PrintShape
+	f.PrintShape = false
+	for slice := 0; slice < cube.Slices(); slice++ {
+		if slice != 0 {
+			buf.WriteString(f.SliceSeparator)
+		}
+		view, _ := cube.ViewSlice(slice)
+		buf.WriteString(f.MatrixToString(view))
+	}
+	f.PrintShape = oldPrintShape
+	if f.PrintShape {
+		return cube.StringShort() + "\n" + buf.String()
+	}
+	return buf.String()
+}
@@ -0,0 +1,60 @@
+package tbool
+
+type BoolFunc func(bool) bool
+
+type BoolBoolFunc func(bool, bool) bool
+
+type BoolProcedure func(bool) bool
+
+type IntIntBoolFunc func(int, int, bool) bool
+
+type VectorProcedure func(Vec) bool
+
+// Function that returns its argument.
+func Identity(a bool) bool {
+	return a
+}
+
+// Function that returns !a.
+func Not(a bool) bool {
+	return !a
+}
+
+// Function that returns a && b.
+func And(a, b bool) bool {
+	return a && b
+}
+
+// Function that returns a || b.
+func Or(a, b bool) bool {
+	return a || b
+}
+
+// Function that returns a != b, the exclusive or of a and b.
+func Xor(a, b bool) bool {
+	return a != b
+}
+
+// Function that returns a && !b.
+func AndNot(a, b bool) bool {
+	return a && !b
+}
+
+// Constructs a function that returns the constant c.
+func Constant(c bool) BoolFunc {
+	return func(_ bool) bool {
+		return c
+	}
+}
+
+// Constructs a function that returns f(g(a)).
+func ChainUnary(f, g BoolFunc) BoolFunc {
+	return func(a bool) bool {
+		return f(g(a))
+	}
+}
+
+// Returns whether a and b are equal.
+func equals(a, b bool) bool {
+	return a == b
+}
@@ -0,0 +1,28 @@
+package tbool
+
+import "github.com/rwl/goshawk/common"
+
+// Interface for all boolean matrix backends.
+type Mat interface {
+	common.Mat
+
+	GetQuick(int, int) bool
+	SetQuick(int, int, bool)
+
+	Like(int, int) Mat
+	LikeVector(int) Vec
+
+	// Returns a vector view of size cells, the first at index zero of
+	// the elements and the others stride apart, sharing the elements of
+	// the receiver.
+	Like1D(size, zero, stride int) Vec
+
+	// Returns a new backend sharing the elements of the receiver whose
+	// shape may be modified independently.
+	View() Mat
+
+	// Returns a selection view sharing the elements of the receiver,
+	// where cell [r,c] of the view is the element at
+	// rowOffsets[r]+columnOffsets[c].
+	ViewSelectionLike(rowOffsets, columnOffsets []int) Mat
+}
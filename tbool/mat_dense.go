@@ -0,0 +1,49 @@
+package tbool
+
+import "github.com/rwl/goshawk/common"
+
+type DenseMat struct {
+	*common.CoreMat
+	elements []bool // The elements of this matrix.
+}
+
+func (m *DenseMat) GetQuick(row, column int) bool {
+	return m.elements[m.Index(row, column)]
+}
+
+func (m *DenseMat) SetQuick(row, column int, value bool) {
+	m.elements[m.Index(row, column)] = value
+}
+
+func (m *DenseMat) Elements() interface{} {
+	return m.elements
+}
+
+func (m *DenseMat) Like(rows, columns int) Mat {
+	return NewMatrix(rows, columns).Mat
+}
+
+func (m *DenseMat) LikeVector(size int) Vec {
+	return NewVector(size).Vec
+}
+
+func (m *DenseMat) Like1D(size, zero, stride int) Vec {
+	return &DenseVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.elements,
+	}
+}
+
+func (m *DenseMat) View() Mat {
+	return &DenseMat{m.CoreMat.View(), m.elements}
+}
+
+func (m *DenseMat) ViewSelectionLike(rowOffsets, columnOffsets []int) Mat {
+	return &SelectedDenseMat{
+		&DenseMat{
+			common.NewCoreMat(true, len(rowOffsets), len(columnOffsets), 1, 1, 0, 0),
+			m.elements,
+		},
+		rowOffsets, columnOffsets,
+	}
+}
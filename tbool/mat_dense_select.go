@@ -0,0 +1,62 @@
+package tbool
+
+import "github.com/rwl/goshawk/common"
+
+// Selection view on dense 2-d matrices holding bool elements.
+//
+// The row and column zeros and strides index into the offset arrays
+// rather than into the elements. Cell addressing overhead is 2
+// additional array index accesses per get/set.
+type SelectedDenseMat struct {
+	*DenseMat
+	rowOffsets    []int // The offsets of the visible rows of this matrix.
+	columnOffsets []int // The offsets of the visible columns of this matrix.
+}
+
+func (m *SelectedDenseMat) GetQuick(row, column int) bool {
+	return m.elements[m.Index(row, column)]
+}
+
+func (m *SelectedDenseMat) SetQuick(row, column int, value bool) {
+	m.elements[m.Index(row, column)] = value
+}
+
+func (m *SelectedDenseMat) Index(row, column int) int {
+	return m.rowOffsets[m.RowZero()+row*m.RowStride()] +
+		m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()]
+}
+
+func (m *SelectedDenseMat) View() Mat {
+	return &SelectedDenseMat{
+		&DenseMat{m.CoreMat.View(), m.elements},
+		m.rowOffsets, m.columnOffsets,
+	}
+}
+
+// Transposes the axes and their offsets.
+func (m *SelectedDenseMat) VDice() {
+	m.CoreMat.VDice()
+	m.rowOffsets, m.columnOffsets = m.columnOffsets, m.rowOffsets
+}
+
+// Constructs and returns a new selection view of the given row.
+func (m *SelectedDenseMat) ViewRow(row int) Vec {
+	return &SelectedDenseVec{
+		&DenseVec{
+			common.NewCoreVec(true, m.Columns(), m.ColumnZero(), m.ColumnStride()),
+			m.elements,
+		},
+		m.columnOffsets, m.rowOffsets[m.RowZero()+row*m.RowStride()],
+	}
+}
+
+// Constructs and returns a new selection view of the given column.
+func (m *SelectedDenseMat) ViewColumn(column int) Vec {
+	return &SelectedDenseVec{
+		&DenseVec{
+			common.NewCoreVec(true, m.Rows(), m.RowZero(), m.RowStride()),
+			m.elements,
+		},
+		m.rowOffsets, m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()],
+	}
+}
@@ -0,0 +1,54 @@
+package tbool
+
+import "github.com/rwl/goshawk/common"
+
+type SparseMat struct {
+	*common.CoreMat
+	elements map[int]bool // The true elements of this matrix.
+}
+
+func (m *SparseMat) GetQuick(row, column int) bool {
+	return m.elements[m.Index(row, column)]
+}
+
+func (m *SparseMat) SetQuick(row, column int, value bool) {
+	index := m.Index(row, column)
+	if !value {
+		delete(m.elements, index)
+	} else {
+		m.elements[index] = value
+	}
+}
+
+func (m *SparseMat) Elements() interface{} {
+	return m.elements
+}
+
+func (m *SparseMat) Like(rows, columns int) Mat {
+	return NewSparseMatrix(rows, columns).Mat
+}
+
+func (m *SparseMat) LikeVector(size int) Vec {
+	return NewSparseVector(size).Vec
+}
+
+func (m *SparseMat) Like1D(size, zero, stride int) Vec {
+	return &SparseVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.elements,
+	}
+}
+
+func (m *SparseMat) View() Mat {
+	return &SparseMat{m.CoreMat.View(), m.elements}
+}
+
+func (m *SparseMat) ViewSelectionLike(rowOffsets, columnOffsets []int) Mat {
+	return &SelectedSparseMat{
+		&SparseMat{
+			common.NewCoreMat(true, len(rowOffsets), len(columnOffsets), 1, 1, 0, 0),
+			m.elements,
+		},
+		rowOffsets, columnOffsets,
+	}
+}
@@ -0,0 +1,67 @@
+package tbool
+
+import "github.com/rwl/goshawk/common"
+
+// Selection view on sparse 2-d matrices holding bool elements.
+//
+// The row and column zeros and strides index into the offset arrays
+// rather than into the elements. Cell addressing overhead is 2
+// additional array index accesses per get/set.
+type SelectedSparseMat struct {
+	*SparseMat
+	rowOffsets    []int // The offsets of the visible rows of this matrix.
+	columnOffsets []int // The offsets of the visible columns of this matrix.
+}
+
+func (m *SelectedSparseMat) GetQuick(row, column int) bool {
+	return m.elements[m.Index(row, column)]
+}
+
+func (m *SelectedSparseMat) SetQuick(row, column int, value bool) {
+	index := m.Index(row, column)
+	if !value {
+		delete(m.elements, index)
+	} else {
+		m.elements[index] = value
+	}
+}
+
+func (m *SelectedSparseMat) Index(row, column int) int {
+	return m.rowOffsets[m.RowZero()+row*m.RowStride()] +
+		m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()]
+}
+
+func (m *SelectedSparseMat) View() Mat {
+	return &SelectedSparseMat{
+		&SparseMat{m.CoreMat.View(), m.elements},
+		m.rowOffsets, m.columnOffsets,
+	}
+}
+
+// Transposes the axes and their offsets.
+func (m *SelectedSparseMat) VDice() {
+	m.CoreMat.VDice()
+	m.rowOffsets, m.columnOffsets = m.columnOffsets, m.rowOffsets
+}
+
+// Constructs and returns a new selection view of the given row.
+func (m *SelectedSparseMat) ViewRow(row int) Vec {
+	return &SelectedSparseVec{
+		&SparseVec{
+			common.NewCoreVec(true, m.Columns(), m.ColumnZero(), m.ColumnStride()),
+			m.elements,
+		},
+		m.columnOffsets, m.rowOffsets[m.RowZero()+row*m.RowStride()],
+	}
+}
+
+// Constructs and returns a new selection view of the given column.
+func (m *SelectedSparseMat) ViewColumn(column int) Vec {
+	return &SelectedSparseVec{
+		&SparseVec{
+			common.NewCoreVec(true, m.Rows(), m.RowZero(), m.RowStride()),
+			m.elements,
+		},
+		m.rowOffsets, m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()],
+	}
+}
@@ -0,0 +1,27 @@
+package tbool
+
+import "testing"
+
+func makeDenseMatrix() *Matrix {
+	return fillMatrix(NewMatrix(nrows, ncols))
+}
+
+func TestDenseMatrixGetSet(t *testing.T) {
+	testMatrixGetSet(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixAssign(t *testing.T) {
+	testMatrixAssign(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixView(t *testing.T) {
+	testMatrixView(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixMask(t *testing.T) {
+	testMatrixMask(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixConvert(t *testing.T) {
+	testMatrixConvert(t, makeDenseMatrix())
+}
@@ -0,0 +1,47 @@
+package tbool
+
+import "github.com/rwl/goshawk/common"
+
+// Returns a new dense matrix with the given number of rows and columns.
+func NewMatrix(rows, columns int) *Matrix {
+	return &Matrix{
+		&DenseMat{
+			common.NewCoreMat(false, rows, columns, columns, 1, 0, 0),
+			make([]bool, rows*columns),
+		},
+	}
+}
+
+// Returns a new sparse matrix with the given number of rows and columns.
+func NewSparseMatrix(rows, columns int) *Matrix {
+	return &Matrix{
+		&SparseMat{
+			common.NewCoreMat(false, rows, columns, columns, 1, 0, 0),
+			make(map[int]bool),
+		},
+	}
+}
+
+// Returns a new dense matrix holding the values of the given array,
+// which must be rectangular.
+func NewMatrixArray(values [][]bool) (*Matrix, error) {
+	columns := 0
+	if len(values) > 0 {
+		columns = len(values[0])
+	}
+	m := NewMatrix(len(values), columns)
+	_, err := m.AssignArray(values)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Returns a new dense identity matrix of the given size.
+func NewIdentity(size int) *Matrix {
+	m := NewMatrix(size, size)
+	for i := 0; i < size; i++ {
+		m.SetQuick(i, i, true)
+	}
+	return m
+}
@@ -0,0 +1,27 @@
+package tbool
+
+import "testing"
+
+func makeSparseMatrix() *Matrix {
+	return fillMatrix(NewSparseMatrix(nrows, ncols))
+}
+
+func TestSparseMatrixGetSet(t *testing.T) {
+	testMatrixGetSet(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixAssign(t *testing.T) {
+	testMatrixAssign(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixView(t *testing.T) {
+	testMatrixView(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixMask(t *testing.T) {
+	testMatrixMask(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixConvert(t *testing.T) {
+	testMatrixConvert(t, makeSparseMatrix())
+}
@@ -0,0 +1,242 @@
+package tbool
+
+import (
+	"testing"
+
+	"github.com/rwl/goshawk/tfloat64"
+	"github.com/rwl/goshawk/tint"
+)
+
+func fillMatrix(A *Matrix) *Matrix {
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			A.SetQuick(r, c, randValue())
+		}
+	}
+	return A
+}
+
+func testMatrixGetSet(t *testing.T, A *Matrix) {
+	value := next(A.GetQuick(2, 3))
+	A.SetQuick(2, 3, value)
+	if a, err := A.Get(2, 3); err != nil || a != value {
+		t.Errorf("expected:%v actual:%v", value, a)
+	}
+	if err := A.Set(A.Rows(), 0, value); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if _, err := A.Get(0, -1); err == nil {
+		t.Error("expected column out of bounds error")
+	}
+	B := A.Copy()
+	if !B.EqualsMatrix(A) {
+		t.Error("expected copy to equal original")
+	}
+	B.SetQuick(0, 0, next(B.GetQuick(0, 0)))
+	if B.GetQuick(0, 0) == A.GetQuick(0, 0) {
+		t.Error("expected copy to be independent of original")
+	}
+}
+
+func testMatrixAssign(t *testing.T, A *Matrix) {
+	B := A.Copy()
+	A.AssignFunc(next)
+	if _, err := A.AssignMatrixFunc(B, Xor); err != nil {
+		t.Fatal(err)
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := Xor(next(B.GetQuick(r, c)), B.GetQuick(r, c))
+			if A.GetQuick(r, c) != expected {
+				t.Errorf("expected:%v actual:%v", expected, A.GetQuick(r, c))
+			}
+		}
+	}
+	values := B.ToArray()
+	if _, err := A.AssignArray(values); err != nil {
+		t.Fatal(err)
+	}
+	if !A.EqualsMatrix(B) {
+		t.Error("expected matrix assigned from array to equal original")
+	}
+	if _, err := A.AssignArray(values[1:]); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+	A.Assign(true)
+	if !A.Equals(true) {
+		t.Error("expected all cells to equal the assigned value")
+	}
+	if _, err := A.AssignMatrix(B.ViewDice()); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+	A.AssignMatrix(B)
+	if !A.AssignProcedure(Not, true).All() {
+		t.Error("expected false cells to be assigned true")
+	}
+	A.AssignMatrix(B)
+	if A.AssignProcedureFunc(Identity, Not).Any() {
+		t.Error("expected true cells to be negated")
+	}
+}
+
+func testMatrixView(t *testing.T, A *Matrix) {
+	P, err := A.ViewPart(2, 3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := next(A.GetQuick(5, 7))
+	P.SetQuick(3, 4, value)
+	if A.GetQuick(5, 7) != value {
+		t.Error("expected part view to share cells with matrix")
+	}
+	R, err := A.ViewRow(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	C, err := A.ViewColumn(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if R.GetQuick(7) != value || C.GetQuick(5) != value {
+		t.Error("expected row and column views to share cells with matrix")
+	}
+	if A.ViewDice().GetQuick(7, 5) != value {
+		t.Error("expected transposed view to share cells with matrix")
+	}
+	F := A.ViewRowFlip().ViewColumnFlip()
+	if F.GetQuick(0, 0) != A.GetQuick(A.Rows()-1, A.Columns()-1) {
+		t.Error("expected flipped view to reverse both axes")
+	}
+	S, err := A.ViewStrides(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Rows() != 7 || S.Columns() != 6 || S.GetQuick(3, 2) != A.GetQuick(6, 6) {
+		t.Errorf("unexpected strided view: %s", S.StringShort())
+	}
+	if _, err := A.ViewStrides(0, 1); err == nil {
+		t.Error("expected illegal strides error")
+	}
+	if _, err := A.ViewRow(A.Rows()); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if A.String() == "" {
+		t.Error("expected non-empty string")
+	}
+
+	S, err = A.ViewSelection([]int{5, 2, 5}, []int{7, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Rows() != 3 || S.Columns() != 2 || S.GetQuick(0, 0) != value || S.GetQuick(2, 0) != value {
+		t.Errorf("unexpected selection view: %s", S.StringShort())
+	}
+	value = next(A.GetQuick(2, 0))
+	S.SetQuick(1, 1, value)
+	if A.GetQuick(2, 0) != value {
+		t.Error("expected selection view to share cells with matrix")
+	}
+	if R, _ := S.ViewRow(1); R.GetQuick(1) != value {
+		t.Error("expected row of selection view to share cells with matrix")
+	}
+	if S.ViewDice().GetQuick(1, 1) != value {
+		t.Error("expected transposed selection view to share cells with matrix")
+	}
+	if T, _ := S.ViewSelection([]int{1}, nil); T.Columns() != 2 || T.GetQuick(0, 1) != value {
+		t.Error("expected selection of a selection view to share cells with matrix")
+	}
+	if _, err := A.ViewSelection(nil, []int{A.Columns()}); err == nil {
+		t.Error("expected column out of bounds error")
+	}
+	n := 0
+	for r := 0; r < A.Rows(); r++ {
+		if A.GetQuick(r, 0) == value {
+			n++
+		}
+	}
+	V := A.ViewSelectionProcedure(func(row Vec) bool { return row.GetQuick(0) == value })
+	if W, _ := V.ViewColumn(0); V.Rows() != n || V.Columns() != A.Columns() || !W.Equals(value) {
+		t.Errorf("unexpected selection view: %s", V.StringShort())
+	}
+	P, _ = A.ViewPart(0, 0, 5, 5)
+	p := []int{4, 2, 0, 1, 3}
+	B, err := P.ViewPermuted(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range p {
+		for j := range p {
+			if B.GetQuick(i, j) != P.GetQuick(p[i], p[j]) {
+				t.Errorf("expected:%v actual:%v", P.GetQuick(p[i], p[j]), B.GetQuick(i, j))
+			}
+		}
+	}
+	if _, err := P.ViewPermuted([]int{0, 0, 1, 2, 3}); err == nil {
+		t.Error("expected invalid permutation error")
+	}
+	if _, err := A.ViewPermuted(nil); err == nil {
+		t.Error("expected square matrix error")
+	}
+}
+
+func testMatrixMask(t *testing.T, A *Matrix) {
+	rowIndexes, columnIndexes := A.Coordinates()
+	if len(rowIndexes) != A.Cardinality() || len(columnIndexes) != A.Cardinality() {
+		t.Errorf("expected:%d actual:%d", A.Cardinality(), len(rowIndexes))
+	}
+	for k := range rowIndexes {
+		if !A.GetQuick(rowIndexes[k], columnIndexes[k]) {
+			t.Errorf("expected cell [%d,%d] to be true", rowIndexes[k], columnIndexes[k])
+		}
+	}
+	if A.Any() != A.Aggregate(Or, Identity) || A.All() != A.Aggregate(And, Identity) {
+		t.Error("expected Any and All to agree with aggregation")
+	}
+	B := A.Copy()
+	if _, err := B.AssignMatrixFunc(A, AndNot); err != nil {
+		t.Fatal(err)
+	}
+	if B.Any() {
+		t.Error("expected a and not a to be false for every cell")
+	}
+	if a, err := A.AggregateMatrix(A, Or, And); err != nil || a != A.Any() {
+		t.Errorf("expected:%v actual:%v", A.Any(), a)
+	}
+	if a, err := A.AggregateMatrix(B, Or, And); err != nil || a {
+		t.Errorf("expected:%v actual:%v", false, a)
+	}
+	if _, err := A.AggregateMatrix(A.ViewDice(), Or, And); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+	n := 0
+	A.ForEachNonZero(func(r, c int, a bool) bool {
+		n++
+		return !a
+	})
+	if n != len(rowIndexes) || A.Any() {
+		t.Errorf("expected:%d actual:%d", len(rowIndexes), n)
+	}
+	I := NewIdentity(A.Columns())
+	if I.Cardinality() != A.Columns() || I.EqualsMatrix(I.ViewRowFlip()) {
+		t.Error("expected identity mask to be true on the diagonal only")
+	}
+}
+
+func testMatrixConvert(t *testing.T, A *Matrix) {
+	X := A.Float64()
+	Y := A.Int()
+	if Y.ZSum() != A.Cardinality() {
+		t.Errorf("expected:%d actual:%d", A.Cardinality(), Y.ZSum())
+	}
+	_, sparse := A.Mat.(*SparseMat)
+	if _, ok := X.Mat.(*tfloat64.SparseMat); ok != sparse {
+		t.Error("expected converted matrix to keep the storage of the original")
+	}
+	nonZero := func(a float64) bool { return a != 0 }
+	if !NewSparseMatrixFloat64(X, nonZero).EqualsMatrix(A) || !NewMatrixInt(Y, tint.IsEqualTo(1)).EqualsMatrix(A) {
+		t.Error("expected round trip conversion to equal original")
+	}
+	if _, err := A.AssignFloat64(tfloat64.NewMatrix(1, 1).Mat, nonZero); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+}
@@ -0,0 +1,314 @@
+package tbool
+
+import (
+	"fmt"
+
+	"github.com/rwl/goshawk/common"
+)
+
+var fmtr = NewFormatter()
+
+// Vector of bool cells. It has the view, assign and aggregate API of the
+// floating point vectors, except Normalize, which has no meaning for
+// bool cells.
+type Vector struct {
+	Vec
+}
+
+// Returns a string representation using default formatting.
+func (v *Vector) String() string {
+	return fmtr.VectorToString(v)
+}
+
+// Returns the matrix cell value at coordinate "index".
+func (v *Vector) Get(index int) (bool, error) {
+	if index < 0 || index >= v.Size() {
+		return false, fmt.Errorf("Attempted to access %s at index=%d",
+			v.StringShort(), index)
+	}
+	return v.GetQuick(index), nil
+}
+
+// Sets the matrix cell at coordinate index to the specified value.
+func (v *Vector) Set(index int, value bool) error {
+	if index < 0 || index >= v.Size() {
+		return fmt.Errorf("Attempted to access %s at index=%d",
+			v.StringShort(), index)
+	}
+	v.SetQuick(index, value)
+	return nil
+}
+
+// Constructs and returns a deep copy of the receiver.
+func (v *Vector) Copy() *Vector {
+	copy := &Vector{v.Like(v.Size())}
+	copy.AssignVector(v)
+	return copy
+}
+
+// Constructs and returns a new view equal to the receiver. The view is a
+// shallow clone.
+func (v *Vector) ViewVector() *Vector {
+	return &Vector{v.ViewVec()}
+}
+
+// Returns the number of cells having true values.
+func (v *Vector) Cardinality() int {
+	cardinality := 0
+	for i := 0; i < v.Size(); i++ {
+		if v.GetQuick(i) {
+			cardinality++
+		}
+	}
+	return cardinality
+}
+
+// Returns whether all cells are equal to the given value.
+func (v *Vector) Equals(value bool) bool {
+	for i := 0; i < v.Size(); i++ {
+		if !equals(value, v.GetQuick(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether the receiver has the same size and the same values as
+// other.
+func (v *Vector) EqualsVector(other Vec) bool {
+	if v.Size() != other.Size() {
+		return false
+	}
+	for i := 0; i < v.Size(); i++ {
+		if !equals(v.GetQuick(i), other.GetQuick(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Sets all cells to the given value.
+func (v *Vector) Assign(value bool) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, value)
+	}
+	return v
+}
+
+// Assigns the result of a function to each cell; x[i] = f(x[i]).
+func (v *Vector) AssignFunc(f BoolFunc) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, f(v.GetQuick(i)))
+	}
+	return v
+}
+
+// Assigns the result of a function to all cells that satisfy a
+// condition.
+func (v *Vector) AssignProcedureFunc(cond BoolProcedure, f BoolFunc) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		if elem := v.GetQuick(i); cond(elem) {
+			v.SetQuick(i, f(elem))
+		}
+	}
+	return v
+}
+
+// Assigns a value to all cells that satisfy a condition.
+func (v *Vector) AssignProcedure(cond BoolProcedure, value bool) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		if cond(v.GetQuick(i)) {
+			v.SetQuick(i, value)
+		}
+	}
+	return v
+}
+
+// Sets all cells to the values of the given array, which must have the
+// same size as the receiver.
+func (v *Vector) AssignArray(values []bool) (*Vector, error) {
+	if len(values) != v.Size() {
+		return v, fmt.Errorf("Must have same number of cells: length=%d, size=%d",
+			len(values), v.Size())
+	}
+	for i, value := range values {
+		v.SetQuick(i, value)
+	}
+	return v, nil
+}
+
+// Replaces all cell values of the receiver with the values of other,
+// which must have the same size.
+func (v *Vector) AssignVector(other Vec) (*Vector, error) {
+	err := v.checkSize(other)
+	if err != nil {
+		return v, err
+	}
+	if o, ok := other.(*Vector); ok {
+		other = o.Vec
+	}
+	if other == v.Vec {
+		return v, nil
+	}
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, other.GetQuick(i))
+	}
+	return v, nil
+}
+
+// Assigns the result of a function to each cell;
+// x[i] = f(x[i], y[i]).
+func (v *Vector) AssignVectorFunc(y Vec, f BoolBoolFunc) (*Vector, error) {
+	err := v.checkSize(y)
+	if err != nil {
+		return v, err
+	}
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, f(v.GetQuick(i), y.GetQuick(i)))
+	}
+	return v, nil
+}
+
+// Applies a function to each cell and aggregates the results. Returns a
+// value v such that v==a(Size()) where
+// a(i) == aggr( a(i-1), f(get(i)) ) and terminators are
+// a(1) == f(get(0)), a(0)==false.
+func (v *Vector) Aggregate(aggr BoolBoolFunc, f BoolFunc) bool {
+	if v.Size() == 0 {
+		return false
+	}
+	a := f(v.GetQuick(0))
+	for i := 1; i < v.Size(); i++ {
+		a = aggr(a, f(v.GetQuick(i)))
+	}
+	return a
+}
+
+// Applies a function to each corresponding cell of the receiver and
+// other, which must have the same size, and aggregates the results;
+// e.g. whether the vectors share a true cell with
+// AggregateVector(other, Or, And).
+func (v *Vector) AggregateVector(other Vec, aggr, f BoolBoolFunc) (bool, error) {
+	err := v.checkSize(other)
+	if err != nil {
+		return false, err
+	}
+	if v.Size() == 0 {
+		return false, nil
+	}
+	a := f(v.GetQuick(0), other.GetQuick(0))
+	for i := 1; i < v.Size(); i++ {
+		a = aggr(a, f(v.GetQuick(i), other.GetQuick(i)))
+	}
+	return a, nil
+}
+
+// Constructs and returns a 1-dimensional array containing the cell
+// values.
+func (v *Vector) ToArray() []bool {
+	values := make([]bool, v.Size())
+	for i := range values {
+		values[i] = v.GetQuick(i)
+	}
+	return values
+}
+
+// Constructs and returns a new flip view. What used to be index 0 is
+// now index Size()-1, ..., what used to be index Size()-1 is now
+// index 0.
+func (v *Vector) ViewFlip() *Vector {
+	view := v.ViewVector()
+	view.VFlip()
+	return view
+}
+
+// Constructs and returns a new view of the width cells starting at
+// index.
+func (v *Vector) ViewPart(index, width int) (*Vector, error) {
+	view := v.ViewVector()
+	err := view.VPart(index, width)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// Constructs and returns a new view of every stride-th cell.
+func (v *Vector) ViewStrides(stride int) (*Vector, error) {
+	view := v.ViewVector()
+	err := view.VStrides(stride)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// Constructs and returns a new selection view holding the indicated
+// cells, with view.Get(i) == v.Get(indexes[i]). Indexes can occur
+// multiple times and can be in arbitrary order; nil selects all cells.
+// The view shares the cells of the receiver; modifying indexes after the
+// call has no effect on the view.
+func (v *Vector) View(indexes []int) (*Vector, error) {
+	indexes, err := selectionIndexes(indexes, v.Size(), "index")
+	if err != nil {
+		return nil, fmt.Errorf("Attempted to access %s at %v", v.StringShort(), err)
+	}
+	offsets := make([]int, len(indexes))
+	for i, idx := range indexes {
+		offsets[i] = v.Index(idx)
+	}
+	return &Vector{v.ViewSelectionLike(offsets)}, nil
+}
+
+// Constructs and returns a new selection view holding the cells for
+// which condition yields true.
+func (v *Vector) ViewProcedure(condition BoolProcedure) *Vector {
+	matches := make([]int, 0)
+	for i := 0; i < v.Size(); i++ {
+		if condition(v.GetQuick(i)) {
+			matches = append(matches, i)
+		}
+	}
+	view, _ := v.View(matches)
+	return view
+}
+
+func (v *Vector) checkSize(other common.Vec) error {
+	if v.Size() != other.Size() {
+		return fmt.Errorf("Incompatible sizes: %s and %s",
+			v.StringShort(), common.VectorShape(other))
+	}
+	return nil
+}
+
+// Returns whether any cell is true.
+func (v *Vector) Any() bool {
+	for i := 0; i < v.Size(); i++ {
+		if v.GetQuick(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns whether all cells are true. An empty vector is all true.
+func (v *Vector) All() bool {
+	for i := 0; i < v.Size(); i++ {
+		if !v.GetQuick(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the indexes of the true cells in ascending order, e.g. for use
+// with the index selection views of other vectors.
+func (v *Vector) Indexes() []int {
+	indexes := make([]int, 0, v.Cardinality())
+	for i := 0; i < v.Size(); i++ {
+		if v.GetQuick(i) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,205 @@
+package tbool
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/rwl/goshawk/tfloat64"
+	"github.com/rwl/goshawk/tint"
+)
+
+const (
+	size  = 2*17 + 1
+	nrows = 13
+	ncols = 17
+)
+
+func randValue() bool {
+	return rand.Intn(2) == 0
+}
+
+// Returns a value different from a.
+func next(a bool) bool {
+	return !a
+}
+
+func fillVector(v *Vector) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, randValue())
+	}
+	return v
+}
+
+func testVectorGetSet(t *testing.T, A *Vector) {
+	value := next(A.GetQuick(3))
+	A.SetQuick(3, value)
+	if a, err := A.Get(3); err != nil || a != value {
+		t.Errorf("expected:%v actual:%v", value, a)
+	}
+	if err := A.Set(A.Size(), value); err == nil {
+		t.Error("expected index out of bounds error")
+	}
+	if _, err := A.Get(-1); err == nil {
+		t.Error("expected index out of bounds error")
+	}
+	B := A.Copy()
+	if !B.EqualsVector(A) {
+		t.Error("expected copy to equal original")
+	}
+	B.SetQuick(0, next(B.GetQuick(0)))
+	if B.GetQuick(0) == A.GetQuick(0) {
+		t.Error("expected copy to be independent of original")
+	}
+}
+
+func testVectorAssign(t *testing.T, A *Vector) {
+	B := A.Copy()
+	A.AssignFunc(next)
+	for i := 0; i < A.Size(); i++ {
+		if A.GetQuick(i) != next(B.GetQuick(i)) {
+			t.Errorf("expected:%v actual:%v", next(B.GetQuick(i)), A.GetQuick(i))
+		}
+	}
+	if _, err := A.AssignVectorFunc(B, Xor); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < A.Size(); i++ {
+		expected := Xor(next(B.GetQuick(i)), B.GetQuick(i))
+		if A.GetQuick(i) != expected {
+			t.Errorf("expected:%v actual:%v", expected, A.GetQuick(i))
+		}
+	}
+	if _, err := A.AssignArray(B.ToArray()); err != nil {
+		t.Fatal(err)
+	}
+	if !A.EqualsVector(B) {
+		t.Error("expected vector assigned from array to equal original")
+	}
+	A.Assign(true)
+	if !A.Equals(true) {
+		t.Error("expected all cells to equal the assigned value")
+	}
+	if _, err := A.AssignArray(make([]bool, A.Size()+1)); err == nil {
+		t.Error("expected size mismatch error")
+	}
+	if _, err := A.AssignVector(NewVector(1)); err == nil {
+		t.Error("expected size mismatch error")
+	}
+	A.AssignVector(B)
+	if !A.AssignProcedure(Not, true).All() {
+		t.Error("expected false cells to be assigned true")
+	}
+	A.AssignVector(B)
+	if A.AssignProcedureFunc(Identity, Not).Any() {
+		t.Error("expected true cells to be negated")
+	}
+}
+
+func testVectorView(t *testing.T, A *Vector) {
+	F := A.ViewFlip()
+	if F.GetQuick(0) != A.GetQuick(A.Size()-1) {
+		t.Errorf("expected:%v actual:%v", A.GetQuick(A.Size()-1), F.GetQuick(0))
+	}
+	P, err := A.ViewPart(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := next(A.GetQuick(7))
+	P.SetQuick(4, value)
+	if A.GetQuick(7) != value {
+		t.Error("expected part view to share cells with vector")
+	}
+	S, err := A.ViewStrides(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Size() != (A.Size()+1)/2 || S.GetQuick(3) != A.GetQuick(6) {
+		t.Error("expected strided view of every second cell")
+	}
+	if _, err := A.ViewPart(A.Size()-1, 2); err == nil {
+		t.Error("expected range error")
+	}
+	if A.String() == "" {
+		t.Error("expected non-empty string")
+	}
+	V, err := A.View([]int{7, 2, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if V.Size() != 3 || V.GetQuick(0) != value || V.GetQuick(2) != value {
+		t.Errorf("unexpected selection view: %s", V.StringShort())
+	}
+	value = next(A.GetQuick(2))
+	V.ViewFlip().SetQuick(1, value)
+	if A.GetQuick(2) != value {
+		t.Error("expected selection view to share cells with vector")
+	}
+	if W, _ := V.View([]int{1}); W.GetQuick(0) != value {
+		t.Error("expected selection of a selection view to share cells with vector")
+	}
+	if _, err := A.View([]int{A.Size()}); err == nil {
+		t.Error("expected index out of bounds error")
+	}
+	n := 0
+	for i := 0; i < A.Size(); i++ {
+		if A.GetQuick(i) == value {
+			n++
+		}
+	}
+	W := A.ViewProcedure(func(a bool) bool { return a == value })
+	if W.Size() != n || !W.Equals(value) {
+		t.Errorf("unexpected selection view: %s", W.StringShort())
+	}
+}
+
+func testVectorMask(t *testing.T, A *Vector) {
+	indexes := A.Indexes()
+	if len(indexes) != A.Cardinality() {
+		t.Errorf("expected:%d actual:%d", A.Cardinality(), len(indexes))
+	}
+	for _, i := range indexes {
+		if !A.GetQuick(i) {
+			t.Errorf("expected cell %d to be true", i)
+		}
+	}
+	if A.Any() != A.Aggregate(Or, Identity) || A.All() != A.Aggregate(And, Identity) {
+		t.Error("expected Any and All to agree with aggregation")
+	}
+	B := A.Copy()
+	if _, err := B.AssignVectorFunc(A.Copy().AssignFunc(Not), Or); err != nil {
+		t.Fatal(err)
+	}
+	if !B.All() || B.AssignFunc(Not).Any() {
+		t.Error("expected a or !a to hold for every cell")
+	}
+	if a, err := A.AggregateVector(A, Or, And); err != nil || a != A.Any() {
+		t.Errorf("expected:%v actual:%v", A.Any(), a)
+	}
+	if a, err := A.AggregateVector(B, Or, And); err != nil || a {
+		t.Errorf("expected:%v actual:%v", false, a)
+	}
+	if _, err := A.AggregateVector(NewVector(1), Or, And); err == nil {
+		t.Error("expected size mismatch error")
+	}
+}
+
+func testVectorConvert(t *testing.T, A *Vector) {
+	x := A.Float64()
+	y := A.Int()
+	for i := 0; i < A.Size(); i++ {
+		if (x.GetQuick(i) == 1) != A.GetQuick(i) || (y.GetQuick(i) == 1) != A.GetQuick(i) {
+			t.Errorf("expected:%t actual:%g, %d", A.GetQuick(i), x.GetQuick(i), y.GetQuick(i))
+		}
+	}
+	_, sparse := A.Vec.(*SparseVec)
+	if _, ok := x.Vec.(*tfloat64.SparseVec); ok != sparse {
+		t.Error("expected converted vector to keep the storage of the original")
+	}
+	nonZero := func(a float64) bool { return a != 0 }
+	if !NewVectorFloat64(x, nonZero).EqualsVector(A) || !NewSparseVectorInt(y, tint.IsGreaterThan(0)).EqualsVector(A) {
+		t.Error("expected round trip conversion to equal original")
+	}
+	if _, err := A.AssignInt(tint.NewVector(1), tint.IsEqualTo(1)); err == nil {
+		t.Error("expected size mismatch error")
+	}
+}
@@ -0,0 +1,21 @@
+package tcomplex128
+
+import "github.com/rwl/goshawk/common"
+
+// Interface for all complex cube backends.
+type Cub interface {
+	common.Cub
+
+	GetQuick(int, int, int) complex128
+	SetQuick(int, int, int, complex128)
+
+	Like(int, int, int) Cub
+
+	// Returns a rows x columns matrix view sharing the elements of the
+	// receiver, with the given zeros and strides into the elements.
+	Like2D(rows, columns, rowZero, columnZero, rowStride, columnStride int) Mat
+
+	// Returns a new backend sharing the elements of the receiver whose
+	// shape may be modified independently.
+	View() Cub
+}
@@ -0,0 +1,19 @@
+package tcomplex128
+
+import "testing"
+
+func makeDenseCube() *Cube {
+	return fillCube(NewCube(nslices, nrows, ncols))
+}
+
+func TestDenseCubeGetSet(t *testing.T) {
+	testCubeGetSet(t, makeDenseCube())
+}
+
+func TestDenseCubeAssign(t *testing.T) {
+	testCubeAssign(t, makeDenseCube())
+}
+
+func TestDenseCubeView(t *testing.T) {
+	testCubeView(t, makeDenseCube())
+}
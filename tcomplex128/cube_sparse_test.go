@@ -0,0 +1,19 @@
+package tcomplex128
+
+import "testing"
+
+func makeSparseCube() *Cube {
+	return fillCube(NewSparseCube(nslices, nrows, ncols))
+}
+
+func TestSparseCubeGetSet(t *testing.T) {
+	testCubeGetSet(t, makeSparseCube())
+}
+
+func TestSparseCubeAssign(t *testing.T) {
+	testCubeAssign(t, makeSparseCube())
+}
+
+func TestSparseCubeView(t *testing.T) {
+	testCubeView(t, makeSparseCube())
+}
@@ -0,0 +1,113 @@
+package tcomplex128
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+const nslices = 5
+
+func fillCube(A *Cube) *Cube {
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				A.SetQuick(s, r, c, randComplex())
+			}
+		}
+	}
+	return A
+}
+
+func testCubeGetSet(t *testing.T, A *Cube) {
+	A.SetQuick(1, 2, 3, 4i)
+	if a, err := A.Get(1, 2, 3); err != nil || a != 4i {
+		t.Errorf("expected:%v actual:%v", 4i, a)
+	}
+	if err := A.Set(A.Slices(), 0, 0, 1); err == nil {
+		t.Error("expected slice out of bounds error")
+	}
+	B := A.Copy()
+	if !B.EqualsCube(A) {
+		t.Error("expected copy to equal original")
+	}
+	if B.Cardinality() != B.Size() {
+		t.Errorf("expected:%d actual:%d", B.Size(), B.Cardinality())
+	}
+	B.AssignFunc(Conj)
+	if B.GetQuick(1, 2, 3) != -4i || A.GetQuick(1, 2, 3) != 4i {
+		t.Error("expected copy to be independent of original")
+	}
+}
+
+func testCubeAssign(t *testing.T, A *Cube) {
+	B := A.Copy().AssignFunc(Conj)
+	if _, err := B.AssignCubeFunc(A, Plus); err != nil {
+		t.Fatal(err)
+	}
+	var expected complex128
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				a := A.GetQuick(s, r, c)
+				expected += a
+				if B.GetQuick(s, r, c) != complex(2*real(a), 0) {
+					t.Errorf("expected:%v actual:%v", complex(2*real(a), 0), B.GetQuick(s, r, c))
+				}
+			}
+		}
+	}
+	if cmplx.Abs(A.ZSum()-expected) > tol {
+		t.Errorf("expected:%v actual:%v", expected, A.ZSum())
+	}
+	if _, err := B.AssignArray(A.ToArray()); err != nil {
+		t.Fatal(err)
+	}
+	if !B.EqualsCube(A) {
+		t.Error("expected cube assigned from array to equal original")
+	}
+	B.Assign(2i)
+	if !B.Equals(2i) {
+		t.Error("expected all cells to equal the assigned value")
+	}
+	if _, err := B.AssignCube(NewCube(1, 1, 1)); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+}
+
+func testCubeView(t *testing.T, A *Cube) {
+	S, err := A.ViewSlice(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	S.SetQuick(4, 5, 1-1i)
+	if A.GetQuick(3, 4, 5) != 1-1i {
+		t.Error("expected slice view to share cells with cube")
+	}
+	D, err := A.ViewDice(2, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if D.GetQuick(5, 3, 4) != 1-1i {
+		t.Errorf("expected:%v actual:%v", 1-1i, D.GetQuick(5, 3, 4))
+	}
+	P, err := A.ViewPart(1, 2, 3, 3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if P.GetQuick(2, 2, 2) != 1-1i {
+		t.Errorf("expected:%v actual:%v", 1-1i, P.GetQuick(2, 2, 2))
+	}
+	T, err := A.ViewStrides(3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if T.GetQuick(1, 1, 1) != 1-1i {
+		t.Errorf("expected:%v actual:%v", 1-1i, T.GetQuick(1, 1, 1))
+	}
+	if _, err := A.ViewSlice(A.Slices()); err == nil {
+		t.Error("expected slice out of bounds error")
+	}
+	if A.String() == "" {
+		t.Error("expected non-empty string")
+	}
+}
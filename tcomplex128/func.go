@@ -0,0 +1,155 @@
+package tcomplex128
+
+import (
+	"math/cmplx"
+)
+
+type Complex128Func func(complex128) complex128
+
+type Complex128Complex128Func func(complex128, complex128) complex128
+
+type Complex128Procedure func(complex128) bool
+
+type Complex128RealFunc func(complex128) float64
+
+type IntIntComplex128Func func(int, int, complex128) complex128
+
+// Function that returns the complex conjugate of a.
+func Conj(a complex128) complex128 {
+	return cmplx.Conj(a)
+}
+
+// Function that returns the absolute value (modulus) of a.
+func Abs(a complex128) float64 {
+	return cmplx.Abs(a)
+}
+
+// Function that returns the argument (phase) of a, in the range
+// [-Pi, Pi].
+func Arg(a complex128) float64 {
+	return cmplx.Phase(a)
+}
+
+// Function that returns the real part of a.
+func Real(a complex128) float64 {
+	return real(a)
+}
+
+// Function that returns the imaginary part of a.
+func Imag(a complex128) float64 {
+	return imag(a)
+}
+
+// Function that returns its argument.
+func Identity(a complex128) complex128 {
+	return a
+}
+
+// Function that returns -a.
+func Neg(a complex128) complex128 {
+	return -a
+}
+
+// Function that returns 1 / a.
+func Inv(a complex128) complex128 {
+	return 1 / a
+}
+
+// Function that returns a * a.
+func Square(a complex128) complex128 {
+	return a * a
+}
+
+// Function that returns the principal square root of a.
+func Sqrt(a complex128) complex128 {
+	return cmplx.Sqrt(a)
+}
+
+// Function that returns e^a.
+func Exp(a complex128) complex128 {
+	return cmplx.Exp(a)
+}
+
+// Function that returns the natural logarithm of a.
+func Log(a complex128) complex128 {
+	return cmplx.Log(a)
+}
+
+// Function that returns a + b.
+func Plus(a, b complex128) complex128 {
+	return a + b
+}
+
+// Function that returns a - b.
+func Minus(a, b complex128) complex128 {
+	return a - b
+}
+
+// Function that returns a * b.
+func Mult(a, b complex128) complex128 {
+	return a * b
+}
+
+// Function that returns a / b.
+func Div(a, b complex128) complex128 {
+	return a / b
+}
+
+// Function that returns a * conj(b).
+func MultConj(a, b complex128) complex128 {
+	return a * cmplx.Conj(b)
+}
+
+// Constructs a function that returns a + b. a is a
+// variable, b is fixed.
+func Add(b complex128) Complex128Func {
+	return func(a complex128) complex128 {
+		return a + b
+	}
+}
+
+// Constructs a function that returns a * b. a is a
+// variable, b is fixed.
+func Multiply(b complex128) Complex128Func {
+	return func(a complex128) complex128 {
+		return a * b
+	}
+}
+
+// Constructs a function that returns a / b. a is a
+// variable, b is fixed.
+func Divide(b complex128) Complex128Func {
+	return func(a complex128) complex128 {
+		return a / b
+	}
+}
+
+// Constructs a function that returns a^b. a is a variable,
+// b is fixed.
+func Pow(b complex128) Complex128Func {
+	return func(a complex128) complex128 {
+		return cmplx.Pow(a, b)
+	}
+}
+
+// Constructs a function that returns the constant c.
+func Constant(c complex128) Complex128Func {
+	return func(_ complex128) complex128 {
+		return c
+	}
+}
+
+// Constructs a function that returns a == b. a is a
+// variable, b is fixed.
+func IsEqualTo(b complex128) Complex128Procedure {
+	return func(a complex128) bool {
+		return a == b
+	}
+}
+
+// Constructs a function that returns f(g(a)).
+func ChainUnary(f, g Complex128Func) Complex128Func {
+	return func(a complex128) complex128 {
+		return f(g(a))
+	}
+}
@@ -0,0 +1,31 @@
+package tcomplex128
+
+import (
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+// Interface for all complex matrix backends.
+type Mat interface {
+	common.Mat
+
+	GetQuick(int, int) complex128
+	SetQuick(int, int, complex128)
+
+	Like(int, int) Mat
+	LikeVector(int) Vec
+
+	// Returns a vector view of size cells, the first at index zero of
+	// the elements and the others stride apart, sharing the elements of
+	// the receiver.
+	Like1D(size, zero, stride int) Vec
+
+	// Returns a new backend sharing the elements of the receiver whose
+	// shape may be modified independently.
+	View() Mat
+
+	// Returns views of the real and imaginary parts of the receiver
+	// that share its elements.
+	ViewRealMat() tfloat64.Mat
+	ViewImagMat() tfloat64.Mat
+}
@@ -0,0 +1,50 @@
+package tcomplex128
+
+import (
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+type DenseMat struct {
+	*common.CoreMat
+	elements []complex128 // The elements of this matrix.
+}
+
+func (m *DenseMat) GetQuick(row, column int) complex128 {
+	return m.elements[m.Index(row, column)]
+}
+
+func (m *DenseMat) SetQuick(row, column int, value complex128) {
+	m.elements[m.Index(row, column)] = value
+}
+
+func (m *DenseMat) Elements() interface{} {
+	return m.elements
+}
+
+func (m *DenseMat) Like(rows, columns int) Mat {
+	return NewMatrix(rows, columns).Mat
+}
+
+func (m *DenseMat) LikeVector(size int) Vec {
+	return NewVector(size).Vec
+}
+
+func (m *DenseMat) Like1D(size, zero, stride int) Vec {
+	return &DenseVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.elements,
+	}
+}
+
+func (m *DenseMat) View() Mat {
+	return &DenseMat{m.CoreMat.View(), m.elements}
+}
+
+func (m *DenseMat) ViewRealMat() tfloat64.Mat {
+	return &densePartMat{m.CoreMat.View(), m.elements, false}
+}
+
+func (m *DenseMat) ViewImagMat() tfloat64.Mat {
+	return &densePartMat{m.CoreMat.View(), m.elements, true}
+}
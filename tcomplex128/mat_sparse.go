@@ -0,0 +1,55 @@
+package tcomplex128
+
+import (
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+type SparseMat struct {
+	*common.CoreMat
+	elements map[int]complex128 // The elements of this matrix.
+}
+
+func (m *SparseMat) GetQuick(row, column int) complex128 {
+	return m.elements[m.Index(row, column)]
+}
+
+func (m *SparseMat) SetQuick(row, column int, value complex128) {
+	index := m.Index(row, column)
+	if value == 0 {
+		delete(m.elements, index)
+	} else {
+		m.elements[index] = value
+	}
+}
+
+func (m *SparseMat) Elements() interface{} {
+	return m.elements
+}
+
+func (m *SparseMat) Like(rows, columns int) Mat {
+	return NewSparseMatrix(rows, columns).Mat
+}
+
+func (m *SparseMat) LikeVector(size int) Vec {
+	return NewSparseVector(size).Vec
+}
+
+func (m *SparseMat) Like1D(size, zero, stride int) Vec {
+	return &SparseVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.elements,
+	}
+}
+
+func (m *SparseMat) View() Mat {
+	return &SparseMat{m.CoreMat.View(), m.elements}
+}
+
+func (m *SparseMat) ViewRealMat() tfloat64.Mat {
+	return &sparsePartMat{m.CoreMat.View(), m.elements, false}
+}
+
+func (m *SparseMat) ViewImagMat() tfloat64.Mat {
+	return &sparsePartMat{m.CoreMat.View(), m.elements, true}
+}
@@ -0,0 +1,406 @@
+package tcomplex128
+
+import (
+	"errors"
+	"fmt"
+	"math/cmplx"
+
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+type Matrix struct {
+	Mat
+}
+
+// Returns a string representation using default formatting.
+func (m *Matrix) String() string {
+	return fmtr.MatrixToString(m)
+}
+
+// Returns the matrix cell value at coordinate [row,column].
+func (m *Matrix) Get(row, column int) (complex128, error) {
+	if column < 0 || column >= m.Columns() || row < 0 || row >= m.Rows() {
+		return cmplx.NaN(), fmt.Errorf("row:%d, column:%d", row, column)
+	}
+	return m.GetQuick(row, column), nil
+}
+
+// Sets the matrix cell at coordinate [row,column] to the specified value.
+func (m *Matrix) Set(row, column int, value complex128) error {
+	if column < 0 || column >= m.Columns() || row < 0 || row >= m.Rows() {
+		return fmt.Errorf("row:%d, column:%d", row, column)
+	}
+	m.SetQuick(row, column, value)
+	return nil
+}
+
+// Constructs and returns a deep copy of the receiver.
+func (m *Matrix) Copy() *Matrix {
+	copy := &Matrix{m.Like(m.Rows(), m.Columns())}
+	copy.AssignMatrix(m)
+	return copy
+}
+
+// Returns the number of cells having non-zero values; ignores tolerance.
+func (m *Matrix) Cardinality() int {
+	cardinality := 0
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			if m.GetQuick(r, c) != 0 {
+				cardinality++
+			}
+		}
+	}
+	return cardinality
+}
+
+// Returns whether all cells are equal to the given value, within the
+// default tolerance.
+func (m *Matrix) Equals(value complex128) bool {
+	return prop.MatrixEqualsValue(m, value)
+}
+
+// Returns whether the receiver has the same shape and, within the default
+// tolerance, the same values as other.
+func (m *Matrix) EqualsMatrix(other Mat) bool {
+	return prop.MatrixEqualsMatrix(m, other)
+}
+
+// Constructs and returns a 2-dimensional array containing the cell
+// values, indexed [row][column].
+func (m *Matrix) ToArray() [][]complex128 {
+	values := make([][]complex128, m.Rows())
+	for r := range values {
+		values[r] = make([]complex128, m.Columns())
+		for c := range values[r] {
+			values[r][c] = m.GetQuick(r, c)
+		}
+	}
+	return values
+}
+
+// Sets all cells to the given value.
+func (m *Matrix) Assign(value complex128) *Matrix {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, value)
+		}
+	}
+	return m
+}
+
+// Assigns the result of a function to each cell; x[row,col] =
+// f(x[row,col]).
+func (m *Matrix) AssignFunc(f Complex128Func) *Matrix {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, f(m.GetQuick(r, c)))
+		}
+	}
+	return m
+}
+
+// Sets all cells to the values of the given array, indexed
+// [row][column], which must have the same shape as the receiver.
+func (m *Matrix) AssignArray(values [][]complex128) (*Matrix, error) {
+	if len(values) != m.Rows() {
+		return m, fmt.Errorf("Must have same number of rows: rows=%d rows()=%d",
+			len(values), m.Rows())
+	}
+	for r, row := range values {
+		if len(row) != m.Columns() {
+			return m, fmt.Errorf("Must have same number of columns in every row: columns=%d columns()=%d",
+				len(row), m.Columns())
+		}
+		for c, value := range row {
+			m.SetQuick(r, c, value)
+		}
+	}
+	return m, nil
+}
+
+// Replaces all cell values of the receiver with the values of other,
+// which must have the same shape.
+func (m *Matrix) AssignMatrix(other Mat) (*Matrix, error) {
+	err := m.checkShape(other)
+	if err != nil {
+		return m, err
+	}
+	if o, ok := other.(*Matrix); ok {
+		other = o.Mat
+	}
+	if other == m.Mat {
+		return m, nil
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, other.GetQuick(r, c))
+		}
+	}
+	return m, nil
+}
+
+// Assigns the result of a function to each cell;
+// x[row,col] = f(x[row,col], y[row,col]).
+func (m *Matrix) AssignMatrixFunc(y Mat, f Complex128Complex128Func) (*Matrix, error) {
+	err := m.checkShape(y)
+	if err != nil {
+		return m, err
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, f(m.GetQuick(r, c), y.GetQuick(r, c)))
+		}
+	}
+	return m, nil
+}
+
+// Sets the real parts of the cells to the values of re and the imaginary
+// parts to the values of im. im may be nil to set the imaginary parts to
+// zero.
+func (m *Matrix) AssignRealImag(re, im tfloat64.Mat) (*Matrix, error) {
+	err := m.checkShape(re)
+	if err != nil {
+		return m, err
+	}
+	if im != nil {
+		err = m.checkShape(im)
+		if err != nil {
+			return m, err
+		}
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			value := complex(re.GetQuick(r, c), 0)
+			if im != nil {
+				value += complex(0, im.GetQuick(r, c))
+			}
+			m.SetQuick(r, c, value)
+		}
+	}
+	return m, nil
+}
+
+// Applies a function to each cell and aggregates the results, in row
+// major order.
+func (m *Matrix) Aggregate(aggr Complex128Complex128Func, f Complex128Func) complex128 {
+	if m.Size() == 0 {
+		return 0
+	}
+	a := f(m.GetQuick(0, 0))
+	d := 1 // First cell already done.
+	for r := 0; r < m.Rows(); r++ {
+		for c := d; c < m.Columns(); c++ {
+			a = aggr(a, f(m.GetQuick(r, c)))
+		}
+		d = 0
+	}
+	return a
+}
+
+// Returns the sum of all cells; Sum( x[i,j] ).
+func (m *Matrix) ZSum() complex128 {
+	return m.Aggregate(Plus, Identity)
+}
+
+// Returns a view of the real parts of the cells. Setting a cell of the
+// view sets the real part of the corresponding cell of the receiver.
+func (m *Matrix) ViewReal() *tfloat64.Matrix {
+	return &tfloat64.Matrix{Mat: m.ViewRealMat()}
+}
+
+// Returns a view of the imaginary parts of the cells. Setting a cell of
+// the view sets the imaginary part of the corresponding cell of the
+// receiver.
+func (m *Matrix) ViewImag() *tfloat64.Matrix {
+	return &tfloat64.Matrix{Mat: m.ViewImagMat()}
+}
+
+// Returns new real matrices holding copies of the real and imaginary
+// parts of the cells. The matrices are sparse if the receiver is.
+func (m *Matrix) RealImag() (*tfloat64.Matrix, *tfloat64.Matrix) {
+	return m.RealFunc(Real), m.RealFunc(Imag)
+}
+
+// Returns a new real matrix holding f applied to each cell, e.g. Abs or
+// Arg.
+func (m *Matrix) RealFunc(f Complex128RealFunc) *tfloat64.Matrix {
+	A := &tfloat64.Matrix{Mat: m.ViewRealMat().Like(m.Rows(), m.Columns())}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			A.SetQuick(r, c, f(m.GetQuick(r, c)))
+		}
+	}
+	return A
+}
+
+// Returns a new real matrix holding the absolute value of each cell.
+func (m *Matrix) Abs() *tfloat64.Matrix {
+	return m.RealFunc(Abs)
+}
+
+// Returns a new real matrix holding the argument of each cell.
+func (m *Matrix) Arg() *tfloat64.Matrix {
+	return m.RealFunc(Arg)
+}
+
+// Returns a new matrix which is the conjugate (Hermitian) transpose of
+// the receiver; B[i,j] == conj(A[j,i]).
+func (m *Matrix) ConjugateTranspose() *Matrix {
+	return m.ViewDice().Copy().AssignFunc(Conj)
+}
+
+// Constructs and returns a new view of the given column. The view shares
+// the cells of the receiver.
+func (m *Matrix) ViewColumn(column int) (*Vector, error) {
+	if column < 0 || column >= m.Columns() {
+		return nil, fmt.Errorf("Attempted to access %s at column=%d", m.StringShort(), column)
+	}
+	return &Vector{m.Like1D(m.Rows(), m.Index(0, column), m.RowStride())}, nil
+}
+
+// Constructs and returns a new view of the given row. The view shares
+// the cells of the receiver.
+func (m *Matrix) ViewRow(row int) (*Vector, error) {
+	if row < 0 || row >= m.Rows() {
+		return nil, fmt.Errorf("Attempted to access %s at row=%d", m.StringShort(), row)
+	}
+	return &Vector{m.Like1D(m.Columns(), m.Index(row, 0), m.ColumnStride())}, nil
+}
+
+// Constructs and returns a new view which is the (unconjugated)
+// transposition of the receiver.
+func (m *Matrix) ViewDice() *Matrix {
+	v := m.View()
+	v.VDice()
+	return &Matrix{v}
+}
+
+// Constructs and returns a new view of the height x width sub-range of
+// cells starting at [row,column].
+func (m *Matrix) ViewPart(row, column, height, width int) (*Matrix, error) {
+	v := m.View()
+	err := v.VPart(row, column, height, width)
+	if err != nil {
+		return nil, err
+	}
+	return &Matrix{v}, nil
+}
+
+// Constructs and returns a new view with the order of the rows reversed.
+func (m *Matrix) ViewRowFlip() *Matrix {
+	v := m.View()
+	v.VRowFlip()
+	return &Matrix{v}
+}
+
+// Constructs and returns a new view with the order of the columns
+// reversed.
+func (m *Matrix) ViewColumnFlip() *Matrix {
+	v := m.View()
+	v.VColumnFlip()
+	return &Matrix{v}
+}
+
+// Constructs and returns a new view of every rowStride-th row and
+// columnStride-th column.
+func (m *Matrix) ViewStrides(rowStride, columnStride int) (*Matrix, error) {
+	v := m.View()
+	err := v.VStrides(rowStride, columnStride)
+	if err != nil {
+		return nil, err
+	}
+	return &Matrix{v}, nil
+}
+
+// Linear algebraic matrix-vector multiplication; z = A * y.
+func (m *Matrix) ZMult(y, z *Vector) (*Vector, error) {
+	return m.ZMultConst(y, z, 1, 0, false)
+}
+
+// Linear algebraic matrix-vector multiplication;
+// z = alpha * A * y + beta*z, where A is the conjugate transpose of the
+// receiver if conjTransposeA is true. A new result vector is created if
+// z is nil.
+func (m *Matrix) ZMultConst(y, z *Vector, alpha, beta complex128, conjTransposeA bool) (*Vector, error) {
+	rows, columns := m.Rows(), m.Columns()
+	if conjTransposeA {
+		rows, columns = columns, rows
+	}
+	if z == nil {
+		z = &Vector{y.Like(rows)}
+	}
+	if columns != y.Size() || rows > z.Size() {
+		return nil, fmt.Errorf("Incompatible args: %s, %s, %s",
+			m.StringShort(), y.StringShort(), z.StringShort())
+	}
+	for r := 0; r < rows; r++ {
+		var s complex128
+		for c := 0; c < columns; c++ {
+			s += m.element(r, c, conjTransposeA) * y.GetQuick(c)
+		}
+		z.SetQuick(r, alpha*s+beta*z.GetQuick(r))
+	}
+	return z, nil
+}
+
+// Linear algebraic matrix-matrix multiplication; C = A x B.
+func (m *Matrix) ZMultMatrix(B, C *Matrix) (*Matrix, error) {
+	return m.ZMultMatrixConst(B, C, 1, 0, false, false)
+}
+
+// Linear algebraic matrix-matrix multiplication;
+// C = alpha * A x B + beta*C, where A and B are replaced by their
+// conjugate transposes if conjTransposeA or conjTransposeB are true. A
+// new result matrix is created if C is nil.
+func (m *Matrix) ZMultMatrixConst(B, C *Matrix, alpha, beta complex128, conjTransposeA, conjTransposeB bool) (*Matrix, error) {
+	rows, n := m.Rows(), m.Columns()
+	if conjTransposeA {
+		rows, n = n, rows
+	}
+	bn, columns := B.Rows(), B.Columns()
+	if conjTransposeB {
+		bn, columns = columns, bn
+	}
+	if bn != n {
+		return nil, fmt.Errorf("Matrix inner dimensions must agree: %s, %s",
+			m.StringShort(), B.StringShort())
+	}
+	if C == nil {
+		C = &Matrix{m.Like(rows, columns)}
+	}
+	if C.Rows() != rows || C.Columns() != columns {
+		return nil, fmt.Errorf("Incompatible result matrix: %s, %s, %s",
+			m.StringShort(), B.StringShort(), C.StringShort())
+	}
+	if C == m || C == B {
+		return nil, errors.New("Matrices must not be identical")
+	}
+	for c := 0; c < columns; c++ {
+		for r := 0; r < rows; r++ {
+			var s complex128
+			for k := 0; k < n; k++ {
+				s += m.element(r, k, conjTransposeA) * B.element(k, c, conjTransposeB)
+			}
+			C.SetQuick(r, c, alpha*s+beta*C.GetQuick(r, c))
+		}
+	}
+	return C, nil
+}
+
+// Returns A[row,column], or conj(A[column,row]) if conjTranspose is true.
+func (m *Matrix) element(row, column int, conjTranspose bool) complex128 {
+	if conjTranspose {
+		return cmplx.Conj(m.GetQuick(column, row))
+	}
+	return m.GetQuick(row, column)
+}
+
+func (m *Matrix) checkShape(other common.Mat) error {
+	if m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return fmt.Errorf("Incompatible dimensions: %s and %s",
+			m.StringShort(), other.StringShort())
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package tcomplex128
+
+import "testing"
+
+func makeDenseMatrix() *Matrix {
+	return fillMatrix(NewMatrix(nrows, ncols))
+}
+
+func TestDenseMatrixGetSet(t *testing.T) {
+	testMatrixGetSet(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixAssign(t *testing.T) {
+	testMatrixAssign(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixRealImag(t *testing.T) {
+	testMatrixRealImag(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixConjugateTranspose(t *testing.T) {
+	testMatrixConjugateTranspose(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixView(t *testing.T) {
+	testMatrixView(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixZMult(t *testing.T) {
+	testMatrixZMult(t, makeDenseMatrix(), fillVector(NewVector(ncols)))
+}
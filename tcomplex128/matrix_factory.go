@@ -0,0 +1,74 @@
+package tcomplex128
+
+import (
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+// Returns a new dense matrix with the given number of rows and columns.
+func NewMatrix(rows, columns int) *Matrix {
+	return &Matrix{
+		&DenseMat{
+			common.NewCoreMat(false, rows, columns, columns, 1, 0, 0),
+			make([]complex128, rows*columns),
+		},
+	}
+}
+
+// Returns a new sparse matrix with the given number of rows and columns.
+func NewSparseMatrix(rows, columns int) *Matrix {
+	return &Matrix{
+		&SparseMat{
+			common.NewCoreMat(false, rows, columns, columns, 1, 0, 0),
+			make(map[int]complex128),
+		},
+	}
+}
+
+// Returns a new dense matrix holding the values of the given array,
+// which must be rectangular.
+func NewMatrixArray(values [][]complex128) (*Matrix, error) {
+	columns := 0
+	if len(values) > 0 {
+		columns = len(values[0])
+	}
+	m := NewMatrix(len(values), columns)
+	_, err := m.AssignArray(values)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Returns a new dense matrix with the real parts of its cells taken from
+// re and the imaginary parts from im. im may be nil for a matrix with
+// zero imaginary parts.
+func NewMatrixRealImag(re, im tfloat64.Mat) (*Matrix, error) {
+	m := NewMatrix(re.Rows(), re.Columns())
+	_, err := m.AssignRealImag(re, im)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Returns a new sparse matrix with the real parts of its cells taken from
+// re and the imaginary parts from im. im may be nil for a matrix with
+// zero imaginary parts.
+func NewSparseMatrixRealImag(re, im tfloat64.Mat) (*Matrix, error) {
+	m := NewSparseMatrix(re.Rows(), re.Columns())
+	_, err := m.AssignRealImag(re, im)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Returns a new dense identity matrix of the given size.
+func NewIdentity(size int) *Matrix {
+	m := NewMatrix(size, size)
+	for i := 0; i < size; i++ {
+		m.SetQuick(i, i, 1)
+	}
+	return m
+}
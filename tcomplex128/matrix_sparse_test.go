@@ -0,0 +1,31 @@
+package tcomplex128
+
+import "testing"
+
+func makeSparseMatrix() *Matrix {
+	return fillMatrix(NewSparseMatrix(nrows, ncols))
+}
+
+func TestSparseMatrixGetSet(t *testing.T) {
+	testMatrixGetSet(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixAssign(t *testing.T) {
+	testMatrixAssign(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixRealImag(t *testing.T) {
+	testMatrixRealImag(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixConjugateTranspose(t *testing.T) {
+	testMatrixConjugateTranspose(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixView(t *testing.T) {
+	testMatrixView(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixZMult(t *testing.T) {
+	testMatrixZMult(t, makeSparseMatrix(), fillVector(NewSparseVector(ncols)))
+}
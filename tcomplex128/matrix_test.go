@@ -0,0 +1,211 @@
+package tcomplex128
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+func fillMatrix(A *Matrix) *Matrix {
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			A.SetQuick(r, c, randComplex())
+		}
+	}
+	return A
+}
+
+func testMatrixGetSet(t *testing.T, A *Matrix) {
+	A.SetQuick(2, 3, -1+1i)
+	if a, err := A.Get(2, 3); err != nil || a != -1+1i {
+		t.Errorf("expected:%v actual:%v", -1+1i, a)
+	}
+	if err := A.Set(A.Rows(), 0, 1); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if _, err := A.Get(0, -1); err == nil {
+		t.Error("expected column out of bounds error")
+	}
+	B := A.Copy()
+	if !B.EqualsMatrix(A) {
+		t.Error("expected copy to equal original")
+	}
+	B.SetQuick(0, 0, 100)
+	if A.GetQuick(0, 0) == 100 {
+		t.Error("expected copy to be independent of original")
+	}
+}
+
+func testMatrixAssign(t *testing.T, A *Matrix) {
+	B := A.Copy()
+	A.AssignMatrixFunc(B, MultConj)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := complex(cmplx.Abs(B.GetQuick(r, c))*cmplx.Abs(B.GetQuick(r, c)), 0)
+			if cmplx.Abs(A.GetQuick(r, c)-expected) > tol {
+				t.Errorf("expected:%v actual:%v", expected, A.GetQuick(r, c))
+			}
+		}
+	}
+	values := B.ToArray()
+	if _, err := A.AssignArray(values); err != nil {
+		t.Fatal(err)
+	}
+	if !A.EqualsMatrix(B) {
+		t.Error("expected matrix assigned from array to equal original")
+	}
+	if _, err := A.AssignArray(values[1:]); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+	var expected complex128
+	for _, row := range values {
+		for _, value := range row {
+			expected += value
+		}
+	}
+	if cmplx.Abs(A.ZSum()-expected) > tol {
+		t.Errorf("expected:%v actual:%v", expected, A.ZSum())
+	}
+}
+
+func testMatrixRealImag(t *testing.T, A *Matrix) {
+	re, im := A.RealImag()
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if complex(re.GetQuick(r, c), im.GetQuick(r, c)) != A.GetQuick(r, c) {
+				t.Errorf("expected:%v actual:(%g, %g)", A.GetQuick(r, c), re.GetQuick(r, c), im.GetQuick(r, c))
+			}
+		}
+	}
+	B, err := NewMatrixRealImag(re, im)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !B.EqualsMatrix(A) {
+		t.Error("expected matrix from parts to equal original")
+	}
+	C, err := NewSparseMatrixRealImag(re, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if C.GetQuick(1, 2) != complex(re.GetQuick(1, 2), 0) {
+		t.Errorf("expected:%v actual:%v", complex(re.GetQuick(1, 2), 0), C.GetQuick(1, 2))
+	}
+	if _, err := NewMatrixRealImag(re, im.Mat.Like(1, 1)); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+
+	// Views share the cells and follow the shape of the receiver.
+	D := A.ViewDice()
+	D.ViewReal().SetQuick(4, 1, 6)
+	D.ViewImag().SetQuick(4, 1, 8)
+	if A.GetQuick(1, 4) != 6+8i {
+		t.Errorf("expected:%v actual:%v", 6+8i, A.GetQuick(1, 4))
+	}
+	if A.Abs().GetQuick(1, 4) != 10 {
+		t.Errorf("expected:%g actual:%g", 10.0, A.Abs().GetQuick(1, 4))
+	}
+}
+
+func testMatrixConjugateTranspose(t *testing.T, A *Matrix) {
+	H := A.ConjugateTranspose()
+	if H.Rows() != A.Columns() || H.Columns() != A.Rows() {
+		t.Fatalf("unexpected shape: %s", H.StringShort())
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if H.GetQuick(c, r) != cmplx.Conj(A.GetQuick(r, c)) {
+				t.Errorf("expected:%v actual:%v", cmplx.Conj(A.GetQuick(r, c)), H.GetQuick(c, r))
+			}
+		}
+	}
+
+	// A^H * A is Hermitian, but not symmetric.
+	P, err := H.ZMultMatrix(A, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !prop.IsHermitian(P) {
+		t.Error("expected A^H * A to be Hermitian")
+	}
+	if prop.IsSymmetric(P) || prop.IsHermitian(A) {
+		t.Error("expected A^H * A not to be symmetric")
+	}
+	Q, err := A.ZMultMatrixConst(A, nil, 1, 0, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Q.EqualsMatrix(P) {
+		t.Error("expected conjugate transposed product to equal A^H * A")
+	}
+}
+
+func testMatrixView(t *testing.T, A *Matrix) {
+	P, err := A.ViewPart(2, 3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	P.SetQuick(3, 4, 1i)
+	if A.GetQuick(5, 7) != 1i {
+		t.Error("expected part view to share cells with matrix")
+	}
+	R, err := A.ViewRow(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	C, err := A.ViewColumn(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if R.GetQuick(7) != 1i || C.GetQuick(5) != 1i {
+		t.Error("expected row and column views to share cells with matrix")
+	}
+	F := A.ViewRowFlip().ViewColumnFlip()
+	if F.GetQuick(0, 0) != A.GetQuick(A.Rows()-1, A.Columns()-1) {
+		t.Error("expected flipped view to reverse both axes")
+	}
+	S, err := A.ViewStrides(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Rows() != 7 || S.Columns() != 6 || S.GetQuick(3, 2) != A.GetQuick(6, 6) {
+		t.Errorf("unexpected strided view: %s", S.StringShort())
+	}
+	if _, err := A.ViewStrides(0, 1); err == nil {
+		t.Error("expected illegal strides error")
+	}
+	if _, err := A.ViewRow(A.Rows()); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if A.String() == "" {
+		t.Error("expected non-empty string")
+	}
+}
+
+func testMatrixZMult(t *testing.T, A *Matrix, y *Vector) {
+	z, err := A.ZMult(y, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r := 0; r < A.Rows(); r++ {
+		var expected complex128
+		for c := 0; c < A.Columns(); c++ {
+			expected += A.GetQuick(r, c) * y.GetQuick(c)
+		}
+		if cmplx.Abs(z.GetQuick(r)-expected) > tol {
+			t.Errorf("expected:%v actual:%v", expected, z.GetQuick(r))
+		}
+	}
+
+	// y^H * (A^H * z) == (A * y)^H * z == |z|^2
+	w, err := A.ZMultConst(z, nil, 1, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := z.ZDotProduct(z)
+	if cmplx.Abs(w.ZDotProduct(y)-expected) > tol*float64(A.Size()) {
+		t.Errorf("expected:%v actual:%v", expected, w.ZDotProduct(y))
+	}
+	if _, err := A.ZMult(z, nil); err == nil {
+		t.Error("expected incompatible args error")
+	}
+}
@@ -0,0 +1,238 @@
+package tcomplex128
+
+import (
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+// View on the real or imaginary parts of the cells of a dense complex
+// vector. Setting a cell of the view modifies only the corresponding part
+// of the complex cell.
+type densePartVec struct {
+	*common.CoreVec
+	elements []complex128 // The elements of the complex vector.
+	isImag   bool         // Whether the imaginary parts are viewed.
+	offsets  []int        // The offsets of a selection view, or nil.
+}
+
+func (v *densePartVec) GetQuick(index int) float64 {
+	return part(v.elements[v.Index(index)], v.isImag)
+}
+
+func (v *densePartVec) SetQuick(index int, value float64) {
+	i := v.Index(index)
+	v.elements[i] = setPart(v.elements[i], value, v.isImag)
+}
+
+func (v *densePartVec) Index(rank int) int {
+	if v.offsets != nil {
+		return v.offsets[v.Zero()+rank*v.Stride()]
+	}
+	return v.Zero() + rank*v.Stride()
+}
+
+func (v *densePartVec) Elements() interface{} {
+	return v.elements
+}
+
+func (v *densePartVec) Like(size int) tfloat64.Vec {
+	return tfloat64.NewVector(size).Vec
+}
+
+func (v *densePartVec) LikeMatrix(rows, columns int) tfloat64.Mat {
+	return tfloat64.NewMatrix(rows, columns).Mat
+}
+
+func (v *densePartVec) ReshapeMatrix(rows, columns int) (*tfloat64.Matrix, error) {
+	return tfloat64.NewVectorArray(partArray(v)).ReshapeMatrix(rows, columns)
+}
+
+func (v *densePartVec) ReshapeCube(slices, rows, columns int) (*tfloat64.Cube, error) {
+	return tfloat64.NewVectorArray(partArray(v)).ReshapeCube(slices, rows, columns)
+}
+
+func (v *densePartVec) ViewSelectionLike(offsets []int) tfloat64.Vec {
+	return &densePartVec{
+		common.NewCoreVec(true, len(offsets), 0, 1),
+		v.elements, v.isImag, offsets,
+	}
+}
+
+func (v *densePartVec) ViewVec() tfloat64.Vec {
+	return &densePartVec{
+		common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+		v.elements, v.isImag, v.offsets,
+	}
+}
+
+// View on the real or imaginary parts of the cells of a sparse complex
+// vector. A cell is removed from the complex vector when both of its
+// parts become zero.
+type sparsePartVec struct {
+	*common.CoreVec
+	elements map[int]complex128 // The elements of the complex vector.
+	isImag   bool               // Whether the imaginary parts are viewed.
+	offsets  []int              // The offsets of a selection view, or nil.
+}
+
+func (v *sparsePartVec) GetQuick(index int) float64 {
+	return part(v.elements[v.Index(index)], v.isImag)
+}
+
+func (v *sparsePartVec) SetQuick(index int, value float64) {
+	setSparsePart(v.elements, v.Index(index), value, v.isImag)
+}
+
+func (v *sparsePartVec) Index(rank int) int {
+	if v.offsets != nil {
+		return v.offsets[v.Zero()+rank*v.Stride()]
+	}
+	return v.Zero() + rank*v.Stride()
+}
+
+func (v *sparsePartVec) Elements() interface{} {
+	return v.elements
+}
+
+func (v *sparsePartVec) Like(size int) tfloat64.Vec {
+	return tfloat64.NewSparseVector(size).Vec
+}
+
+func (v *sparsePartVec) LikeMatrix(rows, columns int) tfloat64.Mat {
+	return tfloat64.NewSparseMatrix(rows, columns).Mat
+}
+
+func (v *sparsePartVec) ReshapeMatrix(rows, columns int) (*tfloat64.Matrix, error) {
+	return tfloat64.NewVectorArray(partArray(v)).ReshapeMatrix(rows, columns)
+}
+
+func (v *sparsePartVec) ReshapeCube(slices, rows, columns int) (*tfloat64.Cube, error) {
+	return tfloat64.NewVectorArray(partArray(v)).ReshapeCube(slices, rows, columns)
+}
+
+func (v *sparsePartVec) ViewSelectionLike(offsets []int) tfloat64.Vec {
+	return &sparsePartVec{
+		common.NewCoreVec(true, len(offsets), 0, 1),
+		v.elements, v.isImag, offsets,
+	}
+}
+
+func (v *sparsePartVec) ViewVec() tfloat64.Vec {
+	return &sparsePartVec{
+		common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+		v.elements, v.isImag, v.offsets,
+	}
+}
+
+// View on the real or imaginary parts of the cells of a dense complex
+// matrix.
+type densePartMat struct {
+	*common.CoreMat
+	elements []complex128 // The elements of the complex matrix.
+	isImag   bool         // Whether the imaginary parts are viewed.
+}
+
+func (m *densePartMat) GetQuick(row, column int) float64 {
+	return part(m.elements[m.Index(row, column)], m.isImag)
+}
+
+func (m *densePartMat) SetQuick(row, column int, value float64) {
+	i := m.Index(row, column)
+	m.elements[i] = setPart(m.elements[i], value, m.isImag)
+}
+
+func (m *densePartMat) Elements() interface{} {
+	return m.elements
+}
+
+func (m *densePartMat) Like(rows, columns int) tfloat64.Mat {
+	return tfloat64.NewMatrix(rows, columns).Mat
+}
+
+func (m *densePartMat) LikeVector(size int) tfloat64.Vec {
+	return tfloat64.NewVector(size).Vec
+}
+
+func (m *densePartMat) Like1D(size, zero, stride int) tfloat64.Vec {
+	return &densePartVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.elements, m.isImag, nil,
+	}
+}
+
+func (m *densePartMat) View() tfloat64.Mat {
+	return &densePartMat{m.CoreMat.View(), m.elements, m.isImag}
+}
+
+// View on the real or imaginary parts of the cells of a sparse complex
+// matrix.
+type sparsePartMat struct {
+	*common.CoreMat
+	elements map[int]complex128 // The elements of the complex matrix.
+	isImag   bool               // Whether the imaginary parts are viewed.
+}
+
+func (m *sparsePartMat) GetQuick(row, column int) float64 {
+	return part(m.elements[m.Index(row, column)], m.isImag)
+}
+
+func (m *sparsePartMat) SetQuick(row, column int, value float64) {
+	setSparsePart(m.elements, m.Index(row, column), value, m.isImag)
+}
+
+func (m *sparsePartMat) Elements() interface{} {
+	return m.elements
+}
+
+func (m *sparsePartMat) Like(rows, columns int) tfloat64.Mat {
+	return tfloat64.NewSparseMatrix(rows, columns).Mat
+}
+
+func (m *sparsePartMat) LikeVector(size int) tfloat64.Vec {
+	return tfloat64.NewSparseVector(size).Vec
+}
+
+func (m *sparsePartMat) Like1D(size, zero, stride int) tfloat64.Vec {
+	return &sparsePartVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.elements, m.isImag, nil,
+	}
+}
+
+func (m *sparsePartMat) View() tfloat64.Mat {
+	return &sparsePartMat{m.CoreMat.View(), m.elements, m.isImag}
+}
+
+// Returns the real part of a, or the imaginary part if isImag is true.
+func part(a complex128, isImag bool) float64 {
+	if isImag {
+		return imag(a)
+	}
+	return real(a)
+}
+
+// Returns a with its real part, or imaginary part if isImag is true,
+// replaced by value.
+func setPart(a complex128, value float64, isImag bool) complex128 {
+	if isImag {
+		return complex(real(a), value)
+	}
+	return complex(value, imag(a))
+}
+
+func setSparsePart(elements map[int]complex128, index int, value float64, isImag bool) {
+	a := setPart(elements[index], value, isImag)
+	if a == 0 {
+		delete(elements, index)
+	} else {
+		elements[index] = a
+	}
+}
+
+func partArray(v tfloat64.Vec) []float64 {
+	values := make([]float64, v.Size())
+	for i := range values {
+		values[i] = v.GetQuick(i)
+	}
+	return values
+}
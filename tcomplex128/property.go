@@ -0,0 +1,170 @@
+package tcomplex128
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+type Property struct {
+	tolerance float64
+}
+
+// Constructs and returns a new property object with the given tolerance.
+// The absolute value is used.
+func NewProperty(tolerance float64) *Property {
+	return &Property{math.Abs(tolerance)}
+}
+
+// Returns the current tolerance.
+func (p *Property) Tolerance() float64 {
+	return p.tolerance
+}
+
+// Sets the tolerance to math.Abs(tolerance).
+func (p *Property) SetTolerance(tolerance float64) {
+	p.tolerance = math.Abs(tolerance)
+}
+
+// Returns whether a and b differ by no more than the tolerance, i.e.
+// !(cmplx.Abs(a - b) > tolerance). Cells that are both NaN, or both the
+// same infinity, are considered equal.
+func (p *Property) equals(a, b complex128) bool {
+	if a == b {
+		return true
+	}
+	diff := cmplx.Abs(a - b)
+	if diff != diff {
+		return cmplx.IsNaN(a) && cmplx.IsNaN(b)
+	}
+	return !(diff > p.tolerance)
+}
+
+// Returns whether all cells of the given vector are equal to the given
+// value, within the tolerance.
+func (p *Property) VectorEqualsValue(v Vec, value complex128) bool {
+	for i := 0; i < v.Size(); i++ {
+		if !p.equals(value, v.GetQuick(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether both given vectors have the same size and, within the
+// tolerance, the same values at the same indexes.
+func (p *Property) VectorEqualsVector(A, B Vec) bool {
+	if A == B {
+		return true
+	}
+	if A == nil || B == nil || A.Size() != B.Size() {
+		return false
+	}
+	for i := 0; i < A.Size(); i++ {
+		if !p.equals(A.GetQuick(i), B.GetQuick(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether all cells of the given matrix are equal to the given
+// value, within the tolerance.
+func (p *Property) MatrixEqualsValue(A Mat, value complex128) bool {
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if !p.equals(value, A.GetQuick(r, c)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns whether both given matrices have the same shape and, within the
+// tolerance, the same values at the same coordinates.
+func (p *Property) MatrixEqualsMatrix(A, B Mat) bool {
+	if A == B {
+		return true
+	}
+	if A == nil || B == nil || A.Rows() != B.Rows() || A.Columns() != B.Columns() {
+		return false
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if !p.equals(A.GetQuick(r, c), B.GetQuick(r, c)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns whether all cells of the given cube are equal to the given
+// value, within the tolerance.
+func (p *Property) CubeEqualsValue(A Cub, value complex128) bool {
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				if !p.equals(value, A.GetQuick(s, r, c)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Returns whether both given cubes have the same shape and, within the
+// tolerance, the same values at the same coordinates.
+func (p *Property) CubeEqualsCube(A, B Cub) bool {
+	if A == B {
+		return true
+	}
+	if A == nil || B == nil || A.Slices() != B.Slices() ||
+		A.Rows() != B.Rows() || A.Columns() != B.Columns() {
+		return false
+	}
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				if !p.equals(A.GetQuick(s, r, c), B.GetQuick(s, r, c)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Returns whether A is square and equal to its conjugate transpose;
+// A[i,j] == conj(A[j,i]), within the tolerance.
+func (p *Property) IsHermitian(A Mat) bool {
+	if A.Rows() != A.Columns() {
+		return false
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c <= r; c++ {
+			if !p.equals(A.GetQuick(r, c), cmplx.Conj(A.GetQuick(c, r))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns whether A is square and equal to its (unconjugated) transpose;
+// A[i,j] == A[j,i], within the tolerance. Bus admittance matrices of
+// networks without phase shifting transformers are complex symmetric.
+func (p *Property) IsSymmetric(A Mat) bool {
+	if A.Rows() != A.Columns() {
+		return false
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < r; c++ {
+			if !p.equals(A.GetQuick(r, c), A.GetQuick(c, r)) {
+				return false
+			}
+		}
+	}
+	return true
+}
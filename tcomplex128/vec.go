@@ -0,0 +1,41 @@
+package tcomplex128
+
+import (
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+// Interface for all complex vector backends.
+type Vec interface {
+	common.Vec
+
+	// Returns the matrix cell value at coordinate "index".
+	//
+	// Provided with invalid parameters this method may cause a panic or
+	// return invalid values without causing an error. You should only
+	// use this method when you are absolutely sure that the coordinate
+	// is within bounds.
+	// Precondition (unchecked): index < 0 || index >= Size().
+	GetQuick(int) complex128
+
+	// Sets the matrix cell at coordinate "index" to the specified value.
+	//
+	// Provided with invalid parameters this method may cause a panic or
+	// access illegal indexes without causing an error. You should only use
+	// this method when you are absolutely sure that the coordinate is
+	// within bounds.
+	// Precondition (unchecked): index < 0 || index >= Size().
+	SetQuick(int, complex128)
+
+	Like(int) Vec
+	LikeMatrix(int, int) Mat
+
+	// Returns a new backend sharing the elements of the receiver whose
+	// shape may be modified independently.
+	ViewVec() Vec
+
+	// Returns views of the real and imaginary parts of the receiver
+	// that share its elements.
+	ViewRealVec() tfloat64.Vec
+	ViewImagVec() tfloat64.Vec
+}
@@ -0,0 +1,52 @@
+package tcomplex128
+
+import (
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+type DenseVec struct {
+	*common.CoreVec
+	elements []complex128 // The elements of this vector.
+}
+
+func (v *DenseVec) GetQuick(index int) complex128 {
+	return v.elements[v.Index(index)]
+}
+
+func (v *DenseVec) SetQuick(index int, value complex128) {
+	v.elements[v.Index(index)] = value
+}
+
+func (v *DenseVec) Elements() interface{} {
+	return v.elements
+}
+
+func (v *DenseVec) Like(size int) Vec {
+	return NewVector(size).Vec
+}
+
+func (v *DenseVec) LikeMatrix(rows, columns int) Mat {
+	return NewMatrix(rows, columns).Mat
+}
+
+func (v *DenseVec) ViewVec() Vec {
+	return &DenseVec{
+		common.NewCoreVec(v.IsView(), v.Size(), v.Zero(), v.Stride()),
+		v.elements,
+	}
+}
+
+func (v *DenseVec) ViewRealVec() tfloat64.Vec {
+	return &densePartVec{
+		common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+		v.elements, false, nil,
+	}
+}
+
+func (v *DenseVec) ViewImagVec() tfloat64.Vec {
+	return &densePartVec{
+		common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+		v.elements, true, nil,
+	}
+}
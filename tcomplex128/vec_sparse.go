@@ -0,0 +1,57 @@
+package tcomplex128
+
+import (
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+type SparseVec struct {
+	*common.CoreVec
+	elements map[int]complex128 // The elements of this vector.
+}
+
+func (v *SparseVec) GetQuick(index int) complex128 {
+	return v.elements[v.Index(index)]
+}
+
+func (v *SparseVec) SetQuick(index int, value complex128) {
+	i := v.Index(index)
+	if value == 0 {
+		delete(v.elements, i)
+	} else {
+		v.elements[i] = value
+	}
+}
+
+func (v *SparseVec) Elements() interface{} {
+	return v.elements
+}
+
+func (v *SparseVec) Like(size int) Vec {
+	return NewSparseVector(size).Vec
+}
+
+func (v *SparseVec) LikeMatrix(rows, columns int) Mat {
+	return NewSparseMatrix(rows, columns).Mat
+}
+
+func (v *SparseVec) ViewVec() Vec {
+	return &SparseVec{
+		common.NewCoreVec(v.IsView(), v.Size(), v.Zero(), v.Stride()),
+		v.elements,
+	}
+}
+
+func (v *SparseVec) ViewRealVec() tfloat64.Vec {
+	return &sparsePartVec{
+		common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+		v.elements, false, nil,
+	}
+}
+
+func (v *SparseVec) ViewImagVec() tfloat64.Vec {
+	return &sparsePartVec{
+		common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+		v.elements, true, nil,
+	}
+}
@@ -0,0 +1,291 @@
+package tcomplex128
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+var (
+	prop = NewProperty(1e-9)
+	fmtr = NewFormatter()
+)
+
+type Vector struct {
+	Vec
+}
+
+// Returns a string representation using default formatting.
+func (v *Vector) String() string {
+	return fmtr.VectorToString(v)
+}
+
+// Returns the matrix cell value at coordinate "index".
+func (v *Vector) Get(index int) (complex128, error) {
+	if index < 0 || index >= v.Size() {
+		return 0, fmt.Errorf("Attempted to access %s at index=%d",
+			v.StringShort(), index)
+	}
+	return v.GetQuick(index), nil
+}
+
+// Sets the matrix cell at coordinate index to the specified value.
+func (v *Vector) Set(index int, value complex128) error {
+	if index < 0 || index >= v.Size() {
+		return fmt.Errorf("Attempted to access %s at index=%d",
+			v.StringShort(), index)
+	}
+	v.SetQuick(index, value)
+	return nil
+}
+
+// Constructs and returns a deep copy of the receiver.
+func (v *Vector) Copy() *Vector {
+	copy := &Vector{v.Like(v.Size())}
+	copy.AssignVector(v)
+	return copy
+}
+
+// Constructs and returns a new view equal to the receiver. The view is a
+// shallow clone.
+func (v *Vector) ViewVector() *Vector {
+	return &Vector{v.ViewVec()}
+}
+
+// Returns the number of cells having non-zero values; ignores tolerance.
+func (v *Vector) Cardinality() int {
+	cardinality := 0
+	for i := 0; i < v.Size(); i++ {
+		if v.GetQuick(i) != 0 {
+			cardinality++
+		}
+	}
+	return cardinality
+}
+
+// Returns whether all cells are equal to the given value, within the
+// default tolerance.
+func (v *Vector) Equals(value complex128) bool {
+	return prop.VectorEqualsValue(v, value)
+}
+
+// Returns whether the receiver has the same size and, within the default
+// tolerance, the same values as other.
+func (v *Vector) EqualsVector(other Vec) bool {
+	return prop.VectorEqualsVector(v, other)
+}
+
+// Sets all cells to the given value.
+func (v *Vector) Assign(value complex128) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, value)
+	}
+	return v
+}
+
+// Assigns the result of a function to each cell; x[i] = f(x[i]).
+func (v *Vector) AssignFunc(f Complex128Func) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, f(v.GetQuick(i)))
+	}
+	return v
+}
+
+// Sets all cells to the values of the given array, which must have the
+// same size as the receiver.
+func (v *Vector) AssignArray(values []complex128) (*Vector, error) {
+	if len(values) != v.Size() {
+		return v, fmt.Errorf("Must have same number of cells: length=%d, size=%d",
+			len(values), v.Size())
+	}
+	for i, value := range values {
+		v.SetQuick(i, value)
+	}
+	return v, nil
+}
+
+// Replaces all cell values of the receiver with the values of other,
+// which must have the same size.
+func (v *Vector) AssignVector(other Vec) (*Vector, error) {
+	err := v.checkSize(other)
+	if err != nil {
+		return v, err
+	}
+	if o, ok := other.(*Vector); ok {
+		other = o.Vec
+	}
+	if other == v.Vec {
+		return v, nil
+	}
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, other.GetQuick(i))
+	}
+	return v, nil
+}
+
+// Assigns the result of a function to each cell;
+// x[i] = f(x[i], y[i]).
+func (v *Vector) AssignVectorFunc(y Vec, f Complex128Complex128Func) (*Vector, error) {
+	err := v.checkSize(y)
+	if err != nil {
+		return v, err
+	}
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, f(v.GetQuick(i), y.GetQuick(i)))
+	}
+	return v, nil
+}
+
+// Sets the real parts of the cells to the values of re and the imaginary
+// parts to the values of im. im may be nil to set the imaginary parts to
+// zero.
+func (v *Vector) AssignRealImag(re, im tfloat64.Vec) (*Vector, error) {
+	err := v.checkSize(re)
+	if err != nil {
+		return v, err
+	}
+	if im != nil {
+		err = v.checkSize(im)
+		if err != nil {
+			return v, err
+		}
+	}
+	for i := 0; i < v.Size(); i++ {
+		value := complex(re.GetQuick(i), 0)
+		if im != nil {
+			value += complex(0, im.GetQuick(i))
+		}
+		v.SetQuick(i, value)
+	}
+	return v, nil
+}
+
+// Applies a function to each cell and aggregates the results. Returns a
+// value v such that v==a(Size()) where
+// a(i) == aggr( a(i-1), f(get(i)) ) and terminators are
+// a(1) == f(get(0)), a(0)==0.
+func (v *Vector) Aggregate(aggr Complex128Complex128Func, f Complex128Func) complex128 {
+	if v.Size() == 0 {
+		return 0
+	}
+	a := f(v.GetQuick(0))
+	for i := 1; i < v.Size(); i++ {
+		a = aggr(a, f(v.GetQuick(i)))
+	}
+	return a
+}
+
+// Returns the sum of all cells; Sum( x[i] ).
+func (v *Vector) ZSum() complex128 {
+	return v.Aggregate(Plus, Identity)
+}
+
+// Returns the dot product of two vectors x and y, which is
+// Sum(x[i]*conj(y[i])). Where x == this. Operates on cells at
+// indexes 0 .. Min(Size(), y.Size()).
+func (v *Vector) ZDotProduct(y Vec) complex128 {
+	n := v.Size()
+	if y.Size() < n {
+		n = y.Size()
+	}
+	var sum complex128
+	for i := 0; i < n; i++ {
+		sum += v.GetQuick(i) * cmplx.Conj(y.GetQuick(i))
+	}
+	return sum
+}
+
+// Returns the Euclidean norm of the vector; Sqrt(Sum(|x[i]|^2)).
+func (v *Vector) Norm2() float64 {
+	return math.Sqrt(real(v.ZDotProduct(v)))
+}
+
+// Constructs and returns a 1-dimensional array containing the cell
+// values.
+func (v *Vector) ToArray() []complex128 {
+	values := make([]complex128, v.Size())
+	for i := range values {
+		values[i] = v.GetQuick(i)
+	}
+	return values
+}
+
+// Returns a view of the real parts of the cells. Setting a cell of the
+// view sets the real part of the corresponding cell of the receiver.
+func (v *Vector) ViewReal() *tfloat64.Vector {
+	return &tfloat64.Vector{Vec: v.ViewRealVec()}
+}
+
+// Returns a view of the imaginary parts of the cells. Setting a cell of
+// the view sets the imaginary part of the corresponding cell of the
+// receiver.
+func (v *Vector) ViewImag() *tfloat64.Vector {
+	return &tfloat64.Vector{Vec: v.ViewImagVec()}
+}
+
+// Returns new real vectors holding copies of the real and imaginary parts
+// of the cells.
+func (v *Vector) RealImag() (*tfloat64.Vector, *tfloat64.Vector) {
+	return v.RealFunc(Real), v.RealFunc(Imag)
+}
+
+// Returns a new real vector holding f applied to each cell, e.g. Abs or
+// Arg.
+func (v *Vector) RealFunc(f Complex128RealFunc) *tfloat64.Vector {
+	r := &tfloat64.Vector{Vec: v.ViewRealVec().Like(v.Size())}
+	for i := 0; i < v.Size(); i++ {
+		r.SetQuick(i, f(v.GetQuick(i)))
+	}
+	return r
+}
+
+// Returns a new real vector holding the absolute value of each cell.
+func (v *Vector) Abs() *tfloat64.Vector {
+	return v.RealFunc(Abs)
+}
+
+// Returns a new real vector holding the argument of each cell.
+func (v *Vector) Arg() *tfloat64.Vector {
+	return v.RealFunc(Arg)
+}
+
+// Constructs and returns a new flip view. What used to be index 0 is
+// now index Size()-1, ..., what used to be index Size()-1 is now
+// index 0.
+func (v *Vector) ViewFlip() *Vector {
+	view := v.ViewVector()
+	view.VFlip()
+	return view
+}
+
+// Constructs and returns a new view of the width cells starting at
+// index.
+func (v *Vector) ViewPart(index, width int) (*Vector, error) {
+	view := v.ViewVector()
+	err := view.VPart(index, width)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// Constructs and returns a new view of every stride-th cell.
+func (v *Vector) ViewStrides(stride int) (*Vector, error) {
+	view := v.ViewVector()
+	err := view.VStrides(stride)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func (v *Vector) checkSize(other common.Vec) error {
+	if v.Size() != other.Size() {
+		return fmt.Errorf("Incompatible sizes: %s and %s",
+			v.StringShort(), common.VectorShape(other))
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package tcomplex128
+
+import "testing"
+
+func makeDenseVector() *Vector {
+	return fillVector(NewVector(size))
+}
+
+func TestDenseVectorGetSet(t *testing.T) {
+	testVectorGetSet(t, makeDenseVector())
+}
+
+func TestDenseVectorAssign(t *testing.T) {
+	testVectorAssign(t, makeDenseVector())
+}
+
+func TestDenseVectorAggregate(t *testing.T) {
+	testVectorAggregate(t, makeDenseVector())
+}
+
+func TestDenseVectorRealImag(t *testing.T) {
+	testVectorRealImag(t, makeDenseVector())
+}
+
+func TestDenseVectorView(t *testing.T) {
+	testVectorView(t, makeDenseVector())
+}
@@ -0,0 +1,74 @@
+package tcomplex128
+
+import (
+	"fmt"
+	"math/cmplx"
+
+	"github.com/rwl/goshawk/common"
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+// Returns a new dense vector of the given size.
+func NewVector(size int) *Vector {
+	return &Vector{
+		&DenseVec{
+			common.NewCoreVec(false, size, 0, 1),
+			make([]complex128, size),
+		},
+	}
+}
+
+// Returns a new sparse vector of the given size.
+func NewSparseVector(size int) *Vector {
+	return &Vector{
+		&SparseVec{
+			common.NewCoreVec(false, size, 0, 1),
+			make(map[int]complex128),
+		},
+	}
+}
+
+// Returns a new dense vector holding the values of the given array.
+func NewVectorArray(a []complex128) *Vector {
+	v := NewVector(len(a))
+	v.AssignArray(a)
+	return v
+}
+
+// Returns a new dense vector with the real parts of its cells taken from
+// re and the imaginary parts from im. im may be nil for a vector with
+// zero imaginary parts.
+func NewVectorRealImag(re, im tfloat64.Vec) (*Vector, error) {
+	v := NewVector(re.Size())
+	_, err := v.AssignRealImag(re, im)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Returns a new sparse vector with the real parts of its cells taken from
+// re and the imaginary parts from im. im may be nil for a vector with
+// zero imaginary parts.
+func NewSparseVectorRealImag(re, im tfloat64.Vec) (*Vector, error) {
+	v := NewSparseVector(re.Size())
+	_, err := v.AssignRealImag(re, im)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Returns a new dense vector with the cells given in polar form by
+// their moduli r and arguments theta.
+func NewVectorPolar(r, theta tfloat64.Vec) (*Vector, error) {
+	if r.Size() != theta.Size() {
+		return nil, fmt.Errorf("Incompatible sizes: %s and %s",
+			common.VectorShape(r), common.VectorShape(theta))
+	}
+	v := NewVector(r.Size())
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, cmplx.Rect(r.GetQuick(i), theta.GetQuick(i)))
+	}
+	return v, nil
+}
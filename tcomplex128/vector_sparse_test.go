@@ -0,0 +1,27 @@
+package tcomplex128
+
+import "testing"
+
+func makeSparseVector() *Vector {
+	return fillVector(NewSparseVector(size))
+}
+
+func TestSparseVectorGetSet(t *testing.T) {
+	testVectorGetSet(t, makeSparseVector())
+}
+
+func TestSparseVectorAssign(t *testing.T) {
+	testVectorAssign(t, makeSparseVector())
+}
+
+func TestSparseVectorAggregate(t *testing.T) {
+	testVectorAggregate(t, makeSparseVector())
+}
+
+func TestSparseVectorRealImag(t *testing.T) {
+	testVectorRealImag(t, makeSparseVector())
+}
+
+func TestSparseVectorView(t *testing.T) {
+	testVectorView(t, makeSparseVector())
+}
@@ -0,0 +1,158 @@
+package tcomplex128
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+const (
+	tol   = 1e-10
+	size  = 2*17 + 1
+	nrows = 13
+	ncols = 17
+)
+
+func randComplex() complex128 {
+	return complex(rand.Float64(), rand.Float64())
+}
+
+func fillVector(v *Vector) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, randComplex())
+	}
+	return v
+}
+
+func testVectorGetSet(t *testing.T, A *Vector) {
+	A.SetQuick(3, 1+2i)
+	if a, err := A.Get(3); err != nil || a != 1+2i {
+		t.Errorf("expected:%v actual:%v", 1+2i, a)
+	}
+	if err := A.Set(A.Size(), 1); err == nil {
+		t.Error("expected index out of bounds error")
+	}
+	if _, err := A.Get(-1); err == nil {
+		t.Error("expected index out of bounds error")
+	}
+}
+
+func testVectorAssign(t *testing.T, A *Vector) {
+	B := A.Copy()
+	A.AssignFunc(Conj)
+	for i := 0; i < A.Size(); i++ {
+		if A.GetQuick(i) != cmplx.Conj(B.GetQuick(i)) {
+			t.Errorf("expected:%v actual:%v", cmplx.Conj(B.GetQuick(i)), A.GetQuick(i))
+		}
+	}
+	A.AssignVectorFunc(B, Plus)
+	for i := 0; i < A.Size(); i++ {
+		expected := complex(2*real(B.GetQuick(i)), 0)
+		if cmplx.Abs(A.GetQuick(i)-expected) > tol {
+			t.Errorf("expected:%v actual:%v", expected, A.GetQuick(i))
+		}
+	}
+	A.Assign(3 - 1i)
+	if !A.Equals(3 - 1i) {
+		t.Error("expected all cells to equal the assigned value")
+	}
+	if _, err := A.AssignArray(make([]complex128, A.Size()+1)); err == nil {
+		t.Error("expected size mismatch error")
+	}
+}
+
+func testVectorAggregate(t *testing.T, A *Vector) {
+	var expected complex128
+	for i := 0; i < A.Size(); i++ {
+		expected += A.GetQuick(i)
+	}
+	if cmplx.Abs(A.ZSum()-expected) > tol {
+		t.Errorf("expected:%v actual:%v", expected, A.ZSum())
+	}
+	norm := 0.0
+	for i := 0; i < A.Size(); i++ {
+		norm += cmplx.Abs(A.GetQuick(i)) * cmplx.Abs(A.GetQuick(i))
+	}
+	if math.Abs(A.Norm2()-math.Sqrt(norm)) > tol {
+		t.Errorf("expected:%g actual:%g", math.Sqrt(norm), A.Norm2())
+	}
+	B := A.Copy().AssignFunc(Multiply(1i))
+	// Sum(x[i] * conj(i*x[i])) == -i * Sum(|x[i]|^2)
+	if cmplx.Abs(A.ZDotProduct(B)-complex(0, -norm)) > tol {
+		t.Errorf("expected:%v actual:%v", complex(0, -norm), A.ZDotProduct(B))
+	}
+}
+
+func testVectorRealImag(t *testing.T, A *Vector) {
+	re, im := A.RealImag()
+	for i := 0; i < A.Size(); i++ {
+		if re.GetQuick(i) != real(A.GetQuick(i)) || im.GetQuick(i) != imag(A.GetQuick(i)) {
+			t.Errorf("expected:%v actual:(%g, %g)", A.GetQuick(i), re.GetQuick(i), im.GetQuick(i))
+		}
+	}
+	B, err := NewVectorRealImag(re, im)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !B.EqualsVector(A) {
+		t.Error("expected vector from parts to equal original")
+	}
+
+	// Views share the cells and set one part only.
+	R := A.ViewReal()
+	R.SetQuick(2, 5)
+	I := A.ViewImag()
+	I.SetQuick(2, -7)
+	if A.GetQuick(2) != 5-7i {
+		t.Errorf("expected:%v actual:%v", 5-7i, A.GetQuick(2))
+	}
+	I.SetQuick(4, 0)
+	R.SetQuick(4, 0)
+	if A.GetQuick(4) != 0 || A.Cardinality() != A.Size()-1 {
+		t.Errorf("expected cell to be cleared: %v", A.GetQuick(4))
+	}
+
+	abs, arg := A.Abs(), A.Arg()
+	for i := 0; i < A.Size(); i++ {
+		a := A.GetQuick(i)
+		if cmplx.Abs(cmplx.Rect(abs.GetQuick(i), arg.GetQuick(i))-a) > tol {
+			t.Errorf("expected:%v actual:%v", a, cmplx.Rect(abs.GetQuick(i), arg.GetQuick(i)))
+		}
+	}
+	P, err := NewVectorPolar(abs, arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !P.EqualsVector(A) {
+		t.Error("expected vector from polar form to equal original")
+	}
+}
+
+func testVectorView(t *testing.T, A *Vector) {
+	F := A.ViewFlip()
+	if F.GetQuick(0) != A.GetQuick(A.Size()-1) {
+		t.Errorf("expected:%v actual:%v", A.GetQuick(A.Size()-1), F.GetQuick(0))
+	}
+	P, err := A.ViewPart(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	P.SetQuick(4, 9i)
+	if A.GetQuick(7) != 9i {
+		t.Error("expected part view to share cells with vector")
+	}
+	S, err := A.ViewStrides(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Size() != (A.Size()+1)/2 || S.GetQuick(3) != A.GetQuick(6) {
+		t.Error("expected strided view of every second cell")
+	}
+	if _, err := A.ViewPart(A.Size()-1, 2); err == nil {
+		t.Error("expected range error")
+	}
+	if A.String() == "" {
+		t.Error("expected non-empty string")
+	}
+}
@@ -0,0 +1,94 @@
+package tfloat32
+
+import (
+	"fmt"
+
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+// Sets the cells to the values of other converted to
+// float32.
+func (v *Vector) AssignFloat64(other tfloat64.Vec) (*Vector, error) {
+	err := v.checkSize(other)
+	if err != nil {
+		return v, err
+	}
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, float32(other.GetQuick(i)))
+	}
+	return v, nil
+}
+
+// Returns a new float64 vector holding the values of the receiver. The
+// vector is sparse if the receiver is.
+func (v *Vector) Float64() *tfloat64.Vector {
+	var x *tfloat64.Vector
+	if _, ok := v.Vec.(*SparseVec); ok {
+		x = tfloat64.NewSparseVector(v.Size())
+	} else {
+		x = tfloat64.NewVector(v.Size())
+	}
+	for i := 0; i < v.Size(); i++ {
+		x.SetQuick(i, float64(v.GetQuick(i)))
+	}
+	return x
+}
+
+// Sets the cells to the values of other converted to
+// float32.
+func (m *Matrix) AssignFloat64(other tfloat64.Mat) (*Matrix, error) {
+	err := m.checkShape(other)
+	if err != nil {
+		return m, err
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, float32(other.GetQuick(r, c)))
+		}
+	}
+	return m, nil
+}
+
+// Returns a new float64 matrix holding the values of the receiver. The
+// matrix is sparse if the receiver is.
+func (m *Matrix) Float64() *tfloat64.Matrix {
+	var A *tfloat64.Matrix
+	if _, ok := m.Mat.(*SparseMat); ok {
+		A = tfloat64.NewSparseMatrix(m.Rows(), m.Columns())
+	} else {
+		A = tfloat64.NewMatrix(m.Rows(), m.Columns())
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			A.SetQuick(r, c, float64(m.GetQuick(r, c)))
+		}
+	}
+	return A
+}
+
+// Sets the cells to the values of other converted to
+// float32.
+func (m *Cube) AssignFloat64(other tfloat64.Cub) (*Cube, error) {
+	if m.Slices() != other.Slices() || m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return m, fmt.Errorf("Incompatible dimensions: %s and %s", m.StringShort(), other.StringShort())
+	}
+	m.forEach(func(s, r, c int) {
+		m.SetQuick(s, r, c, float32(other.GetQuick(s, r, c)))
+	})
+	return m, nil
+}
+
+// Returns a new float64 cube holding the values of the receiver. The
+// cube is sparse if the receiver is.
+func (m *Cube) Float64() *tfloat64.Cube {
+	var A *tfloat64.Cube
+	if _, ok := m.Cub.(*SparseCub); ok {
+		A = tfloat64.NewSparseCube(m.Slices(), m.Rows(), m.Columns())
+	} else {
+		A = tfloat64.NewCube(m.Slices(), m.Rows(), m.Columns())
+	}
+	m.forEach(func(s, r, c int) {
+		A.SetQuick(s, r, c, float64(m.GetQuick(s, r, c)))
+	})
+	return A
+}
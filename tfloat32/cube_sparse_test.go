@@ -0,0 +1,27 @@
+package tfloat32
+
+import "testing"
+
+func makeSparseCube() *Cube {
+	return fillCube(NewSparseCube(nslices, nrows, ncols))
+}
+
+func TestSparseCubeGetSet(t *testing.T) {
+	testCubeGetSet(t, makeSparseCube())
+}
+
+func TestSparseCubeAssign(t *testing.T) {
+	testCubeAssign(t, makeSparseCube())
+}
+
+func TestSparseCubeView(t *testing.T) {
+	testCubeView(t, makeSparseCube())
+}
+
+func TestSparseCubeAggregate(t *testing.T) {
+	testCubeAggregate(t, makeSparseCube())
+}
+
+func TestSparseCubeConvert(t *testing.T) {
+	testCubeConvert(t, makeSparseCube())
+}
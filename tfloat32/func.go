@@ -0,0 +1,144 @@
+package tfloat32
+
+import "math"
+
+type Float32Func func(float32) float32
+
+type Float32Float32Func func(float32, float32) float32
+
+type Float32Procedure func(float32) bool
+
+type IntIntFloat32Func func(int, int, float32) float32
+
+type VectorProcedure func(Vec) bool
+
+// Function that returns its argument.
+func Identity(a float32) float32 {
+	return a
+}
+
+// Function that returns -a.
+func Neg(a float32) float32 {
+	return -a
+}
+
+// Function that returns the absolute value of a.
+func Abs(a float32) float32 {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Function that returns 1 / a.
+func Inv(a float32) float32 {
+	return 1 / a
+}
+
+// Function that returns a * a.
+func Square(a float32) float32 {
+	return a * a
+}
+
+// Function that returns the square root of a.
+func Sqrt(a float32) float32 {
+	return float32(math.Sqrt(float64(a)))
+}
+
+// Function that returns a + b.
+func Plus(a, b float32) float32 {
+	return a + b
+}
+
+// Function that returns a - b.
+func Minus(a, b float32) float32 {
+	return a - b
+}
+
+// Function that returns a * b.
+func Mult(a, b float32) float32 {
+	return a * b
+}
+
+// Function that returns a / b.
+func Div(a, b float32) float32 {
+	return a / b
+}
+
+// Function that returns the larger of a and b.
+func Max(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Function that returns the smaller of a and b.
+func Min(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Constructs a function that returns a + b. a is a
+// variable, b is fixed.
+func Add(b float32) Float32Func {
+	return func(a float32) float32 {
+		return a + b
+	}
+}
+
+// Constructs a function that returns a * b. a is a
+// variable, b is fixed.
+func Multiply(b float32) Float32Func {
+	return func(a float32) float32 {
+		return a * b
+	}
+}
+
+// Constructs a function that returns a / b. a is a
+// variable, b is fixed.
+func Divide(b float32) Float32Func {
+	return func(a float32) float32 {
+		return a / b
+	}
+}
+
+// Constructs a function that returns the constant c.
+func Constant(c float32) Float32Func {
+	return func(_ float32) float32 {
+		return c
+	}
+}
+
+// Constructs a function that returns a == b. a is a
+// variable, b is fixed.
+func IsEqualTo(b float32) Float32Procedure {
+	return func(a float32) bool {
+		return a == b
+	}
+}
+
+// Constructs a function that returns a > b. a is a
+// variable, b is fixed.
+func IsGreaterThan(b float32) Float32Procedure {
+	return func(a float32) bool {
+		return a > b
+	}
+}
+
+// Constructs a function that returns a < b. a is a
+// variable, b is fixed.
+func IsLessThan(b float32) Float32Procedure {
+	return func(a float32) bool {
+		return a < b
+	}
+}
+
+// Constructs a function that returns f(g(a)).
+func ChainUnary(f, g Float32Func) Float32Func {
+	return func(a float32) float32 {
+		return f(g(a))
+	}
+}
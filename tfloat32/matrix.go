@@ -0,0 +1,563 @@
+package tfloat32
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/rwl/goshawk/common"
+)
+
+type Matrix struct {
+	Mat
+}
+
+// Implemented by backends whose rows and columns cannot be viewed with
+// Like1D, such as selection views.
+type lineViewMat interface {
+	ViewRow(int) Vec
+	ViewColumn(int) Vec
+}
+
+// Returns a string representation using default formatting.
+func (m *Matrix) String() string {
+	return fmtr.MatrixToString(m)
+}
+
+// Returns the matrix cell value at coordinate [row,column].
+func (m *Matrix) Get(row, column int) (float32, error) {
+	if column < 0 || column >= m.Columns() || row < 0 || row >= m.Rows() {
+		return float32(math.NaN()), fmt.Errorf("row:%d, column:%d", row, column)
+	}
+	return m.GetQuick(row, column), nil
+}
+
+// Sets the matrix cell at coordinate [row,column] to the specified value.
+func (m *Matrix) Set(row, column int, value float32) error {
+	if column < 0 || column >= m.Columns() || row < 0 || row >= m.Rows() {
+		return fmt.Errorf("row:%d, column:%d", row, column)
+	}
+	m.SetQuick(row, column, value)
+	return nil
+}
+
+// Constructs and returns a deep copy of the receiver.
+func (m *Matrix) Copy() *Matrix {
+	copy := &Matrix{m.Like(m.Rows(), m.Columns())}
+	copy.AssignMatrix(m)
+	return copy
+}
+
+// Returns the number of cells having non-zero values.
+func (m *Matrix) Cardinality() int {
+	cardinality := 0
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			if m.GetQuick(r, c) != 0 {
+				cardinality++
+			}
+		}
+	}
+	return cardinality
+}
+
+// Returns whether all cells are equal to the given value, within the default
+// tolerance.
+func (m *Matrix) Equals(value float32) bool {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			if !prop.equals(value, m.GetQuick(r, c)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Returns whether the receiver has the same shape and the same values as
+// other, within the default
+// tolerance.
+func (m *Matrix) EqualsMatrix(other Mat) bool {
+	if m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return false
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			if !prop.equals(m.GetQuick(r, c), other.GetQuick(r, c)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Constructs and returns a 2-dimensional array containing the cell
+// values, indexed [row][column].
+func (m *Matrix) ToArray() [][]float32 {
+	values := make([][]float32, m.Rows())
+	for r := range values {
+		values[r] = make([]float32, m.Columns())
+		for c := range values[r] {
+			values[r][c] = m.GetQuick(r, c)
+		}
+	}
+	return values
+}
+
+// Applies a function to each non-zero cell, storing the result where it
+// differs from the cell value; x[row,col] = f(row,col,x[row,col]).
+func (m *Matrix) ForEachNonZero(function IntIntFloat32Func) *Matrix {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			value := m.GetQuick(r, c)
+			if value != 0 {
+				a := function(r, c, value)
+				if a != value {
+					m.SetQuick(r, c, a)
+				}
+			}
+		}
+	}
+	return m
+}
+
+// Sets all cells to the given value.
+func (m *Matrix) Assign(value float32) *Matrix {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, value)
+		}
+	}
+	return m
+}
+
+// Assigns the result of a function to each cell; x[row,col] =
+// f(x[row,col]).
+func (m *Matrix) AssignFunc(f Float32Func) *Matrix {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, f(m.GetQuick(r, c)))
+		}
+	}
+	return m
+}
+
+// Assigns the result of a function to all cells that satisfy a
+// condition.
+func (m *Matrix) AssignProcedureFunc(cond Float32Procedure, f Float32Func) *Matrix {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			if elem := m.GetQuick(r, c); cond(elem) {
+				m.SetQuick(r, c, f(elem))
+			}
+		}
+	}
+	return m
+}
+
+// Assigns a value to all cells that satisfy a condition.
+func (m *Matrix) AssignProcedure(cond Float32Procedure, value float32) *Matrix {
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			if cond(m.GetQuick(r, c)) {
+				m.SetQuick(r, c, value)
+			}
+		}
+	}
+	return m
+}
+
+// Sets all cells to the values of the given array, indexed
+// [row][column], which must have the same shape as the receiver.
+func (m *Matrix) AssignArray(values [][]float32) (*Matrix, error) {
+	if len(values) != m.Rows() {
+		return m, fmt.Errorf("Must have same number of rows: rows=%d rows()=%d",
+			len(values), m.Rows())
+	}
+	for r, row := range values {
+		if len(row) != m.Columns() {
+			return m, fmt.Errorf("Must have same number of columns in every row: columns=%d columns()=%d",
+				len(row), m.Columns())
+		}
+		for c, value := range row {
+			m.SetQuick(r, c, value)
+		}
+	}
+	return m, nil
+}
+
+// Replaces all cell values of the receiver with the values of other,
+// which must have the same shape.
+func (m *Matrix) AssignMatrix(other Mat) (*Matrix, error) {
+	err := m.checkShape(other)
+	if err != nil {
+		return m, err
+	}
+	if o, ok := other.(*Matrix); ok {
+		other = o.Mat
+	}
+	if other == m.Mat {
+		return m, nil
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, other.GetQuick(r, c))
+		}
+	}
+	return m, nil
+}
+
+// Assigns the result of a function to each cell;
+// x[row,col] = f(x[row,col], y[row,col]).
+func (m *Matrix) AssignMatrixFunc(y Mat, f Float32Float32Func) (*Matrix, error) {
+	err := m.checkShape(y)
+	if err != nil {
+		return m, err
+	}
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Columns(); c++ {
+			m.SetQuick(r, c, f(m.GetQuick(r, c), y.GetQuick(r, c)))
+		}
+	}
+	return m, nil
+}
+
+// Applies a function to each cell and aggregates the results, in row
+// major order.
+func (m *Matrix) Aggregate(aggr Float32Float32Func, f Float32Func) float32 {
+	if m.Size() == 0 {
+		return 0
+	}
+	a := f(m.GetQuick(0, 0))
+	d := 1 // First cell already done.
+	for r := 0; r < m.Rows(); r++ {
+		for c := d; c < m.Columns(); c++ {
+			a = aggr(a, f(m.GetQuick(r, c)))
+		}
+		d = 0
+	}
+	return a
+}
+
+// Applies a function to each corresponding cell of the receiver and
+// other, which must have the same shape, and aggregates the results in
+// row major order; e.g. Sum( x[row,col]*y[row,col] ) with
+// AggregateMatrix(other, Plus, Mult).
+func (m *Matrix) AggregateMatrix(other Mat, aggr Float32Float32Func, f Float32Float32Func) (float32, error) {
+	err := m.checkShape(other)
+	if err != nil {
+		return 0, err
+	}
+	if m.Size() == 0 {
+		return 0, nil
+	}
+	a := f(m.GetQuick(0, 0), other.GetQuick(0, 0))
+	d := 1 // First cell already done.
+	for r := 0; r < m.Rows(); r++ {
+		for c := d; c < m.Columns(); c++ {
+			a = aggr(a, f(m.GetQuick(r, c), other.GetQuick(r, c)))
+		}
+		d = 0
+	}
+	return a, nil
+}
+
+// Constructs and returns a new view of the given column. The view shares
+// the cells of the receiver.
+func (m *Matrix) ViewColumn(column int) (*Vector, error) {
+	if column < 0 || column >= m.Columns() {
+		return nil, fmt.Errorf("Attempted to access %s at column=%d", m.StringShort(), column)
+	}
+	if lv, ok := m.Mat.(lineViewMat); ok {
+		return &Vector{lv.ViewColumn(column)}, nil
+	}
+	return &Vector{m.Like1D(m.Rows(), m.Index(0, column), m.RowStride())}, nil
+}
+
+// Constructs and returns a new view of the given row. The view shares
+// the cells of the receiver.
+func (m *Matrix) ViewRow(row int) (*Vector, error) {
+	if row < 0 || row >= m.Rows() {
+		return nil, fmt.Errorf("Attempted to access %s at row=%d", m.StringShort(), row)
+	}
+	if lv, ok := m.Mat.(lineViewMat); ok {
+		return &Vector{lv.ViewRow(row)}, nil
+	}
+	return &Vector{m.Like1D(m.Columns(), m.Index(row, 0), m.ColumnStride())}, nil
+}
+
+// Constructs and returns a new view which is the transposition of the
+// receiver.
+func (m *Matrix) ViewDice() *Matrix {
+	v := m.View()
+	v.VDice()
+	return &Matrix{v}
+}
+
+// Constructs and returns a new view of the height x width sub-range of
+// cells starting at [row,column].
+func (m *Matrix) ViewPart(row, column, height, width int) (*Matrix, error) {
+	v := m.View()
+	err := v.VPart(row, column, height, width)
+	if err != nil {
+		return nil, err
+	}
+	return &Matrix{v}, nil
+}
+
+// Constructs and returns a new view with the order of the rows reversed.
+func (m *Matrix) ViewRowFlip() *Matrix {
+	v := m.View()
+	v.VRowFlip()
+	return &Matrix{v}
+}
+
+// Constructs and returns a new view with the order of the columns
+// reversed.
+func (m *Matrix) ViewColumnFlip() *Matrix {
+	v := m.View()
+	v.VColumnFlip()
+	return &Matrix{v}
+}
+
+// Constructs and returns a new view of every rowStride-th row and
+// columnStride-th column.
+func (m *Matrix) ViewStrides(rowStride, columnStride int) (*Matrix, error) {
+	v := m.View()
+	err := v.VStrides(rowStride, columnStride)
+	if err != nil {
+		return nil, err
+	}
+	return &Matrix{v}, nil
+}
+
+// Returns a selection view holding the rows for which condition yields
+// true when applied to the row view, together with all columns.
+func (m *Matrix) ViewSelectionProcedure(condition VectorProcedure) *Matrix {
+	matches := make([]int, 0)
+	for i := 0; i < m.Rows(); i++ {
+		row, _ := m.ViewRow(i)
+		if condition(row.Vec) {
+			matches = append(matches, i)
+		}
+	}
+	view, _ := m.ViewSelection(matches, nil) // take all columns
+	return view
+}
+
+// Returns a selection view holding the indicated rows and columns, with
+// view.Get(r,c) == m.Get(rowIndexes[r], columnIndexes[c]). Indexes can
+// occur multiple times and can be in arbitrary order. A nil list selects
+// all indexes of that axis. The view shares the cells of the matrix;
+// modifying the index lists after the call has no effect on the view.
+func (m *Matrix) ViewSelection(rowIndexes, columnIndexes []int) (*Matrix, error) {
+	rowIndexes, err := selectionIndexes(rowIndexes, m.Rows(), "row")
+	if err != nil {
+		return nil, fmt.Errorf("Attempted to access %s at %v", m.StringShort(), err)
+	}
+	columnIndexes, err = selectionIndexes(columnIndexes, m.Columns(), "column")
+	if err != nil {
+		return nil, fmt.Errorf("Attempted to access %s at %v", m.StringShort(), err)
+	}
+	rowOffsets := make([]int, len(rowIndexes))
+	columnOffsets := make([]int, len(columnIndexes))
+	if len(rowIndexes) > 0 && len(columnIndexes) > 0 {
+		base := m.Index(0, 0)
+		for i, r := range rowIndexes {
+			rowOffsets[i] = m.Index(r, 0)
+		}
+		for i, c := range columnIndexes {
+			columnOffsets[i] = m.Index(0, c) - base
+		}
+	}
+	return &Matrix{m.ViewSelectionLike(rowOffsets, columnOffsets)}, nil
+}
+
+// Returns a symmetric permuted view B of the square matrix, with
+// B[i,j] == A[p[i],p[j]]. Returns an error if p is not a permutation of
+// the rows.
+func (m *Matrix) ViewPermuted(p []int) (*Matrix, error) {
+	n := m.Rows()
+	if m.Columns() != n {
+		return nil, fmt.Errorf("Matrix must be square: %s", m.StringShort())
+	}
+	if len(p) != n {
+		return nil, fmt.Errorf("Invalid permutation length: %d, %s", len(p), m.StringShort())
+	}
+	seen := make([]bool, n)
+	for _, i := range p {
+		if i < 0 || i >= n || seen[i] {
+			return nil, fmt.Errorf("Invalid permutation: %v", p)
+		}
+		seen[i] = true
+	}
+	return m.ViewSelection(p, p)
+}
+
+// Returns the given indexes, or all n indexes if indexes is nil. Returns
+// an error naming the axis if an index is out of bounds.
+func selectionIndexes(indexes []int, n int, axis string) ([]int, error) {
+	if indexes == nil {
+		indexes = make([]int, n)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes, nil
+	}
+	for _, index := range indexes {
+		if index < 0 || index >= n {
+			return nil, fmt.Errorf("%s=%d", axis, index)
+		}
+	}
+	return indexes, nil
+}
+
+func (m *Matrix) checkShape(other common.Mat) error {
+	if m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return fmt.Errorf("Incompatible dimensions: %s and %s",
+			m.StringShort(), other.StringShort())
+	}
+	return nil
+}
+
+// Returns the maximum value of the cells together with its location.
+func (m *Matrix) MaxLocation() (float32, int, int) {
+	rowLocation := 0
+	columnLocation := 0
+	maxValue := m.GetQuick(0, 0)
+	d := 1 // First cell already done.
+	for r := 0; r < m.Rows(); r++ {
+		for c := d; c < m.Columns(); c++ {
+			if elem := m.GetQuick(r, c); maxValue < elem {
+				maxValue = elem
+				rowLocation = r
+				columnLocation = c
+			}
+		}
+		d = 0
+	}
+	return maxValue, rowLocation, columnLocation
+}
+
+// Returns the minimum value of the cells together with its location.
+func (m *Matrix) MinLocation() (float32, int, int) {
+	rowLocation := 0
+	columnLocation := 0
+	minValue := m.GetQuick(0, 0)
+	d := 1 // First cell already done.
+	for r := 0; r < m.Rows(); r++ {
+		for c := d; c < m.Columns(); c++ {
+			if elem := m.GetQuick(r, c); minValue > elem {
+				minValue = elem
+				rowLocation = r
+				columnLocation = c
+			}
+		}
+		d = 0
+	}
+	return minValue, rowLocation, columnLocation
+}
+
+// Returns the sum of all cells; Sum( x[i,j] ).
+func (m *Matrix) ZSum() float32 {
+	return m.Aggregate(Plus, Identity)
+}
+
+// Normalizes the matrix, i.e. makes the sum of all cells equal to 1.0.
+// If the matrix contains negative cells then all the values are shifted
+// to ensure non-negativity.
+func (m *Matrix) Normalize() *Matrix {
+	min, _, _ := m.MinLocation()
+	if min < 0 {
+		m.AssignFunc(Add(-min))
+	}
+	max, _, _ := m.MaxLocation()
+	if max == 0 {
+		m.Assign(1.0 / float32(m.Size()))
+	} else {
+		m.AssignFunc(Multiply(1.0 / m.ZSum()))
+	}
+	return m
+}
+
+// Linear algebraic matrix-vector multiplication; z = A * y.
+func (m *Matrix) ZMult(y, z *Vector) (*Vector, error) {
+	return m.ZMultConst(y, z, 1, 0, false)
+}
+
+// Linear algebraic matrix-vector multiplication;
+// z = alpha * A * y + beta*z, where A is the transpose of the receiver
+// if transposeA is true. A new result vector is created if z is nil.
+func (m *Matrix) ZMultConst(y, z *Vector, alpha, beta float32, transposeA bool) (*Vector, error) {
+	rows, columns := m.Rows(), m.Columns()
+	if transposeA {
+		rows, columns = columns, rows
+	}
+	if z == nil {
+		z = &Vector{y.Like(rows)}
+	}
+	if columns != y.Size() || rows > z.Size() {
+		return nil, fmt.Errorf("Incompatible args: %s, %s, %s",
+			m.StringShort(), y.StringShort(), z.StringShort())
+	}
+	for r := 0; r < rows; r++ {
+		var s float32
+		for c := 0; c < columns; c++ {
+			s += m.element(r, c, transposeA) * y.GetQuick(c)
+		}
+		z.SetQuick(r, alpha*s+beta*z.GetQuick(r))
+	}
+	return z, nil
+}
+
+// Linear algebraic matrix-matrix multiplication; C = A x B.
+func (m *Matrix) ZMultMatrix(B, C *Matrix) (*Matrix, error) {
+	return m.ZMultMatrixConst(B, C, 1, 0, false, false)
+}
+
+// Linear algebraic matrix-matrix multiplication;
+// C = alpha * A x B + beta*C, where A and B are replaced by their
+// transposes if transposeA or transposeB are true. A new result matrix
+// is created if C is nil.
+func (m *Matrix) ZMultMatrixConst(B, C *Matrix, alpha, beta float32, transposeA, transposeB bool) (*Matrix, error) {
+	rows, n := m.Rows(), m.Columns()
+	if transposeA {
+		rows, n = n, rows
+	}
+	bn, columns := B.Rows(), B.Columns()
+	if transposeB {
+		bn, columns = columns, bn
+	}
+	if bn != n {
+		return nil, fmt.Errorf("Matrix inner dimensions must agree: %s, %s",
+			m.StringShort(), B.StringShort())
+	}
+	if C == nil {
+		C = &Matrix{m.Like(rows, columns)}
+	}
+	if C.Rows() != rows || C.Columns() != columns {
+		return nil, fmt.Errorf("Incompatible result matrix: %s, %s, %s",
+			m.StringShort(), B.StringShort(), C.StringShort())
+	}
+	if C == m || C == B {
+		return nil, errors.New("Matrices must not be identical")
+	}
+	for c := 0; c < columns; c++ {
+		for r := 0; r < rows; r++ {
+			var s float32
+			for k := 0; k < n; k++ {
+				s += m.element(r, k, transposeA) * B.element(k, c, transposeB)
+			}
+			C.SetQuick(r, c, alpha*s+beta*C.GetQuick(r, c))
+		}
+	}
+	return C, nil
+}
+
+// Returns A[row,column], or A[column,row] if transpose is true.
+func (m *Matrix) element(row, column int, transpose bool) float32 {
+	if transpose {
+		return m.GetQuick(column, row)
+	}
+	return m.GetQuick(row, column)
+}
@@ -0,0 +1,303 @@
+package tfloat32
+
+import (
+	"testing"
+
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+func fillMatrix(A *Matrix) *Matrix {
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			A.SetQuick(r, c, randValue())
+		}
+	}
+	return A
+}
+
+func testMatrixGetSet(t *testing.T, A *Matrix) {
+	value := next(A.GetQuick(2, 3))
+	A.SetQuick(2, 3, value)
+	if a, err := A.Get(2, 3); err != nil || a != value {
+		t.Errorf("expected:%v actual:%v", value, a)
+	}
+	if err := A.Set(A.Rows(), 0, value); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if _, err := A.Get(0, -1); err == nil {
+		t.Error("expected column out of bounds error")
+	}
+	B := A.Copy()
+	if !B.EqualsMatrix(A) {
+		t.Error("expected copy to equal original")
+	}
+	B.SetQuick(0, 0, next(B.GetQuick(0, 0)))
+	if B.GetQuick(0, 0) == A.GetQuick(0, 0) {
+		t.Error("expected copy to be independent of original")
+	}
+}
+
+func testMatrixAssign(t *testing.T, A *Matrix) {
+	B := A.Copy()
+	A.AssignFunc(next)
+	if _, err := A.AssignMatrixFunc(B, Plus); err != nil {
+		t.Fatal(err)
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := Plus(next(B.GetQuick(r, c)), B.GetQuick(r, c))
+			if A.GetQuick(r, c) != expected {
+				t.Errorf("expected:%v actual:%v", expected, A.GetQuick(r, c))
+			}
+		}
+	}
+	values := B.ToArray()
+	if _, err := A.AssignArray(values); err != nil {
+		t.Fatal(err)
+	}
+	if !A.EqualsMatrix(B) {
+		t.Error("expected matrix assigned from array to equal original")
+	}
+	if _, err := A.AssignArray(values[1:]); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+	A.Assign(3.5)
+	if !A.Equals(3.5) {
+		t.Error("expected all cells to equal the assigned value")
+	}
+	if _, err := A.AssignMatrix(B.ViewDice()); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+	A.AssignMatrix(B)
+	A.AssignProcedure(IsLessThan(0.5), 0)
+	A.AssignProcedureFunc(IsGreaterThan(0), Neg)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := -B.GetQuick(r, c)
+			if B.GetQuick(r, c) < 0.5 {
+				expected = 0
+			}
+			if A.GetQuick(r, c) != expected {
+				t.Errorf("expected:%v actual:%v", expected, A.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func testMatrixView(t *testing.T, A *Matrix) {
+	P, err := A.ViewPart(2, 3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := next(A.GetQuick(5, 7))
+	P.SetQuick(3, 4, value)
+	if A.GetQuick(5, 7) != value {
+		t.Error("expected part view to share cells with matrix")
+	}
+	R, err := A.ViewRow(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	C, err := A.ViewColumn(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if R.GetQuick(7) != value || C.GetQuick(5) != value {
+		t.Error("expected row and column views to share cells with matrix")
+	}
+	if A.ViewDice().GetQuick(7, 5) != value {
+		t.Error("expected transposed view to share cells with matrix")
+	}
+	F := A.ViewRowFlip().ViewColumnFlip()
+	if F.GetQuick(0, 0) != A.GetQuick(A.Rows()-1, A.Columns()-1) {
+		t.Error("expected flipped view to reverse both axes")
+	}
+	S, err := A.ViewStrides(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Rows() != 7 || S.Columns() != 6 || S.GetQuick(3, 2) != A.GetQuick(6, 6) {
+		t.Errorf("unexpected strided view: %s", S.StringShort())
+	}
+	if _, err := A.ViewStrides(0, 1); err == nil {
+		t.Error("expected illegal strides error")
+	}
+	if _, err := A.ViewRow(A.Rows()); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if A.String() == "" {
+		t.Error("expected non-empty string")
+	}
+
+	S, err = A.ViewSelection([]int{5, 2, 5}, []int{7, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Rows() != 3 || S.Columns() != 2 || S.GetQuick(0, 0) != value || S.GetQuick(2, 0) != value {
+		t.Errorf("unexpected selection view: %s", S.StringShort())
+	}
+	value = next(A.GetQuick(2, 0))
+	S.SetQuick(1, 1, value)
+	if A.GetQuick(2, 0) != value {
+		t.Error("expected selection view to share cells with matrix")
+	}
+	if R, _ := S.ViewRow(1); R.GetQuick(1) != value {
+		t.Error("expected row of selection view to share cells with matrix")
+	}
+	if S.ViewDice().GetQuick(1, 1) != value {
+		t.Error("expected transposed selection view to share cells with matrix")
+	}
+	if T, _ := S.ViewSelection([]int{1}, nil); T.Columns() != 2 || T.GetQuick(0, 1) != value {
+		t.Error("expected selection of a selection view to share cells with matrix")
+	}
+	if _, err := A.ViewSelection(nil, []int{A.Columns()}); err == nil {
+		t.Error("expected column out of bounds error")
+	}
+	n := 0
+	for r := 0; r < A.Rows(); r++ {
+		if A.GetQuick(r, 0) == value {
+			n++
+		}
+	}
+	V := A.ViewSelectionProcedure(func(row Vec) bool { return row.GetQuick(0) == value })
+	if W, _ := V.ViewColumn(0); V.Rows() != n || V.Columns() != A.Columns() || !W.Equals(value) {
+		t.Errorf("unexpected selection view: %s", V.StringShort())
+	}
+	P, _ = A.ViewPart(0, 0, 5, 5)
+	p := []int{4, 2, 0, 1, 3}
+	B, err := P.ViewPermuted(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range p {
+		for j := range p {
+			if B.GetQuick(i, j) != P.GetQuick(p[i], p[j]) {
+				t.Errorf("expected:%v actual:%v", P.GetQuick(p[i], p[j]), B.GetQuick(i, j))
+			}
+		}
+	}
+	if _, err := P.ViewPermuted([]int{0, 0, 1, 2, 3}); err == nil {
+		t.Error("expected invalid permutation error")
+	}
+	if _, err := A.ViewPermuted(nil); err == nil {
+		t.Error("expected square matrix error")
+	}
+}
+
+func testMatrixAggregate(t *testing.T, A *Matrix) {
+	var sum float32
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			sum += A.GetQuick(r, c)
+		}
+	}
+	if !near(A.ZSum(), sum) {
+		t.Errorf("expected:%v actual:%v", sum, A.ZSum())
+	}
+	maxValue, maxRow, maxColumn := A.MaxLocation()
+	minValue, minRow, minColumn := A.MinLocation()
+	if A.GetQuick(maxRow, maxColumn) != maxValue || A.GetQuick(minRow, minColumn) != minValue {
+		t.Error("expected locations of the extreme values")
+	}
+	if A.Aggregate(Max, Identity) != maxValue || A.Aggregate(Min, Identity) != minValue {
+		t.Errorf("expected extreme values %v and %v", maxValue, minValue)
+	}
+	dot, err := A.AggregateMatrix(A, Plus, Mult)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := A.Aggregate(Plus, Square); !near(dot, expected) {
+		t.Errorf("expected:%v actual:%v", expected, dot)
+	}
+	if _, err := A.AggregateMatrix(A.ViewDice(), Plus, Mult); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+	n := 0
+	A.ForEachNonZero(func(r, c int, a float32) float32 {
+		n++
+		return a
+	})
+	if n != A.Cardinality() {
+		t.Errorf("expected:%d actual:%d", A.Cardinality(), n)
+	}
+	A.SetQuick(0, 0, -1)
+	A.Normalize()
+	if !near(A.ZSum(), 1) || A.GetQuick(0, 0) != 0 {
+		t.Errorf("expected normalized matrix, sum:%v", A.ZSum())
+	}
+}
+
+func testMatrixZMult(t *testing.T, A *Matrix) {
+	y := fillVector(&Vector{A.LikeVector(A.Columns())})
+	z, err := A.ZMult(y, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r := 0; r < A.Rows(); r++ {
+		var expected float32
+		for c := 0; c < A.Columns(); c++ {
+			expected += A.GetQuick(r, c) * y.GetQuick(c)
+		}
+		if !near(z.GetQuick(r), expected) {
+			t.Errorf("expected:%v actual:%v", expected, z.GetQuick(r))
+		}
+	}
+	w, err := A.ZMultConst(z, nil, 1, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := A.ViewDice().ZMult(z, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.EqualsVector(v) {
+		t.Error("expected transposed product to equal product with transposed view")
+	}
+	if _, err := A.ZMult(z, nil); err == nil {
+		t.Error("expected incompatible args error")
+	}
+
+	I := NewIdentity(A.Columns())
+	B, err := A.ZMultMatrix(I, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !B.EqualsMatrix(A) {
+		t.Error("expected product with identity to equal original")
+	}
+	P, err := A.ZMultMatrixConst(A, nil, 1, 0, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Q, err := A.ViewDice().ZMultMatrix(A, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !P.EqualsMatrix(Q) || P.Rows() != A.Columns() {
+		t.Error("expected transposed product to equal product with transposed view")
+	}
+	if _, err := A.ZMultMatrix(A, nil); err == nil {
+		t.Error("expected inner dimension error")
+	}
+}
+
+func testMatrixConvert(t *testing.T, A *Matrix) {
+	X := A.Float64()
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if X.GetQuick(r, c) != float64(A.GetQuick(r, c)) {
+				t.Errorf("expected:%v actual:%v", float64(A.GetQuick(r, c)), X.GetQuick(r, c))
+			}
+		}
+	}
+	_, sparse := A.Mat.(*SparseMat)
+	if _, ok := X.Mat.(*tfloat64.SparseMat); ok != sparse {
+		t.Error("expected converted matrix to keep the storage of the original")
+	}
+	if !NewMatrixFloat64(X).EqualsMatrix(A) || !NewSparseMatrixFloat64(X).EqualsMatrix(A) {
+		t.Error("expected round trip conversion to equal original")
+	}
+	if _, err := A.AssignFloat64(tfloat64.NewMatrix(1, 1).Mat); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+}
@@ -0,0 +1,53 @@
+package tfloat32
+
+import "math"
+
+type Property struct {
+	tolerance float64
+}
+
+// Constructs and returns a new property object with the given tolerance.
+// The absolute value is used.
+func NewProperty(tolerance float64) *Property {
+	return &Property{math.Abs(tolerance)}
+}
+
+// Returns the current tolerance.
+func (p *Property) Tolerance() float64 {
+	return p.tolerance
+}
+
+// Sets the tolerance to math.Abs(tolerance).
+func (p *Property) SetTolerance(tolerance float64) {
+	p.tolerance = math.Abs(tolerance)
+}
+
+// Returns whether a and b differ by no more than the tolerance, i.e.
+// !(Abs(a - b) > tolerance). Cells that are both NaN, or both the same
+// infinity, are considered equal.
+func (p *Property) equals(a, b float32) bool {
+	if a == b {
+		return true
+	}
+	diff := math.Abs(float64(a) - float64(b))
+	if diff != diff {
+		return a != a && b != b
+	}
+	return !(diff > p.tolerance)
+}
+
+// Returns whether the given matrix is square and A[i,j] == A[j,i],
+// within the tolerance.
+func (p *Property) IsSymmetric(A Mat) bool {
+	if A.Rows() != A.Columns() {
+		return false
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < r; c++ {
+			if !p.equals(A.GetQuick(r, c), A.GetQuick(c, r)) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package tfloat32
+
+import "github.com/rwl/goshawk/common"
+
+type DenseVec struct {
+	*common.CoreVec
+	elements []float32 // The elements of this vector.
+}
+
+func (v *DenseVec) GetQuick(index int) float32 {
+	return v.elements[v.Index(index)]
+}
+
+func (v *DenseVec) SetQuick(index int, value float32) {
+	v.elements[v.Index(index)] = value
+}
+
+func (v *DenseVec) Elements() interface{} {
+	return v.elements
+}
+
+func (v *DenseVec) Like(size int) Vec {
+	return NewVector(size).Vec
+}
+
+func (v *DenseVec) LikeMatrix(rows, columns int) Mat {
+	return NewMatrix(rows, columns).Mat
+}
+
+func (v *DenseVec) ViewVec() Vec {
+	return &DenseVec{
+		common.NewCoreVec(v.IsView(), v.Size(), v.Zero(), v.Stride()),
+		v.elements,
+	}
+}
+
+func (v *DenseVec) ViewSelectionLike(offsets []int) Vec {
+	return &SelectedDenseVec{
+		&DenseVec{
+			common.NewCoreVec(true, len(offsets), 0, 1),
+			v.elements,
+		},
+		offsets, 0,
+	}
+}
@@ -0,0 +1,50 @@
+package tfloat32
+
+import "github.com/rwl/goshawk/common"
+
+type SparseVec struct {
+	*common.CoreVec
+	elements map[int]float32 // The non-zero elements of this vector.
+}
+
+func (v *SparseVec) GetQuick(index int) float32 {
+	return v.elements[v.Index(index)]
+}
+
+func (v *SparseVec) SetQuick(index int, value float32) {
+	i := v.Index(index)
+	if value == 0 {
+		delete(v.elements, i)
+	} else {
+		v.elements[i] = value
+	}
+}
+
+func (v *SparseVec) Elements() interface{} {
+	return v.elements
+}
+
+func (v *SparseVec) Like(size int) Vec {
+	return NewSparseVector(size).Vec
+}
+
+func (v *SparseVec) LikeMatrix(rows, columns int) Mat {
+	return NewSparseMatrix(rows, columns).Mat
+}
+
+func (v *SparseVec) ViewVec() Vec {
+	return &SparseVec{
+		common.NewCoreVec(v.IsView(), v.Size(), v.Zero(), v.Stride()),
+		v.elements,
+	}
+}
+
+func (v *SparseVec) ViewSelectionLike(offsets []int) Vec {
+	return &SelectedSparseVec{
+		&SparseVec{
+			common.NewCoreVec(true, len(offsets), 0, 1),
+			v.elements,
+		},
+		offsets, 0,
+	}
+}
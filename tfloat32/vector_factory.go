@@ -0,0 +1,30 @@
+package tfloat32
+
+import "github.com/rwl/goshawk/common"
+
+// Returns a new dense vector of the given size.
+func NewVector(size int) *Vector {
+	return &Vector{
+		&DenseVec{
+			common.NewCoreVec(false, size, 0, 1),
+			make([]float32, size),
+		},
+	}
+}
+
+// Returns a new sparse vector of the given size.
+func NewSparseVector(size int) *Vector {
+	return &Vector{
+		&SparseVec{
+			common.NewCoreVec(false, size, 0, 1),
+			make(map[int]float32),
+		},
+	}
+}
+
+// Returns a new dense vector holding the values of the given array.
+func NewVectorArray(a []float32) *Vector {
+	v := NewVector(len(a))
+	v.AssignArray(a)
+	return v
+}
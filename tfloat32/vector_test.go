@@ -0,0 +1,224 @@
+package tfloat32
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+const (
+	tol   = 1e-4
+	size  = 2*17 + 1
+	nrows = 13
+	ncols = 17
+)
+
+func randValue() float32 {
+	return rand.Float32()
+}
+
+// Returns a value different from a.
+func next(a float32) float32 {
+	return a + 1
+}
+
+// Returns whether a and b are equal to within the test tolerance.
+func near(a, b float32) bool {
+	return math.Abs(float64(a)-float64(b)) <= tol
+}
+
+func fillVector(v *Vector) *Vector {
+	for i := 0; i < v.Size(); i++ {
+		v.SetQuick(i, randValue())
+	}
+	return v
+}
+
+func testVectorGetSet(t *testing.T, A *Vector) {
+	value := next(A.GetQuick(3))
+	A.SetQuick(3, value)
+	if a, err := A.Get(3); err != nil || a != value {
+		t.Errorf("expected:%v actual:%v", value, a)
+	}
+	if err := A.Set(A.Size(), value); err == nil {
+		t.Error("expected index out of bounds error")
+	}
+	if _, err := A.Get(-1); err == nil {
+		t.Error("expected index out of bounds error")
+	}
+	B := A.Copy()
+	if !B.EqualsVector(A) {
+		t.Error("expected copy to equal original")
+	}
+	B.SetQuick(0, next(B.GetQuick(0)))
+	if B.GetQuick(0) == A.GetQuick(0) {
+		t.Error("expected copy to be independent of original")
+	}
+}
+
+func testVectorAssign(t *testing.T, A *Vector) {
+	B := A.Copy()
+	A.AssignFunc(next)
+	for i := 0; i < A.Size(); i++ {
+		if A.GetQuick(i) != next(B.GetQuick(i)) {
+			t.Errorf("expected:%v actual:%v", next(B.GetQuick(i)), A.GetQuick(i))
+		}
+	}
+	if _, err := A.AssignVectorFunc(B, Plus); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < A.Size(); i++ {
+		expected := Plus(next(B.GetQuick(i)), B.GetQuick(i))
+		if A.GetQuick(i) != expected {
+			t.Errorf("expected:%v actual:%v", expected, A.GetQuick(i))
+		}
+	}
+	if _, err := A.AssignArray(B.ToArray()); err != nil {
+		t.Fatal(err)
+	}
+	if !A.EqualsVector(B) {
+		t.Error("expected vector assigned from array to equal original")
+	}
+	A.Assign(3.5)
+	if !A.Equals(3.5) {
+		t.Error("expected all cells to equal the assigned value")
+	}
+	if _, err := A.AssignArray(make([]float32, A.Size()+1)); err == nil {
+		t.Error("expected size mismatch error")
+	}
+	if _, err := A.AssignVector(NewVector(1)); err == nil {
+		t.Error("expected size mismatch error")
+	}
+	A.AssignVector(B)
+	A.AssignProcedure(IsLessThan(0.5), 0)
+	A.AssignProcedureFunc(IsGreaterThan(0), Neg)
+	for i := 0; i < A.Size(); i++ {
+		expected := -B.GetQuick(i)
+		if B.GetQuick(i) < 0.5 {
+			expected = 0
+		}
+		if A.GetQuick(i) != expected {
+			t.Errorf("expected:%v actual:%v", expected, A.GetQuick(i))
+		}
+	}
+}
+
+func testVectorView(t *testing.T, A *Vector) {
+	F := A.ViewFlip()
+	if F.GetQuick(0) != A.GetQuick(A.Size()-1) {
+		t.Errorf("expected:%v actual:%v", A.GetQuick(A.Size()-1), F.GetQuick(0))
+	}
+	P, err := A.ViewPart(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := next(A.GetQuick(7))
+	P.SetQuick(4, value)
+	if A.GetQuick(7) != value {
+		t.Error("expected part view to share cells with vector")
+	}
+	S, err := A.ViewStrides(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Size() != (A.Size()+1)/2 || S.GetQuick(3) != A.GetQuick(6) {
+		t.Error("expected strided view of every second cell")
+	}
+	if _, err := A.ViewPart(A.Size()-1, 2); err == nil {
+		t.Error("expected range error")
+	}
+	if A.String() == "" {
+		t.Error("expected non-empty string")
+	}
+	V, err := A.View([]int{7, 2, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if V.Size() != 3 || V.GetQuick(0) != value || V.GetQuick(2) != value {
+		t.Errorf("unexpected selection view: %s", V.StringShort())
+	}
+	value = next(A.GetQuick(2))
+	V.ViewFlip().SetQuick(1, value)
+	if A.GetQuick(2) != value {
+		t.Error("expected selection view to share cells with vector")
+	}
+	if W, _ := V.View([]int{1}); W.GetQuick(0) != value {
+		t.Error("expected selection of a selection view to share cells with vector")
+	}
+	if _, err := A.View([]int{A.Size()}); err == nil {
+		t.Error("expected index out of bounds error")
+	}
+	n := 0
+	for i := 0; i < A.Size(); i++ {
+		if A.GetQuick(i) == value {
+			n++
+		}
+	}
+	W := A.ViewProcedure(IsEqualTo(value))
+	if W.Size() != n || !W.Equals(value) {
+		t.Errorf("unexpected selection view: %s", W.StringShort())
+	}
+}
+
+func testVectorAggregate(t *testing.T, A *Vector) {
+	var sum, dot float32
+	maxIndex, minIndex := 0, 0
+	for i := 0; i < A.Size(); i++ {
+		a := A.GetQuick(i)
+		sum += a
+		dot += a * a
+		if a > A.GetQuick(maxIndex) {
+			maxIndex = i
+		}
+		if a < A.GetQuick(minIndex) {
+			minIndex = i
+		}
+	}
+	if !near(A.ZSum(), sum) {
+		t.Errorf("expected:%v actual:%v", sum, A.ZSum())
+	}
+	if !near(A.ZDotProduct(A), dot) {
+		t.Errorf("expected:%v actual:%v", dot, A.ZDotProduct(A))
+	}
+	if value, index := A.MaxLocation(); index != maxIndex || value != A.GetQuick(maxIndex) {
+		t.Errorf("expected:%v at %d actual:%v at %d", A.GetQuick(maxIndex), maxIndex, value, index)
+	}
+	if value, index := A.MinLocation(); index != minIndex || value != A.GetQuick(minIndex) {
+		t.Errorf("expected:%v at %d actual:%v at %d", A.GetQuick(minIndex), minIndex, value, index)
+	}
+	if A.Aggregate(Max, Abs) != Max(Abs(A.GetQuick(maxIndex)), Abs(A.GetQuick(minIndex))) {
+		t.Error("expected aggregate of absolute values to be the largest magnitude")
+	}
+	if a, err := A.AggregateVector(A, Plus, Mult); err != nil || !near(a, dot) {
+		t.Errorf("expected:%v actual:%v", dot, a)
+	}
+	if _, err := A.AggregateVector(NewVector(1), Plus, Mult); err == nil {
+		t.Error("expected size mismatch error")
+	}
+	A.SetQuick(0, -1)
+	A.Normalize()
+	if !near(A.ZSum(), 1) || A.GetQuick(0) != 0 {
+		t.Errorf("expected normalized vector, sum:%v", A.ZSum())
+	}
+}
+
+func testVectorConvert(t *testing.T, A *Vector) {
+	x := A.Float64()
+	for i := 0; i < A.Size(); i++ {
+		if x.GetQuick(i) != float64(A.GetQuick(i)) {
+			t.Errorf("expected:%v actual:%v", float64(A.GetQuick(i)), x.GetQuick(i))
+		}
+	}
+	_, sparse := A.Vec.(*SparseVec)
+	if _, ok := x.Vec.(*tfloat64.SparseVec); ok != sparse {
+		t.Error("expected converted vector to keep the storage of the original")
+	}
+	if !NewVectorFloat64(x).EqualsVector(A) || !NewSparseVectorFloat64(x).EqualsVector(A) {
+		t.Error("expected round trip conversion to equal original")
+	}
+	if _, err := A.AssignFloat64(tfloat64.NewVector(1)); err == nil {
+		t.Error("expected size mismatch error")
+	}
+}
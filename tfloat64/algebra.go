@@ -0,0 +1,386 @@
+package tfloat64
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+)
+
+// Package-level algebra facade, with Property tolerance 1e-9. The
+// tolerance may be changed with SetProperty.
+var alg = NewAlgebra(1e-9)
+
+// Linear algebraic matrix operations. Operations that require a matrix
+// decomposition (e.g. Inverse, Det, Rank) compute it on demand and the
+// tolerance of the associated Property is used to decide whether values
+// are to be treated as zero.
+type Algebra struct {
+	property *Property
+}
+
+// Constructs and returns a new algebra object with a Property of the
+// given tolerance.
+func NewAlgebra(tolerance float64) *Algebra {
+	return &Algebra{NewProperty(tolerance)}
+}
+
+// Returns the property object attached to this algebra, defining
+// tolerance.
+func (a *Algebra) Property() *Property {
+	return a.property
+}
+
+// Attaches the given property object to this algebra, defining tolerance.
+func (a *Algebra) SetProperty(property *Property) error {
+	if property == nil {
+		return fmt.Errorf("property must not be nil")
+	}
+	a.property = property
+	return nil
+}
+
+// Returns the condition of matrix A, which is the ratio of largest to
+// smallest singular value.
+func (a *Algebra) Cond(A *Matrix) float64 {
+	return NewDenseSingularValueDecomposition(A).Cond()
+}
+
+// Returns the determinant of matrix A.
+func (a *Algebra) Det(A *Matrix) (float64, error) {
+	return a.lu(A).Det()
+}
+
+// Returns the inverse or pseudo-inverse of matrix A. If A is square the
+// inverse is computed via LU decomposition, otherwise the least squares
+// solution of A*X = I is computed via QR decomposition.
+func (a *Algebra) Inverse(A *Matrix) (*Matrix, error) {
+	I := &Matrix{A.Like(A.Rows(), A.Rows())}
+	for i := 0; i < A.Rows(); i++ {
+		I.SetQuick(i, i, 1)
+	}
+	return a.Solve(A, I)
+}
+
+// Computes the Kronecker product of two matrices. The result has
+// A.Rows()*B.Rows() rows and A.Columns()*B.Columns() columns.
+func (a *Algebra) Kron(A, B *Matrix) *Matrix {
+	rb := B.Rows()
+	cb := B.Columns()
+	C := &Matrix{A.Like(A.Rows()*rb, A.Columns()*cb)}
+	for i := 0; i < A.Rows(); i++ {
+		for j := 0; j < A.Columns(); j++ {
+			aij := A.GetQuick(i, j)
+			if aij == 0 {
+				continue
+			}
+			for k := 0; k < rb; k++ {
+				for l := 0; l < cb; l++ {
+					C.SetQuick(i*rb+k, j*cb+l, aij*B.GetQuick(k, l))
+				}
+			}
+		}
+	}
+	return C
+}
+
+// Linear algebraic matrix-matrix multiplication; C = A x B.
+func (a *Algebra) Mult(A, B *Matrix) (*Matrix, error) {
+	return A.ZMultMatrix(B, nil)
+}
+
+// Linear algebraic matrix-vector multiplication; z = A * y.
+func (a *Algebra) MultVector(A *Matrix, y *Vector) (*Vector, error) {
+	return A.ZMult(y, nil)
+}
+
+// Returns the one-norm of matrix A, which is the maximum absolute column
+// sum.
+func (a *Algebra) Norm1(A *Matrix) float64 {
+	max := 0.0
+	for c := 0; c < A.Columns(); c++ {
+		sum := 0.0
+		for r := 0; r < A.Rows(); r++ {
+			sum += math.Abs(A.GetQuick(r, c))
+		}
+		max = math.Max(max, sum)
+	}
+	return max
+}
+
+// Returns the two-norm of matrix A, which is the maximum singular value;
+// obtained from SVD.
+func (a *Algebra) Norm2(A *Matrix) float64 {
+	return NewDenseSingularValueDecomposition(A).Norm2()
+}
+
+// Returns the Frobenius norm of matrix A, which is
+// Sqrt(Sum(A[i,j]^2)).
+func (a *Algebra) NormF(A *Matrix) float64 {
+	f := 0.0
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			f = math.Hypot(f, A.GetQuick(r, c))
+		}
+	}
+	return f
+}
+
+// Returns the infinity norm of matrix A, which is the maximum absolute row
+// sum.
+func (a *Algebra) NormInf(A *Matrix) float64 {
+	max := 0.0
+	for r := 0; r < A.Rows(); r++ {
+		sum := 0.0
+		for c := 0; c < A.Columns(); c++ {
+			sum += math.Abs(A.GetQuick(r, c))
+		}
+		max = math.Max(max, sum)
+	}
+	return max
+}
+
+// Linear algebraic matrix power; B = A^p = A*A*...*A.
+//
+// p >= 1: B = A*A*...*A.
+// p == 0: B = identity matrix.
+// p < 0: B = Pow(Inverse(A), -p).
+//
+// Computed by repeated squaring.
+func (a *Algebra) Pow(A *Matrix, p int) (*Matrix, error) {
+	if A.Rows() != A.Columns() {
+		return nil, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	n := A.Rows()
+	if p < 0 {
+		inv, err := a.Inverse(A)
+		if err != nil {
+			return nil, err
+		}
+		A = inv
+		p = -p
+	}
+	result := &Matrix{A.Like(n, n)}
+	for i := 0; i < n; i++ {
+		result.SetQuick(i, i, 1)
+	}
+	square := A.Copy()
+	for p > 0 {
+		if p&1 == 1 {
+			tmp, err := result.ZMultMatrix(square, nil)
+			if err != nil {
+				return nil, err
+			}
+			result = tmp
+		}
+		p >>= 1
+		if p > 0 {
+			tmp, err := square.ZMultMatrix(square, nil)
+			if err != nil {
+				return nil, err
+			}
+			square = tmp
+		}
+	}
+	return result, nil
+}
+
+// Returns the effective numerical rank of matrix A, obtained from singular
+// value decomposition.
+func (a *Algebra) Rank(A *Matrix) int {
+	return NewDenseSingularValueDecomposition(A).Rank()
+}
+
+// Solves A*X = B. Returns the solution if A is square, the least squares
+// solution otherwise.
+func (a *Algebra) Solve(A, B *Matrix) (*Matrix, error) {
+	if A.Rows() == A.Columns() {
+		X := B.Copy()
+		err := a.lu(A).SolveMatrix(X)
+		if err != nil {
+			return nil, err
+		}
+		return X, nil
+	}
+	qr, err := NewDenseQRDecompositionTolerance(A, a.property.tolerance)
+	if err != nil {
+		return nil, err
+	}
+	return qr.SolveMatrix(B)
+}
+
+// Solves A*x = b. Returns the solution if A is square, the least squares
+// solution otherwise.
+func (a *Algebra) SolveVector(A *Matrix, b *Vector) (*Vector, error) {
+	if A.Rows() == A.Columns() {
+		x := b.Copy()
+		err := a.lu(A).Solve(x)
+		if err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+	qr, err := NewDenseQRDecompositionTolerance(A, a.property.tolerance)
+	if err != nil {
+		return nil, err
+	}
+	return qr.Solve(b)
+}
+
+// Returns the sum of the diagonal elements of matrix A; Sum(A[i,i]).
+func (a *Algebra) Trace(A *Matrix) float64 {
+	sum := 0.0
+	for i := 0; i < A.Rows() && i < A.Columns(); i++ {
+		sum += A.GetQuick(i, i)
+	}
+	return sum
+}
+
+// Constructs and returns the transposition of the given matrix A. The
+// result is always an independent copy; use A.ViewDice() for a
+// transposed view that writes through to A. For the compressed row and
+// column backends the copy is compressed along the other dimension.
+func (a *Algebra) Transpose(A *Matrix) *Matrix {
+	switch m := A.Mat.(type) {
+	case *SparseRCMat:
+		t := newSparseCCMat(m.Columns(), m.Rows())
+		copy(t.columnPointers, m.rowPointers)
+		t.rowIndexes = append(t.rowIndexes, m.columnIndexes...)
+		t.values = append(t.values, m.values...)
+		return &Matrix{t}
+	case *SparseCCMat:
+		t := newSparseRCMat(m.Columns(), m.Rows())
+		copy(t.rowPointers, m.columnPointers)
+		t.columnIndexes = append(t.columnIndexes, m.rowIndexes...)
+		t.values = append(t.values, m.values...)
+		return &Matrix{t}
+	}
+	return A.ViewDice().Copy()
+}
+
+func (a *Algebra) lu(A *Matrix) *DenseLUDecompositionQuick {
+	lu := NewDenseLUDecompositionQuickTolerance(a.property.tolerance)
+	lu.Decompose(A.Copy())
+	return lu
+}
+
+// Returns a string with (propertyName, propertyValue) pairs. Useful for
+// debugging or to quickly get the rough picture. The report covers the
+// properties of A, a summary of decompositions and norms, followed by the
+// factors of each decomposition that applies to A. Operations that are
+// illegal for A are reported in place of their value.
+func (a *Algebra) VerboseString(A *Matrix) string {
+	var buf bytes.Buffer
+	square := A.Rows() == A.Columns()
+
+	// Cells are rounded to six significant digits so that rounding
+	// errors do not clutter the report. Matrices and vectors start on
+	// the line after their name and are followed by a blank line.
+	f := NewFormatterFormat("%.6G")
+	f.PrintShape = false
+	write := func(name string, value interface{}, err error) {
+		fmt.Fprintf(&buf, "%-28s: ", name)
+		if err != nil {
+			fmt.Fprintf(&buf, "Illegal operation or error: %s\n", err)
+			return
+		}
+		switch v := value.(type) {
+		case *Matrix:
+			fmt.Fprintf(&buf, "%s\n%s\n\n", v.StringShort(), f.MatrixToString(v))
+		case *Vector:
+			fmt.Fprintf(&buf, "%s\n%s\n\n", v.StringShort(), f.VectorToString(v))
+		case float64:
+			fmt.Fprintf(&buf, f.Format+"\n", v)
+		default:
+			fmt.Fprintf(&buf, "%v\n", value)
+		}
+	}
+
+	// Starts a section, separated from the previous one by a blank line.
+	section := func(title string) {
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n\n")) {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(title + ":\n")
+	}
+
+	fmt.Fprintf(&buf, "A = %s\n%s\n", A.StringShort(), f.MatrixToString(A))
+
+	section("Properties")
+	write("rows", A.Rows(), nil)
+	write("columns", A.Columns(), nil)
+	write("density", float64(A.Cardinality())/float64(A.Size()), nil)
+	write("isSquare", square, nil)
+	p := a.property
+	write("isDiagonal", p.IsDiagonal(A), nil)
+	write("isDiagonallyDominantByColumn", p.IsDiagonallyDominantByColumn(A), nil)
+	write("isDiagonallyDominantByRow", p.IsDiagonallyDominantByRow(A), nil)
+	write("isIdentity", p.IsIdentity(A), nil)
+	write("isLowerTriangular", p.IsLowerTriangular(A), nil)
+	write("isOrthogonal", p.IsOrthogonal(A), nil)
+	write("isSkewSymmetric", p.IsSkewSymmetric(A), nil)
+	write("isSymmetric", p.IsSymmetric(A), nil)
+	write("isTridiagonal", p.IsTridiagonal(A), nil)
+	write("isUpperTriangular", p.IsUpperTriangular(A), nil)
+	write("lowerBandwidth", p.LowerBandwidth(A), nil)
+	write("semiBandwidth", p.SemiBandwidth(A), nil)
+	write("upperBandwidth", p.UpperBandwidth(A), nil)
+
+	section("Summary of decompositions and norms")
+	write("cond", a.Cond(A), nil)
+	det, err := a.Det(A)
+	write("det", det, err)
+	write("norm1", a.Norm1(A), nil)
+	write("norm2", a.Norm2(A), nil)
+	write("normF", a.NormF(A), nil)
+	write("normInfinity", a.NormInf(A), nil)
+	write("rank", a.Rank(A), nil)
+	write("trace", a.Trace(A), nil)
+
+	section("LU decomposition")
+	lu := a.lu(A)
+	write("isNonSingular", lu.IsNonSingular(), nil)
+	write("pivot", lu.Pivot(), nil)
+	write("L", lu.L(), nil)
+	write("U", lu.U(), nil)
+	inv, err := a.Inverse(A)
+	write("inverse(A)", inv, err)
+
+	section("QR decomposition")
+	qr, err := NewDenseQRDecompositionTolerance(A, a.property.tolerance)
+	if err != nil {
+		write("QR", nil, err)
+	} else {
+		write("isFullRank", qr.IsFullRank(), nil)
+		write("Q", qr.Q(), nil)
+		write("R", qr.R(), nil)
+	}
+
+	section("Cholesky decomposition")
+	chol, err := NewDenseCholeskyDecompositionTolerance(A, a.property.tolerance)
+	if err != nil {
+		write("Cholesky", nil, err)
+	} else {
+		write("isSPD", chol.IsSymmetricPositiveDefinite(), nil)
+		write("L", chol.L(), nil)
+	}
+
+	section("Eigenvalue decomposition")
+	eig, err := NewDenseEigenvalueDecompositionTolerance(A, a.property.tolerance)
+	if err != nil {
+		write("Eigenvalues", nil, err)
+	} else {
+		write("realEigenvalues", eig.RealEigenvalues(), nil)
+		write("imagEigenvalues", eig.ImagEigenvalues(), nil)
+		write("D", eig.D(), nil)
+		write("V", eig.V(), nil)
+	}
+
+	section("Singular value decomposition")
+	svd := NewDenseSingularValueDecomposition(A)
+	write("S", svd.S(), nil)
+	write("U", svd.U(), nil)
+	write("V", svd.V(), nil)
+	write("pseudoInverse(A)", svd.PseudoInverse(), nil)
+
+	return buf.String()
+}
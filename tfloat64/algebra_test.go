@@ -0,0 +1,244 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func testAlgebraNorms(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{1, -2, 3},
+		{-4, 5, -6},
+	})
+	if v := alg.Norm1(A); math.Abs(9-v) > tol {
+		t.Errorf("expected:%g actual:%g", 9.0, v)
+	}
+	if v := alg.NormInf(A); math.Abs(15-v) > tol {
+		t.Errorf("expected:%g actual:%g", 15.0, v)
+	}
+	if v := alg.NormF(A); math.Abs(math.Sqrt(91)-v) > tol {
+		t.Errorf("expected:%g actual:%g", math.Sqrt(91), v)
+	}
+	if v := alg.Norm2(A); math.Abs(9.508032000695723-v) > tol {
+		t.Errorf("expected:%g actual:%g", 9.508032000695723, v)
+	}
+	if v := alg.Trace(A); math.Abs(6-v) > tol {
+		t.Errorf("expected:%g actual:%g", 6.0, v)
+	}
+	if v := alg.Rank(A); v != 2 {
+		t.Errorf("expected:%d actual:%d", 2, v)
+	}
+	T := alg.Transpose(A)
+	if T.Rows() != 3 || T.GetQuick(2, 1) != -6 {
+		t.Errorf("expected transpose of %s", A.StringShort())
+	}
+}
+
+func testAlgebraInverse(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{4, 7},
+		{2, 6},
+	})
+	det, err := alg.Det(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(10-det) > tol {
+		t.Errorf("expected:%g actual:%g", 10.0, det)
+	}
+	inv, err := alg.Inverse(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]float64{
+		{0.6, -0.7},
+		{-0.2, 0.4},
+	}
+	for r := range expected {
+		for c := range expected[r] {
+			if math.Abs(expected[r][c]-inv.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", expected[r][c], inv.GetQuick(r, c))
+			}
+		}
+	}
+
+	// A^-2 * A^3 == A
+	P, err := alg.Pow(A, -2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Q, _ := alg.Pow(A, 3)
+	PQ, _ := alg.Mult(P, Q)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if math.Abs(A.GetQuick(r, c)-PQ.GetQuick(r, c)) > 1e-9 {
+				t.Errorf("expected:%g actual:%g", A.GetQuick(r, c), PQ.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func testAlgebraKron(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{1, 2},
+		{3, 0},
+	})
+	B := &Matrix{A.Like(1, 2)}
+	B.AssignArray([][]float64{{1, -1}})
+	C := alg.Kron(A, B)
+	expected := [][]float64{
+		{1, -1, 2, -2},
+		{3, -3, 0, 0},
+	}
+	if C.Rows() != 2 || C.Columns() != 4 {
+		t.Fatalf("expected:2 x 4 actual:%s", C.StringShort())
+	}
+	for r := range expected {
+		for c := range expected[r] {
+			if expected[r][c] != C.GetQuick(r, c) {
+				t.Errorf("expected:%g actual:%g", expected[r][c], C.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func testAlgebraVerboseString(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{0, 1, 0, 0},
+		{3, 0, 2, 0},
+		{0, 2, 0, 3},
+		{0, 0, 1, 0},
+	})
+	s := alg.VerboseString(A)
+	for _, name := range []string{"isSymmetric", "semiBandwidth", "rank", "norm2", "realEigenvalues", "pseudoInverse(A)"} {
+		if !strings.Contains(s, name) {
+			t.Errorf("expected %q in verbose string", name)
+		}
+	}
+}
+
+func testAlgebraSetProperty(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{1, 1},
+		{1, 1 + 1e-6},
+		{1, 1},
+	})
+	b := NewVectorArray([]float64{1, 2, 3})
+	if _, err := NewAlgebra(1e-9).SolveVector(A, b); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// The QR decomposition used by SolveVector must use the tolerance of
+	// the algebra's property, not the package default.
+	a := NewAlgebra(1e-9)
+	a.SetProperty(NewProperty(1e-3))
+	if _, err := a.SolveVector(A, b); err == nil {
+		t.Errorf("expected a rank deficient error")
+	}
+
+	// Likewise the symmetry tests of the Cholesky and eigenvalue
+	// decompositions.
+	S := &Matrix{A.Like(2, 2)}
+	S.AssignArray([][]float64{
+		{2, 1},
+		{1 + 1e-6, 2},
+	})
+	if chol, _ := NewDenseCholeskyDecompositionTolerance(S, 1e-3); !chol.IsSymmetricPositiveDefinite() {
+		t.Errorf("expected symmetric positive definite within tolerance")
+	}
+	if chol, _ := NewDenseCholeskyDecomposition(S); chol.IsSymmetricPositiveDefinite() {
+		t.Errorf("expected asymmetric with the default tolerance")
+	}
+	if eig, _ := NewDenseEigenvalueDecompositionTolerance(S, 1e-3); !eig.isSymmetric {
+		t.Errorf("expected symmetric within tolerance")
+	}
+	if !strings.Contains(a.VerboseString(S), fmt.Sprintf("%-28s: true", "isSPD")) {
+		t.Errorf("expected the algebra tolerance in the Cholesky report")
+	}
+}
+
+func testAlgebraTranspose(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{1, 0, 3},
+		{0, 5, 0},
+	})
+	T := alg.Transpose(A)
+	if T.Rows() != A.Columns() || T.Columns() != A.Rows() {
+		t.Errorf("expected:%dx%d actual:%dx%d", A.Columns(), A.Rows(), T.Rows(), T.Columns())
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if A.GetQuick(r, c) != T.GetQuick(c, r) {
+				t.Errorf("expected:%g actual:%g", A.GetQuick(r, c), T.GetQuick(c, r))
+			}
+		}
+	}
+
+	// The transpose is a copy, so writing to it leaves A unchanged.
+	T.SetQuick(2, 0, 7)
+	if A.GetQuick(0, 2) != 3 {
+		t.Errorf("expected:%g actual:%g", 3.0, A.GetQuick(0, 2))
+	}
+}
+
+func TestDenseAlgebraNorms(t *testing.T) {
+	testAlgebraNorms(t, NewMatrix(2, 3))
+}
+
+func TestDenseAlgebraInverse(t *testing.T) {
+	testAlgebraInverse(t, NewMatrix(2, 2))
+}
+
+func TestDenseAlgebraKron(t *testing.T) {
+	testAlgebraKron(t, NewMatrix(2, 2))
+}
+
+func TestDenseAlgebraVerboseString(t *testing.T) {
+	testAlgebraVerboseString(t, NewMatrix(4, 4))
+}
+
+func TestSparseAlgebraNorms(t *testing.T) {
+	testAlgebraNorms(t, NewSparseMatrix(2, 3))
+}
+
+func TestSparseAlgebraInverse(t *testing.T) {
+	testAlgebraInverse(t, NewSparseMatrix(2, 2))
+}
+
+func TestSparseAlgebraKron(t *testing.T) {
+	testAlgebraKron(t, NewSparseMatrix(2, 2))
+}
+
+func TestDenseAlgebraSetProperty(t *testing.T) {
+	testAlgebraSetProperty(t, NewMatrix(3, 2))
+}
+
+func TestSparseAlgebraSetProperty(t *testing.T) {
+	testAlgebraSetProperty(t, NewSparseMatrix(3, 2))
+}
+
+func TestDenseAlgebraTranspose(t *testing.T) {
+	testAlgebraTranspose(t, NewMatrix(2, 3))
+}
+
+func TestSparseAlgebraTranspose(t *testing.T) {
+	testAlgebraTranspose(t, NewSparseMatrix(2, 3))
+}
+
+func TestSparseRCAlgebraTranspose(t *testing.T) {
+	A := NewSparseRCMatrix(2, 3)
+	testAlgebraTranspose(t, A)
+	if _, ok := alg.Transpose(A).Mat.(*SparseCCMat); !ok {
+		t.Errorf("expected a compressed column transpose of %s", A.StringShort())
+	}
+}
+
+func TestSparseCCAlgebraTranspose(t *testing.T) {
+	A := NewSparseCCMatrix(2, 3)
+	testAlgebraTranspose(t, A)
+	if _, ok := alg.Transpose(A).Mat.(*SparseRCMat); !ok {
+		t.Errorf("expected a compressed row transpose of %s", A.StringShort())
+	}
+}
@@ -0,0 +1,519 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+)
+
+// Returns an approximate minimum degree (AMD) ordering of the square
+// matrix A, computed on the pattern of A+A'. The symmetric permutation
+// A[p,p] of A, where p is the returned permutation, typically has much
+// less fill-in under Cholesky or LU factorization than A itself.
+//
+// Reference: P. R. Amestoy, T. A. Davis and I. S. Duff, "An approximate
+// minimum degree ordering algorithm", SIAM J. Matrix Anal. Appl., 1996,
+// in the formulation of T. A. Davis, "Direct Methods for Sparse Linear
+// Systems", SIAM, 2006.
+func AMDOrder(A *Matrix) ([]int, error) {
+	if A.Rows() != A.Columns() {
+		return nil, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	cc := columnCompressed(A.Mat)
+	Cp, Ci := symmetricPattern(cc)
+	return amd(A.Rows(), Cp, Ci), nil
+}
+
+// Returns the pattern of A+A' without the diagonal, in compressed column
+// form.
+func symmetricPattern(A *SparseCCMat) ([]int, []int) {
+	n := A.Columns()
+	Ap := A.columnPointers
+	Ai := A.rowIndexes
+	count := make([]int, n)
+	for j := 0; j < n; j++ {
+		for p := Ap[j]; p < Ap[j+1]; p++ {
+			if i := Ai[p]; i != j {
+				count[i]++
+				count[j]++
+			}
+		}
+	}
+	Cp := make([]int, n+1)
+	for j := 0; j < n; j++ {
+		Cp[j+1] = Cp[j] + count[j]
+	}
+	next := make([]int, n)
+	copy(next, Cp[:n])
+	Ci := make([]int, Cp[n])
+	for j := 0; j < n; j++ {
+		for p := Ap[j]; p < Ap[j+1]; p++ {
+			if i := Ai[p]; i != j {
+				Ci[next[j]] = i
+				next[j]++
+				Ci[next[i]] = j
+				next[i]++
+			}
+		}
+	}
+	return dedupePattern(n, Cp, Ci)
+}
+
+// Returns the pattern of A'*A without the diagonal, in compressed column
+// form. Rows of A with more than max(16, 10*sqrt(n)) entries are ignored
+// as they would make A'*A dense.
+func normalPattern(A *SparseCCMat) ([]int, []int) {
+	n := A.Columns()
+	dense := int(math.Max(16, 10*math.Sqrt(float64(n))))
+	if dense > n-2 {
+		dense = n - 2
+	}
+	rc := A.RowCompressed().Mat.(*SparseRCMat)
+	Ap := A.columnPointers
+	Ai := A.rowIndexes
+	mark := make([]int, n)
+	for j := range mark {
+		mark[j] = -1
+	}
+	Cp := make([]int, n+1)
+	Ci := make([]int, 0)
+	for j := 0; j < n; j++ {
+		mark[j] = j
+		for p := Ap[j]; p < Ap[j+1]; p++ {
+			i := Ai[p]
+			if rc.rowPointers[i+1]-rc.rowPointers[i] > dense {
+				continue
+			}
+			for q := rc.rowPointers[i]; q < rc.rowPointers[i+1]; q++ {
+				if c := rc.columnIndexes[q]; mark[c] != j {
+					mark[c] = j
+					Ci = append(Ci, c)
+				}
+			}
+		}
+		Cp[j+1] = len(Ci)
+	}
+	return Cp, Ci
+}
+
+// Removes duplicate entries from each column of the given pattern.
+func dedupePattern(n int, Cp, Ci []int) ([]int, []int) {
+	mark := make([]int, n)
+	for j := range mark {
+		mark[j] = -1
+	}
+	nz := 0
+	for j := 0; j < n; j++ {
+		p := Cp[j]
+		Cp[j] = nz
+		for ; p < Cp[j+1]; p++ {
+			if i := Ci[p]; mark[i] != j {
+				mark[i] = j
+				Ci[nz] = i
+				nz++
+			}
+		}
+	}
+	Cp[n] = nz
+	return Cp, Ci[:nz]
+}
+
+// Returns -(i+2), which marks a node index as flipped.
+func flip(i int) int {
+	return -i - 2
+}
+
+// Clears the workspace w if the mark would overflow.
+func amdClear(mark, lemax int, w []int, n int) int {
+	if mark < 2 || mark+lemax < 0 {
+		for k := 0; k < n; k++ {
+			if w[k] != 0 {
+				w[k] = 1
+			}
+		}
+		mark = 2
+	}
+	return mark
+}
+
+// Depth-first search and postorder of a tree rooted at node j.
+func treeDFS(j, k int, head, next, post, stack []int) int {
+	top := 0
+	stack[0] = j
+	for top >= 0 {
+		p := stack[top]
+		i := head[p]
+		if i == -1 {
+			top--
+			post[k] = p
+			k++
+		} else {
+			head[p] = next[i]
+			top++
+			stack[top] = i
+		}
+	}
+	return k
+}
+
+// Returns the approximate minimum degree ordering of the symmetric n x n
+// pattern C, given in compressed column form without the diagonal. The
+// slices are consumed.
+func amd(n int, Cp, Ci []int) []int {
+	if n == 0 {
+		return []int{}
+	}
+	dense := int(math.Max(16, 10*math.Sqrt(float64(n))))
+	if dense > n-2 {
+		dense = n - 2
+	}
+	cnz := Cp[n]
+	nzmax := cnz + cnz/5 + 2*n
+	Ci = append(Ci, make([]int, nzmax-len(Ci))...)
+
+	P := make([]int, n+1)
+	length := make([]int, n+1)
+	nv := make([]int, n+1)
+	next := make([]int, n+1)
+	head := make([]int, n+1)
+	elen := make([]int, n+1)
+	degree := make([]int, n+1)
+	w := make([]int, n+1)
+	hhead := make([]int, n+1)
+	last := P
+
+	// Initialize the quotient graph.
+	for k := 0; k < n; k++ {
+		length[k] = Cp[k+1] - Cp[k]
+	}
+	length[n] = 0
+	for i := 0; i <= n; i++ {
+		head[i] = -1
+		last[i] = -1
+		next[i] = -1
+		hhead[i] = -1
+		nv[i] = 1
+		w[i] = 1
+		elen[i] = 0
+		degree[i] = length[i]
+	}
+	mark := amdClear(0, 0, w, n)
+	elen[n] = -2
+	Cp[n] = -1
+	w[n] = 0
+
+	// Initialize the degree lists.
+	nel := 0
+	for i := 0; i < n; i++ {
+		d := degree[i]
+		if d == 0 {
+			elen[i] = -2
+			nel++
+			Cp[i] = -1
+			w[i] = 0
+		} else if d > dense {
+			nv[i] = 0
+			elen[i] = -1
+			nel++
+			Cp[i] = flip(n)
+			nv[n]++
+		} else {
+			if head[d] != -1 {
+				last[head[d]] = i
+			}
+			next[i] = head[d]
+			head[d] = i
+		}
+	}
+
+	mindeg := 0
+	lemax := 0
+	for nel < n {
+		// Select the node of minimum approximate degree.
+		k := -1
+		for ; mindeg < n; mindeg++ {
+			if k = head[mindeg]; k != -1 {
+				break
+			}
+		}
+		if next[k] != -1 {
+			last[next[k]] = -1
+		}
+		head[mindeg] = next[k]
+		elenk := elen[k]
+		nvk := nv[k]
+		nel += nvk
+
+		// Garbage collection.
+		if elenk > 0 && cnz+mindeg >= nzmax {
+			for j := 0; j < n; j++ {
+				if p := Cp[j]; p >= 0 {
+					Cp[j] = Ci[p]
+					Ci[p] = flip(j)
+				}
+			}
+			q := 0
+			for p := 0; p < cnz; {
+				j := flip(Ci[p])
+				p++
+				if j >= 0 {
+					Ci[q] = Cp[j]
+					Cp[j] = q
+					q++
+					for k3 := 0; k3 < length[j]-1; k3++ {
+						Ci[q] = Ci[p]
+						q++
+						p++
+					}
+				}
+			}
+			cnz = q
+		}
+
+		// Construct the new element.
+		dk := 0
+		nv[k] = -nvk
+		p := Cp[k]
+		pk1 := cnz
+		if elenk == 0 {
+			pk1 = p
+		}
+		pk2 := pk1
+		for k1 := 1; k1 <= elenk+1; k1++ {
+			var e, pj, ln int
+			if k1 > elenk {
+				e = k
+				pj = p
+				ln = length[k] - elenk
+			} else {
+				e = Ci[p]
+				p++
+				pj = Cp[e]
+				ln = length[e]
+			}
+			for k2 := 1; k2 <= ln; k2++ {
+				i := Ci[pj]
+				pj++
+				nvi := nv[i]
+				if nvi <= 0 {
+					continue
+				}
+				dk += nvi
+				nv[i] = -nvi
+				Ci[pk2] = i
+				pk2++
+				if next[i] != -1 {
+					last[next[i]] = last[i]
+				}
+				if last[i] != -1 {
+					next[last[i]] = next[i]
+				} else {
+					head[degree[i]] = next[i]
+				}
+			}
+			if e != k {
+				Cp[e] = flip(k)
+				w[e] = 0
+			}
+		}
+		if elenk != 0 {
+			cnz = pk2
+		}
+		degree[k] = dk
+		Cp[k] = pk1
+		length[k] = pk2 - pk1
+		elen[k] = -2
+
+		// Find set differences.
+		mark = amdClear(mark, lemax, w, n)
+		for pk := pk1; pk < pk2; pk++ {
+			i := Ci[pk]
+			eln := elen[i]
+			if eln <= 0 {
+				continue
+			}
+			nvi := -nv[i]
+			wnvi := mark - nvi
+			for p := Cp[i]; p <= Cp[i]+eln-1; p++ {
+				e := Ci[p]
+				if w[e] >= mark {
+					w[e] -= nvi
+				} else if w[e] != 0 {
+					w[e] = degree[e] + wnvi
+				}
+			}
+		}
+
+		// Degree update.
+		for pk := pk1; pk < pk2; pk++ {
+			i := Ci[pk]
+			p1 := Cp[i]
+			p2 := p1 + elen[i] - 1
+			pn := p1
+			h := 0
+			d := 0
+			for p := p1; p <= p2; p++ {
+				e := Ci[p]
+				if w[e] != 0 {
+					dext := w[e] - mark
+					if dext > 0 {
+						d += dext
+						Ci[pn] = e
+						pn++
+						h += e
+					} else {
+						Cp[e] = flip(k)
+						w[e] = 0
+					}
+				}
+			}
+			elen[i] = pn - p1 + 1
+			p3 := pn
+			p4 := p1 + length[i]
+			for p := p2 + 1; p < p4; p++ {
+				j := Ci[p]
+				nvj := nv[j]
+				if nvj <= 0 {
+					continue
+				}
+				d += nvj
+				Ci[pn] = j
+				pn++
+				h += j
+			}
+			if d == 0 {
+				Cp[i] = flip(k)
+				nvi := -nv[i]
+				dk -= nvi
+				nvk += nvi
+				nel += nvi
+				nv[i] = 0
+				elen[i] = -1
+			} else {
+				if d < degree[i] {
+					degree[i] = d
+				}
+				Ci[pn] = Ci[p3]
+				Ci[p3] = Ci[p1]
+				Ci[p1] = k
+				length[i] = pn - p1 + 1
+				if h < 0 {
+					h = -h
+				}
+				h %= n
+				next[i] = hhead[h]
+				hhead[h] = i
+				last[i] = h
+			}
+		}
+		degree[k] = dk
+		if dk > lemax {
+			lemax = dk
+		}
+		mark = amdClear(mark+lemax, lemax, w, n)
+
+		// Supernode detection.
+		for pk := pk1; pk < pk2; pk++ {
+			i := Ci[pk]
+			if nv[i] >= 0 {
+				continue
+			}
+			h := last[i]
+			i = hhead[h]
+			hhead[h] = -1
+			for ; i != -1 && next[i] != -1; i, mark = next[i], mark+1 {
+				ln := length[i]
+				eln := elen[i]
+				for p := Cp[i] + 1; p <= Cp[i]+ln-1; p++ {
+					w[Ci[p]] = mark
+				}
+				jlast := i
+				for j := next[i]; j != -1; {
+					ok := length[j] == ln && elen[j] == eln
+					for p := Cp[j] + 1; ok && p <= Cp[j]+ln-1; p++ {
+						if w[Ci[p]] != mark {
+							ok = false
+						}
+					}
+					if ok {
+						Cp[j] = flip(i)
+						nv[i] += nv[j]
+						nv[j] = 0
+						elen[j] = -1
+						j = next[j]
+						next[jlast] = j
+					} else {
+						jlast = j
+						j = next[j]
+					}
+				}
+			}
+		}
+
+		// Finalize the new element.
+		p = pk1
+		for pk := pk1; pk < pk2; pk++ {
+			i := Ci[pk]
+			nvi := -nv[i]
+			if nvi <= 0 {
+				continue
+			}
+			nv[i] = nvi
+			d := degree[i] + dk - nvi
+			if d > n-nel-nvi {
+				d = n - nel - nvi
+			}
+			if head[d] != -1 {
+				last[head[d]] = i
+			}
+			next[i] = head[d]
+			last[i] = -1
+			head[d] = i
+			if d < mindeg {
+				mindeg = d
+			}
+			degree[i] = d
+			Ci[p] = i
+			p++
+		}
+		nv[k] = nvk
+		length[k] = p - pk1
+		if length[k] == 0 {
+			Cp[k] = -1
+			w[k] = 0
+		}
+		if elenk != 0 {
+			cnz = p
+		}
+	}
+
+	// Postorder the assembly tree.
+	for i := 0; i < n; i++ {
+		Cp[i] = flip(Cp[i])
+	}
+	for j := 0; j <= n; j++ {
+		head[j] = -1
+	}
+	for j := n; j >= 0; j-- {
+		if nv[j] > 0 {
+			continue
+		}
+		next[j] = head[Cp[j]]
+		head[Cp[j]] = j
+	}
+	for e := n; e >= 0; e-- {
+		if nv[e] <= 0 {
+			continue
+		}
+		if Cp[e] != -1 {
+			next[e] = head[Cp[e]]
+			head[Cp[e]] = e
+		}
+	}
+	k := 0
+	for i := 0; i <= n; i++ {
+		if Cp[i] == -1 {
+			k = treeDFS(i, k, head, next, P, w)
+		}
+	}
+	return P[:n]
+}
@@ -0,0 +1,411 @@
+package tfloat64
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/rwl/goshawk/common"
+)
+
+// The binary encoding of a vector, matrix or cube starts with a header of
+// the magic string, the format version, the kind of array and the type of
+// its backend, followed by the shape as unsigned varints. Dense backends
+// are followed by all cells in row-major order, each as the 8 byte
+// little-endian IEEE 754 representation of its value. Sparse backends are
+// followed by the number of non-zero cells as an unsigned varint and, in
+// ascending order of their row-major index, the difference between the
+// index of each non-zero cell and that of the previous one as an unsigned
+// varint together with its value.
+//
+// Decoders accept all versions up to and including binaryVersion, so the
+// version must be incremented whenever the encoding changes.
+const (
+	binaryMagic   = "GSHK"
+	binaryVersion = 1
+)
+
+// The kinds of arrays in the binary encoding.
+const (
+	binaryVector = 'V'
+	binaryMatrix = 'M'
+	binaryCube   = 'C'
+)
+
+// The backend types in the binary encoding.
+const (
+	binaryDense    = 0 // Dense backends and any backend not listed below.
+	binarySparse   = 1 // Hash based sparse backends.
+	binarySparseRC = 2 // Sparse matrices in compressed row storage.
+	binarySparseCC = 3 // Sparse matrices in compressed column storage.
+)
+
+// The non-zero cells of an array by row-major index, sortable by index.
+type binaryCells struct {
+	indexes []int
+	values  []float64
+}
+
+func (b *binaryCells) add(index int, value float64) {
+	b.indexes = append(b.indexes, index)
+	b.values = append(b.values, value)
+}
+
+func (b *binaryCells) Len() int {
+	return len(b.indexes)
+}
+
+func (b *binaryCells) Less(i, j int) bool {
+	return b.indexes[i] < b.indexes[j]
+}
+
+func (b *binaryCells) Swap(i, j int) {
+	b.indexes[i], b.indexes[j] = b.indexes[j], b.indexes[i]
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+}
+
+// Adds the non-zero cells of the given map of elements, whose keys are
+// row-major indexes if the backend is not a view, or otherwise of the
+// array with the given size whose cell at each row-major index is
+// returned by get.
+func (b *binaryCells) addAll(elements map[int]float64, isView bool, size int, get func(int) float64) {
+	if !isView {
+		for index, value := range elements {
+			b.add(index, value)
+		}
+		sort.Sort(b)
+		return
+	}
+	for index := 0; index < size; index++ {
+		if value := get(index); value != 0 {
+			b.add(index, value)
+		}
+	}
+}
+
+// Returns the binary encoding of an array of the given kind, backend type
+// and shape. The cells of dense backends are returned by get for each
+// row-major index and those of sparse backends are given by cells.
+func marshalBinary(kind, backend byte, shape []int, get func(int) float64, cells *binaryCells) []byte {
+	size := 1
+	for _, n := range shape {
+		size *= n
+	}
+	buf := make([]byte, 0, len(binaryMagic)+3+len(shape)*binary.MaxVarintLen64)
+	buf = append(buf, binaryMagic...)
+	buf = append(buf, binaryVersion, kind, backend)
+	for _, n := range shape {
+		buf = binary.AppendUvarint(buf, uint64(n))
+	}
+	if backend == binaryDense {
+		for index := 0; index < size; index++ {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(get(index)))
+		}
+		return buf
+	}
+	buf = binary.AppendUvarint(buf, uint64(cells.Len()))
+	previous := 0
+	for k, index := range cells.indexes {
+		buf = binary.AppendUvarint(buf, uint64(index-previous))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(cells.values[k]))
+		previous = index
+	}
+	return buf
+}
+
+// Decodes the binary encoding of an array.
+type binaryDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *binaryDecoder) fail(format string, a ...interface{}) {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, a...)
+	}
+}
+
+func (d *binaryDecoder) readByte() byte {
+	if len(d.data) < 1 {
+		d.fail("Truncated binary data")
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+// Returns the next unsigned varint, which must be at most max.
+func (d *binaryDecoder) readUvarint(max int) int {
+	x, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail("Truncated binary data")
+		return 0
+	}
+	d.data = d.data[n:]
+	if x > uint64(max) {
+		d.fail("Invalid binary data: %d exceeds %d", x, max)
+		return 0
+	}
+	return int(x)
+}
+
+func (d *binaryDecoder) readFloat64() float64 {
+	if len(d.data) < 8 {
+		d.fail("Truncated binary data")
+		return 0
+	}
+	x := math.Float64frombits(binary.LittleEndian.Uint64(d.data))
+	d.data = d.data[8:]
+	return x
+}
+
+// Decodes the header of an array of the given kind with the given number
+// of dimensions and returns its backend type, shape and size.
+func (d *binaryDecoder) header(kind byte, dims int) (byte, []int, int) {
+	if len(d.data) < len(binaryMagic) || string(d.data[:len(binaryMagic)]) != binaryMagic {
+		d.fail("Missing binary header")
+		return 0, nil, 0
+	}
+	d.data = d.data[len(binaryMagic):]
+	if version := d.readByte(); d.err == nil && (version < 1 || version > binaryVersion) {
+		d.fail("Unsupported binary version: %d", version)
+	}
+	if k := d.readByte(); d.err == nil && k != kind {
+		d.fail("Expected binary %c, found %c", kind, k)
+	}
+	backend := d.readByte()
+	if d.err == nil && backend > binarySparseCC {
+		d.fail("Unsupported binary backend: %d", backend)
+	}
+	shape := make([]int, dims)
+	size := 1
+	for i := range shape {
+		shape[i] = d.readUvarint(math.MaxInt32)
+		if shape[i] != 0 && size > math.MaxInt/shape[i] {
+			d.fail("Invalid binary shape: %v", shape)
+		}
+		size *= shape[i]
+	}
+	if d.err != nil {
+		return 0, nil, 0
+	}
+	return backend, shape, size
+}
+
+// Decodes the cells of a dense array of the given size.
+func (d *binaryDecoder) dense(size int) []float64 {
+	if len(d.data)%8 != 0 || len(d.data)/8 != size {
+		d.fail("Expected %d cells of binary data, found %d bytes", size, len(d.data))
+		return nil
+	}
+	values := make([]float64, size)
+	for i := range values {
+		values[i] = d.readFloat64()
+	}
+	return values
+}
+
+// Decodes the non-zero cells of a sparse array of the given size and
+// passes each of them to set.
+func (d *binaryDecoder) sparse(size int, set func(int, float64)) {
+	max := len(d.data) / 9
+	if size < max {
+		max = size
+	}
+	nonZeros := d.readUvarint(max)
+	index := 0
+	for k := 0; k < nonZeros && d.err == nil; k++ {
+		delta := d.readUvarint(size - 1 - index)
+		if k > 0 && delta == 0 {
+			d.fail("Invalid binary data: repeated index %d", index)
+		}
+		index += delta
+		set(index, d.readFloat64())
+	}
+	if d.err == nil && len(d.data) != 0 {
+		d.fail("Unexpected %d bytes of binary data", len(d.data))
+	}
+}
+
+// Returns the binary encoding of this vector, recording whether its
+// backend is dense or sparse, its size and, for sparse backends, only its
+// non-zero cells.
+func (v *Vector) MarshalBinary() ([]byte, error) {
+	switch m := v.Vec.(type) {
+	case *SparseVec:
+		cells := &binaryCells{}
+		cells.addAll(m.elements, m.IsView(), m.Size(), m.GetQuick)
+		return marshalBinary(binaryVector, binarySparse, []int{m.Size()}, nil, cells), nil
+	}
+	return marshalBinary(binaryVector, binaryDense, []int{v.Size()}, v.GetQuick, nil), nil
+}
+
+// Replaces this vector by the one decoded from the given binary encoding,
+// with a backend of the encoded type.
+func (v *Vector) UnmarshalBinary(data []byte) error {
+	d := &binaryDecoder{data: data}
+	backend, shape, size := d.header(binaryVector, 1)
+	if d.err != nil {
+		return d.err
+	}
+	var w *Vector
+	switch backend {
+	case binaryDense:
+		values := d.dense(size)
+		w = &Vector{&DenseVec{common.NewCoreVec(false, size, 0, 1), values}}
+	case binarySparse:
+		w = NewSparseVector(shape[0])
+		d.sparse(size, w.SetQuick)
+	default:
+		d.fail("Unsupported binary backend for vector: %d", backend)
+	}
+	if d.err != nil {
+		return d.err
+	}
+	v.Vec = w.Vec
+	return nil
+}
+
+// Returns the binary encoding of this vector; see MarshalBinary.
+func (v *Vector) GobEncode() ([]byte, error) {
+	return v.MarshalBinary()
+}
+
+// Decodes the binary encoding of a vector; see UnmarshalBinary.
+func (v *Vector) GobDecode(data []byte) error {
+	return v.UnmarshalBinary(data)
+}
+
+// Returns the binary encoding of this matrix, recording whether its
+// backend is dense, hash based sparse or compressed row or column
+// sparse, its shape and, for sparse backends, only its non-zero cells.
+func (m *Matrix) MarshalBinary() ([]byte, error) {
+	rows, columns := m.Rows(), m.Columns()
+	shape := []int{rows, columns}
+	get := func(index int) float64 {
+		return m.GetQuick(index/columns, index%columns)
+	}
+	cells := &binaryCells{}
+	switch A := m.Mat.(type) {
+	case *SparseMat:
+		cells.addAll(A.elements, A.IsView(), rows*columns, get)
+		return marshalBinary(binaryMatrix, binarySparse, shape, nil, cells), nil
+	case *SparseRCMat, *SparseCCMat:
+		forEachNonZero(A, func(r, c int, value float64) {
+			cells.add(r*columns+c, value)
+		})
+		sort.Sort(cells)
+		backend := byte(binarySparseRC)
+		if _, ok := A.(*SparseCCMat); ok {
+			backend = binarySparseCC
+		}
+		return marshalBinary(binaryMatrix, backend, shape, nil, cells), nil
+	}
+	return marshalBinary(binaryMatrix, binaryDense, shape, get, nil), nil
+}
+
+// Replaces this matrix by the one decoded from the given binary encoding,
+// with a backend of the encoded type.
+func (m *Matrix) UnmarshalBinary(data []byte) error {
+	d := &binaryDecoder{data: data}
+	backend, shape, size := d.header(binaryMatrix, 2)
+	if d.err != nil {
+		return d.err
+	}
+	rows, columns := shape[0], shape[1]
+	var A *Matrix
+	switch backend {
+	case binaryDense:
+		values := d.dense(size)
+		A = &Matrix{&DenseMat{common.NewCoreMat(false, rows, columns, columns, 1, 0, 0), values}}
+	case binarySparse:
+		A = NewSparseMatrix(rows, columns)
+		d.sparse(size, func(index int, value float64) {
+			A.SetQuick(index/columns, index%columns, value)
+		})
+	case binarySparseRC, binarySparseCC:
+		b := NewTripletBuilder(rows, columns)
+		d.sparse(size, func(index int, value float64) {
+			b.Append(index/columns, index%columns, value)
+		})
+		if backend == binarySparseRC {
+			A = b.RowCompressed()
+		} else {
+			A = b.ColumnCompressed()
+		}
+	}
+	if d.err != nil {
+		return d.err
+	}
+	m.Mat = A.Mat
+	return nil
+}
+
+// Returns the binary encoding of this matrix; see MarshalBinary.
+func (m *Matrix) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// Decodes the binary encoding of a matrix; see UnmarshalBinary.
+func (m *Matrix) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
+
+// Returns the binary encoding of this cube, recording whether its backend
+// is dense or sparse, its shape and, for sparse backends, only its
+// non-zero cells.
+func (m *Cube) MarshalBinary() ([]byte, error) {
+	rows, columns := m.Rows(), m.Columns()
+	shape := []int{m.Slices(), rows, columns}
+	get := func(index int) float64 {
+		return m.GetQuick(index/(rows*columns), index/columns%rows, index%columns)
+	}
+	switch A := m.Cub.(type) {
+	case *SparseCub:
+		cells := &binaryCells{}
+		cells.addAll(A.elements, A.IsView(), A.Size(), get)
+		return marshalBinary(binaryCube, binarySparse, shape, nil, cells), nil
+	}
+	return marshalBinary(binaryCube, binaryDense, shape, get, nil), nil
+}
+
+// Replaces this cube by the one decoded from the given binary encoding,
+// with a backend of the encoded type.
+func (m *Cube) UnmarshalBinary(data []byte) error {
+	d := &binaryDecoder{data: data}
+	backend, shape, size := d.header(binaryCube, 3)
+	if d.err != nil {
+		return d.err
+	}
+	slices, rows, columns := shape[0], shape[1], shape[2]
+	var A *Cube
+	switch backend {
+	case binaryDense:
+		values := d.dense(size)
+		A = &Cube{&DenseCub{common.NewCoreCub(false, slices, rows, columns, rows*columns, columns, 1, 0, 0, 0), values}}
+	case binarySparse:
+		A = NewSparseCube(slices, rows, columns)
+		d.sparse(size, func(index int, value float64) {
+			A.SetQuick(index/(rows*columns), index/columns%rows, index%columns, value)
+		})
+	default:
+		d.fail("Unsupported binary backend for cube: %d", backend)
+	}
+	if d.err != nil {
+		return d.err
+	}
+	m.Cub = A.Cub
+	return nil
+}
+
+// Returns the binary encoding of this cube; see MarshalBinary.
+func (m *Cube) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// Decodes the binary encoding of a cube; see UnmarshalBinary.
+func (m *Cube) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
@@ -0,0 +1,179 @@
+package tfloat64
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math"
+	"testing"
+)
+
+func TestVectorMarshalBinary(t *testing.T) {
+	for _, v := range []*Vector{NewVector(1000), NewSparseVector(1000)} {
+		v.SetQuick(3, 1.5)
+		v.SetQuick(999, math.Inf(-1))
+		v.SetQuick(500, -2)
+		for _, x := range []*Vector{v, v.ViewFlip()} {
+			data, err := x.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var y Vector
+			if err := y.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if y.Size() != x.Size() || !y.EqualsVector(x) {
+				t.Errorf("expected:%s actual:%s", x, &y)
+			}
+			_, sparse := v.Vec.(*SparseVec)
+			if _, ok := y.Vec.(*SparseVec); ok != sparse {
+				t.Errorf("expected:%T actual:%T", v.Vec, y.Vec)
+			}
+			if sparse && len(data) > 50 {
+				t.Errorf("expected at most %d bytes, found %d", 50, len(data))
+			}
+		}
+	}
+}
+
+func TestMatrixMarshalBinary(t *testing.T) {
+	for _, A := range []*Matrix{
+		NewMatrix(4, 5),
+		NewSparseMatrix(4, 5),
+		NewSparseRCMatrix(4, 5),
+		NewSparseCCMatrix(4, 5),
+	} {
+		A.SetQuick(0, 0, 1)
+		A.SetQuick(3, 1, -2.5)
+		A.SetQuick(2, 4, 1e-300)
+		A.SetQuick(1, 2, math.NaN())
+		data, err := A.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var B Matrix
+		if err := B.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if B.Rows() != 4 || B.Columns() != 5 {
+			t.Fatalf("expected:%d x %d actual:%d x %d", 4, 5, B.Rows(), B.Columns())
+		}
+		for r := 0; r < 4; r++ {
+			for c := 0; c < 5; c++ {
+				expected, actual := A.GetQuick(r, c), B.GetQuick(r, c)
+				if expected != actual && !(math.IsNaN(expected) && math.IsNaN(actual)) {
+					t.Errorf("expected:%g actual:%g", expected, actual)
+				}
+			}
+		}
+		switch A.Mat.(type) {
+		case *DenseMat:
+			_, ok := B.Mat.(*DenseMat)
+			if !ok || len(data) != 4+3+2+8*20 {
+				t.Errorf("expected:*DenseMat of %d bytes actual:%T of %d bytes", 4+3+2+8*20, B.Mat, len(data))
+			}
+		case *SparseMat:
+			_, ok := B.Mat.(*SparseMat)
+			if !ok || len(data) != 4+3+2+1+4*9 {
+				t.Errorf("expected:*SparseMat of %d bytes actual:%T of %d bytes", 4+3+2+1+4*9, B.Mat, len(data))
+			}
+		case *SparseRCMat:
+			if _, ok := B.Mat.(*SparseRCMat); !ok {
+				t.Errorf("expected:*SparseRCMat actual:%T", B.Mat)
+			}
+		case *SparseCCMat:
+			if _, ok := B.Mat.(*SparseCCMat); !ok {
+				t.Errorf("expected:*SparseCCMat actual:%T", B.Mat)
+			}
+		}
+	}
+}
+
+func TestCubeMarshalBinary(t *testing.T) {
+	for _, A := range []*Cube{NewCube(2, 3, 4), NewSparseCube(2, 3, 4)} {
+		A.SetQuick(1, 2, 3, 7)
+		A.SetQuick(0, 1, 0, -1)
+		D, err := A.ViewDice(2, 0, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, X := range []*Cube{A, D} {
+			data, err := X.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var B Cube
+			if err := B.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if B.Slices() != X.Slices() || B.Rows() != X.Rows() || B.Columns() != X.Columns() {
+				t.Fatalf("expected:%s actual:%s", X.StringShort(), B.StringShort())
+			}
+			for s := 0; s < X.Slices(); s++ {
+				for r := 0; r < X.Rows(); r++ {
+					for c := 0; c < X.Columns(); c++ {
+						if X.GetQuick(s, r, c) != B.GetQuick(s, r, c) {
+							t.Errorf("expected:%g actual:%g", X.GetQuick(s, r, c), B.GetQuick(s, r, c))
+						}
+					}
+				}
+			}
+			_, sparse := A.Cub.(*SparseCub)
+			if _, ok := B.Cub.(*SparseCub); ok != sparse {
+				t.Errorf("expected:%T actual:%T", A.Cub, B.Cub)
+			}
+		}
+	}
+}
+
+func TestMarshalBinaryErrors(t *testing.T) {
+	v := NewSparseVector(10)
+	v.SetQuick(4, 1)
+	data, _ := v.MarshalBinary()
+	for _, b := range [][]byte{
+		nil,
+		[]byte("GSHX\x01V\x01\x0a\x00"),
+		[]byte("GSHK\x02V\x01\x0a\x00"),
+		[]byte("GSHK\x01M\x01\x0a\x00"),
+		[]byte("GSHK\x01V\x07\x0a\x00"),
+		[]byte("GSHK\x01V\x00\x02\x00"),
+		[]byte("GSHK\x01V\x01\x0a\x01\x0a\x00\x00\x00\x00\x00\x00\xf0\x3f"),
+		data[:len(data)-1],
+		append(data, 0),
+	} {
+		var w Vector
+		if err := w.UnmarshalBinary(b); err == nil {
+			t.Errorf("expected error for %q", b)
+		}
+	}
+	var C Cube
+	if err := C.UnmarshalBinary([]byte("GSHK\x01C\x02\x01\x01\x01\x00")); err == nil {
+		t.Errorf("expected error for compressed cube")
+	}
+}
+
+func TestGob(t *testing.T) {
+	type state struct {
+		Step int
+		X    *Vector
+		A    *Matrix
+		C    *Cube
+	}
+	in := state{Step: 3, X: NewSparseVector(5), A: NewSparseCCMatrix(3, 3), C: NewCube(1, 2, 2)}
+	in.X.SetQuick(2, 4)
+	in.A.SetQuick(2, 0, -1)
+	in.C.SetQuick(0, 1, 1, 0.5)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	var out state
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Step != 3 || out.X.GetQuick(2) != 4 || out.A.GetQuick(2, 0) != -1 || out.C.GetQuick(0, 1, 1) != 0.5 {
+		t.Errorf("expected:%v actual:%v", in, out)
+	}
+	if _, ok := out.A.Mat.(*SparseCCMat); !ok {
+		t.Errorf("expected:*SparseCCMat actual:%T", out.A.Mat)
+	}
+}
@@ -0,0 +1,145 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+)
+
+// Cholesky decomposition of a symmetric positive definite matrix.
+//
+// For a symmetric, positive definite n x n matrix A, the Cholesky
+// decomposition is a lower triangular matrix L so that A = L*L'.
+//
+// If the matrix is not symmetric or positive definite, the constructor
+// returns a partial decomposition and sets an internal flag that may be
+// queried by the IsSymmetricPositiveDefinite() method.
+//
+// The input matrix is only read via GetQuick, so views (e.g. ViewPart or
+// ViewDice) may be decomposed without copying.
+type DenseCholeskyDecomposition struct {
+	l     *Matrix // The lower triangular factor.
+	isSPD bool    // Whether the matrix is symmetric and positive definite.
+}
+
+// Constructs and returns a new Cholesky decomposition of the given matrix,
+// with the default tolerance of the package Property. Returns an error if
+// A is not square.
+func NewDenseCholeskyDecomposition(A *Matrix) (*DenseCholeskyDecomposition, error) {
+	return NewDenseCholeskyDecompositionTolerance(A, prop.tolerance)
+}
+
+// Constructs and returns a new Cholesky decomposition of the given matrix
+// which uses the given tolerance to determine whether A is symmetric.
+// Returns an error if A is not square.
+func NewDenseCholeskyDecompositionTolerance(A *Matrix, tolerance float64) (*DenseCholeskyDecomposition, error) {
+	n := A.Rows()
+	if A.Columns() != n {
+		return nil, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	L := &Matrix{A.Like(n, n)}
+	isSPD := true
+
+	// Main loop.
+	for j := 0; j < n; j++ {
+		d := 0.0
+		for k := 0; k < j; k++ {
+			s := 0.0
+			for i := 0; i < k; i++ {
+				s += L.GetQuick(k, i) * L.GetQuick(j, i)
+			}
+			s = (A.GetQuick(j, k) - s) / L.GetQuick(k, k)
+			L.SetQuick(j, k, s)
+			d += s * s
+			isSPD = isSPD && math.Abs(A.GetQuick(k, j)-A.GetQuick(j, k)) <= tolerance
+		}
+		d = A.GetQuick(j, j) - d
+		isSPD = isSPD && d > 0
+		L.SetQuick(j, j, math.Sqrt(math.Max(d, 0)))
+	}
+	return &DenseCholeskyDecomposition{L, isSPD}, nil
+}
+
+// Returns the triangular factor, L.
+func (d *DenseCholeskyDecomposition) L() *Matrix {
+	return d.l
+}
+
+// Returns whether the matrix A is symmetric and positive definite.
+func (d *DenseCholeskyDecomposition) IsSymmetricPositiveDefinite() bool {
+	return d.isSPD
+}
+
+func (d *DenseCholeskyDecomposition) checkSolve(rows int) error {
+	if rows != d.l.Rows() {
+		return fmt.Errorf("Matrix row dimensions must agree: %s, %d", d.l.StringShort(), rows)
+	}
+	if !d.isSPD {
+		return fmt.Errorf("Matrix is not symmetric positive definite")
+	}
+	return nil
+}
+
+// Solves A*x = b and returns x, so that L*L'*x = b. The vector b is left
+// unchanged.
+func (d *DenseCholeskyDecomposition) Solve(b *Vector) (*Vector, error) {
+	err := d.checkSolve(b.Size())
+	if err != nil {
+		return nil, err
+	}
+	n := d.l.Rows()
+	x := b.Copy()
+
+	// Solve L*Y = b
+	for k := 0; k < n; k++ {
+		s := x.GetQuick(k)
+		for i := 0; i < k; i++ {
+			s -= x.GetQuick(i) * d.l.GetQuick(k, i)
+		}
+		x.SetQuick(k, s/d.l.GetQuick(k, k))
+	}
+	// Solve L'*x = Y
+	for k := n - 1; k >= 0; k-- {
+		s := x.GetQuick(k)
+		for i := k + 1; i < n; i++ {
+			s -= x.GetQuick(i) * d.l.GetQuick(i, k)
+		}
+		x.SetQuick(k, s/d.l.GetQuick(k, k))
+	}
+	return x, nil
+}
+
+// Solves A*X = B and returns X, so that L*L'*X = B. The matrix B is left
+// unchanged.
+func (d *DenseCholeskyDecomposition) SolveMatrix(B *Matrix) (*Matrix, error) {
+	err := d.checkSolve(B.Rows())
+	if err != nil {
+		return nil, err
+	}
+	n := d.l.Rows()
+	nx := B.Columns()
+	X := B.Copy()
+
+	// Solve L*Y = B
+	for k := 0; k < n; k++ {
+		lkk := d.l.GetQuick(k, k)
+		for j := 0; j < nx; j++ {
+			s := X.GetQuick(k, j)
+			for i := 0; i < k; i++ {
+				s -= X.GetQuick(i, j) * d.l.GetQuick(k, i)
+			}
+			X.SetQuick(k, j, s/lkk)
+		}
+	}
+	// Solve L'*X = Y
+	for k := n - 1; k >= 0; k-- {
+		lkk := d.l.GetQuick(k, k)
+		for j := 0; j < nx; j++ {
+			s := X.GetQuick(k, j)
+			for i := k + 1; i < n; i++ {
+				s -= X.GetQuick(i, j) * d.l.GetQuick(i, k)
+			}
+			X.SetQuick(k, j, s/lkk)
+		}
+	}
+	return X, nil
+}
@@ -0,0 +1,115 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns A*A' + n*I which is symmetric positive definite.
+func makeSPDMatrix(A *Matrix) *Matrix {
+	makeSquareMatrix(A)
+	S, _ := A.ZMultMatrixConst(A, nil, 1, 0, false, true)
+	for i := 0; i < S.Rows(); i++ {
+		S.SetQuick(i, i, S.GetQuick(i, i)+float64(S.Rows()))
+	}
+	return S
+}
+
+func testCholeskyDecomposition(t *testing.T, A *Matrix) {
+	chol, err := NewDenseCholeskyDecomposition(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !chol.IsSymmetricPositiveDefinite() {
+		t.Fatal("expected symmetric positive definite")
+	}
+
+	// A == L*L'
+	L := chol.L()
+	LLt, _ := L.ZMultMatrixConst(L, nil, 1, 0, false, true)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if math.Abs(A.GetQuick(r, c)-LLt.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", A.GetQuick(r, c), LLt.GetQuick(r, c))
+			}
+		}
+	}
+
+	b := NewVector(A.Rows())
+	for i := 0; i < b.Size(); i++ {
+		b.SetQuick(i, rand.Float64())
+	}
+	x, err := chol.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Ax, _ := A.ZMult(x, nil)
+	for i := 0; i < b.Size(); i++ {
+		if math.Abs(b.GetQuick(i)-Ax.GetQuick(i)) > tol {
+			t.Errorf("expected:%g actual:%g", b.GetQuick(i), Ax.GetQuick(i))
+		}
+	}
+
+	B := &Matrix{A.Like(A.Rows(), 3)}
+	B.AssignFunc(Random())
+	X, err := chol.SolveMatrix(B)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AX, _ := A.ZMultMatrix(X, nil)
+	for r := 0; r < B.Rows(); r++ {
+		for c := 0; c < B.Columns(); c++ {
+			if math.Abs(B.GetQuick(r, c)-AX.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", B.GetQuick(r, c), AX.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func testCholeskyDecompositionNotSPD(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{1, 2},
+		{2, 1},
+	})
+	chol, _ := NewDenseCholeskyDecomposition(A)
+	if chol.IsSymmetricPositiveDefinite() {
+		t.Errorf("expected indefinite matrix to be rejected")
+	}
+
+	A.AssignArray([][]float64{
+		{4, 1},
+		{2, 4},
+	})
+	chol, _ = NewDenseCholeskyDecomposition(A)
+	if chol.IsSymmetricPositiveDefinite() {
+		t.Errorf("expected non-symmetric matrix to be rejected")
+	}
+	if _, err := chol.Solve(NewVector(2)); err == nil {
+		t.Errorf("expected error solving non-SPD system")
+	}
+}
+
+func TestDenseCholeskyDecomposition(t *testing.T) {
+	A := makeSPDMatrix(NewMatrix(nsquare, nsquare))
+	testCholeskyDecomposition(t, A)
+}
+
+func TestDenseCholeskyDecompositionView(t *testing.T) {
+	A := makeSPDMatrix(NewMatrix(nsquare, nsquare))
+	V, _ := A.ViewDice().ViewPart(1, 1, nsquare-2, nsquare-2)
+	testCholeskyDecomposition(t, V)
+}
+
+func TestDenseCholeskyDecompositionNotSPD(t *testing.T) {
+	testCholeskyDecompositionNotSPD(t, NewMatrix(2, 2))
+}
+
+func TestSparseCholeskyDecomposition(t *testing.T) {
+	A := makeSPDMatrix(NewSparseMatrix(nsquare, nsquare))
+	testCholeskyDecomposition(t, A)
+}
+
+func TestSparseCholeskyDecompositionNotSPD(t *testing.T) {
+	testCholeskyDecompositionNotSPD(t, NewSparseMatrix(2, 2))
+}
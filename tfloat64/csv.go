@@ -0,0 +1,188 @@
+package tfloat64
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/rwl/goshawk/common"
+)
+
+// Reads rows of delimited text, such as comma separated values, into
+// vectors and matrices. The fields may be changed before the first row is
+// read. Rows are parsed one at a time as they are read.
+type CSVReader struct {
+	Comma      rune     // Field separator; ',' by default.
+	Comment    rune     // Lines beginning with this character are skipped; none if 0.
+	HeaderRows int      // Number of leading rows skipped, such as a row of column names.
+	NaN        []string // Fields read as NaN, such as "NA" or "?"; blank fields are always NaN.
+
+	r       *csv.Reader
+	skipped bool
+	columns int
+}
+
+// Constructs and returns a new reader of comma separated values from r.
+func NewCSVReader(r io.Reader) *CSVReader {
+	return &CSVReader{Comma: ',', r: csv.NewReader(r), columns: -1}
+}
+
+// Returns the values of the next row, or io.EOF if there are no more
+// rows. Every row must have as many fields as the first one read.
+func (d *CSVReader) Read() ([]float64, error) {
+	d.r.Comma = d.Comma
+	d.r.Comment = d.Comment
+	d.r.FieldsPerRecord = -1
+	d.r.ReuseRecord = true
+	if !d.skipped {
+		for i := 0; i < d.HeaderRows; i++ {
+			if _, err := d.r.Read(); err != nil {
+				return nil, err
+			}
+		}
+		d.skipped = true
+	}
+	record, err := d.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	line, _ := d.r.FieldPos(0)
+	if d.columns < 0 {
+		d.columns = len(record)
+	} else if len(record) != d.columns {
+		return nil, fmt.Errorf("Line %d: expected %d fields, found %d", line, d.columns, len(record))
+	}
+	values := make([]float64, len(record))
+	for i, field := range record {
+		if values[i], err = d.parse(field); err != nil {
+			return nil, fmt.Errorf("Line %d: %s", line, err)
+		}
+	}
+	return values, nil
+}
+
+// Parses a single field.
+func (d *CSVReader) parse(field string) (float64, error) {
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return math.NaN(), nil
+	}
+	for _, token := range d.NaN {
+		if field == token {
+			return math.NaN(), nil
+		}
+	}
+	value, err := strconv.ParseFloat(field, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %q", field)
+	}
+	return value, nil
+}
+
+// Reads all remaining rows and returns them as a dense matrix with one
+// row per row read.
+func (d *CSVReader) ReadMatrix() (*Matrix, error) {
+	var elements []float64
+	rows := 0
+	for {
+		values, err := d.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		elements = append(elements, values...)
+		rows++
+	}
+	columns := 0
+	if rows > 0 {
+		columns = d.columns
+	}
+	return &Matrix{
+		&DenseMat{
+			common.NewCoreMat(false, rows, columns, columns, 1, 0, 0),
+			elements,
+		},
+	}, nil
+}
+
+// Reads all remaining rows, which must have a single field each, and
+// returns them as a dense vector.
+func (d *CSVReader) ReadVector() (*Vector, error) {
+	A, err := d.ReadMatrix()
+	if err != nil {
+		return nil, err
+	}
+	if A.Columns() > 1 {
+		return nil, fmt.Errorf("Expected 1 field per row, found %d", A.Columns())
+	}
+	return NewVectorArray(A.Mat.(*DenseMat).elements), nil
+}
+
+// Writes vectors and matrices as rows of delimited text, such as comma
+// separated values. The fields may be changed before the first row is
+// written. Each row is passed on to the underlying writer as soon as it
+// is formatted; Flush must be called when writing is done.
+type CSVWriter struct {
+	Comma  rune   // Field separator; ',' by default.
+	Format string // Format of each cell, as used by Formatter; "%G" by default.
+	NaN    string // Written for NaN cells instead of their formatted value, if not empty.
+
+	w      *csv.Writer
+	record []string
+}
+
+// Constructs and returns a new writer of comma separated values to w.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{Comma: ',', Format: NewFormatter().Format, w: csv.NewWriter(w)}
+}
+
+// Writes a row of the given names, such as a header row. Names are
+// quoted if necessary.
+func (e *CSVWriter) WriteHeader(names []string) error {
+	e.w.Comma = e.Comma
+	return e.w.Write(names)
+}
+
+// Writes one row per row of A.
+func (e *CSVWriter) WriteMatrix(A Mat) error {
+	e.w.Comma = e.Comma
+	for r := 0; r < A.Rows(); r++ {
+		e.record = e.record[:0]
+		for c := 0; c < A.Columns(); c++ {
+			e.record = append(e.record, e.format(A.GetQuick(r, c)))
+		}
+		if err := e.w.Write(e.record); err != nil {
+			return err
+		}
+	}
+	return e.w.Error()
+}
+
+// Writes v as a single column, with one row per cell.
+func (e *CSVWriter) WriteVector(v Vec) error {
+	e.w.Comma = e.Comma
+	for i := 0; i < v.Size(); i++ {
+		if err := e.w.Write([]string{e.format(v.GetQuick(i))}); err != nil {
+			return err
+		}
+	}
+	return e.w.Error()
+}
+
+// Formats a single cell.
+func (e *CSVWriter) format(value float64) string {
+	if e.NaN != "" && math.IsNaN(value) {
+		return e.NaN
+	}
+	return fmt.Sprintf(e.Format, value)
+}
+
+// Writes any buffered rows to the underlying writer.
+func (e *CSVWriter) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,139 @@
+package tfloat64
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCSVReaderReadMatrix(t *testing.T) {
+	s := `time;voltage;current
+# calibration run
+0; 1.5;-2e-3
+1;NA;  4
+"2";3;
+`
+	d := NewCSVReader(strings.NewReader(s))
+	d.Comma = ';'
+	d.Comment = '#'
+	d.HeaderRows = 1
+	d.NaN = []string{"NA"}
+	A, err := d.ReadMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]float64{
+		{0, 1.5, -2e-3},
+		{1, math.NaN(), 4},
+		{2, 3, math.NaN()},
+	}
+	if A.Rows() != 3 || A.Columns() != 3 {
+		t.Fatalf("expected:%d x %d actual:%d x %d", 3, 3, A.Rows(), A.Columns())
+	}
+	for r := range expected {
+		for c := range expected[r] {
+			value := A.GetQuick(r, c)
+			if math.IsNaN(expected[r][c]) != math.IsNaN(value) || !math.IsNaN(value) && expected[r][c] != value {
+				t.Errorf("expected:%g actual:%g", expected[r][c], value)
+			}
+		}
+	}
+}
+
+func TestCSVReaderRead(t *testing.T) {
+	d := NewCSVReader(strings.NewReader("1,2\n3,4\n"))
+	for _, expected := range [][]float64{{1, 2}, {3, 4}} {
+		values, err := d.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if values[0] != expected[0] || values[1] != expected[1] {
+			t.Errorf("expected:%v actual:%v", expected, values)
+		}
+	}
+	if _, err := d.Read(); err != io.EOF {
+		t.Errorf("expected:%v actual:%v", io.EOF, err)
+	}
+
+	v, err := NewCSVReader(strings.NewReader("1\n-2.5\n3\n")).ReadVector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Size() != 3 || v.GetQuick(1) != -2.5 {
+		t.Errorf("expected:%v actual:%s", []float64{1, -2.5, 3}, v)
+	}
+
+	A, err := NewCSVReader(strings.NewReader("")).ReadMatrix()
+	if err != nil || A.Rows() != 0 || A.Columns() != 0 {
+		t.Errorf("expected empty matrix, got %v %v", A, err)
+	}
+
+	for _, s := range []string{"1,2\n3\n", "1,x\n", "1,\"2\n", "1,2\n"} {
+		d := NewCSVReader(strings.NewReader(s))
+		var err error
+		if s == "1,2\n" {
+			_, err = d.ReadVector()
+		} else {
+			_, err = d.ReadMatrix()
+		}
+		if err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	A := NewMatrix(2, 3)
+	A.AssignArray([][]float64{
+		{1, 0.5, -3},
+		{math.NaN(), 1e-10, 7},
+	})
+	var buf bytes.Buffer
+	e := NewCSVWriter(&buf)
+	if err := e.WriteHeader([]string{"a", "b,c", "d"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WriteMatrix(A); err != nil {
+		t.Fatal(err)
+	}
+	e.Comma = '\t'
+	e.Format = "%.2f"
+	e.NaN = "NA"
+	if err := e.WriteMatrix(A); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.WriteVector(NewVectorArray([]float64{math.NaN(), 2})); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "a,\"b,c\",d\n1,0.5,-3\nNaN,1E-10,7\n" +
+		"1.00\t0.50\t-3.00\nNA\t0.00\t7.00\nNA\n2.00\n"
+	if buf.String() != expected {
+		t.Errorf("expected:%q actual:%q", expected, buf.String())
+	}
+
+	buf.Reset()
+	e = NewCSVWriter(&buf)
+	e.Format = "%v"
+	e.WriteHeader([]string{"x", "y", "z"})
+	e.WriteMatrix(A)
+	e.Flush()
+	d := NewCSVReader(&buf)
+	d.HeaderRows = 1
+	B, err := d.ReadMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !math.IsNaN(B.GetQuick(1, 0)) {
+		t.Errorf("expected:%g actual:%g", math.NaN(), B.GetQuick(1, 0))
+	}
+	B.SetQuick(1, 0, 0)
+	A.SetQuick(1, 0, 0)
+	if !B.EqualsMatrix(A) {
+		t.Errorf("expected:%s actual:%s", A, B)
+	}
+}
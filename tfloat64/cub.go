@@ -7,4 +7,27 @@ type Cub interface {
 
 	GetQuick(int, int, int) float64
 	SetQuick(int, int, int, float64)
+
+	Like(int, int, int) Cub
+
+	// Returns a matrix backend with the given shape that shares the
+	// elements of the receiver.
+	Like2D(rows, columns, rowZero, columnZero, rowStride, columnStride int) Mat
+
+	// Returns a new backend sharing the elements of the receiver whose
+	// shape may be modified independently.
+	View() Cub
+
+	// Returns a selection view sharing the elements of the receiver,
+	// where cell [s,r,c] of the view is the element at
+	// sliceOffsets[s]+rowOffsets[r]+columnOffsets[c].
+	ViewSelectionLike(sliceOffsets, rowOffsets, columnOffsets []int) Cub
+}
+
+// Implemented by backends that provide their own slice, row and column
+// views.
+type planeViewCub interface {
+	ViewSlice(int) Mat
+	ViewRow(int) Mat
+	ViewColumn(int) Mat
 }
@@ -18,3 +18,28 @@ func (m *DenseCub) SetQuick(slice, row, column int, value float64) {
 func (m *DenseCub) Elements() interface{} {
 	return m.elements
 }
+
+func (m *DenseCub) Like(slices, rows, columns int) Cub {
+	return NewCube(slices, rows, columns).Cub
+}
+
+func (m *DenseCub) Like2D(rows, columns, rowZero, columnZero, rowStride, columnStride int) Mat {
+	return &DenseMat{
+		common.NewCoreMat(true, rows, columns, rowStride, columnStride, rowZero, columnZero),
+		m.elements,
+	}
+}
+
+func (m *DenseCub) View() Cub {
+	return &DenseCub{m.CoreCub.View(), m.elements}
+}
+
+func (m *DenseCub) ViewSelectionLike(sliceOffsets, rowOffsets, columnOffsets []int) Cub {
+	return &SelectedDenseCub{
+		&DenseCub{
+			common.NewCoreCub(true, len(sliceOffsets), len(rowOffsets), len(columnOffsets), 1, 1, 1, 0, 0, 0),
+			m.elements,
+		},
+		sliceOffsets, rowOffsets, columnOffsets, 0,
+	}
+}
@@ -0,0 +1,91 @@
+package tfloat64
+
+import "github.com/rwl/goshawk/common"
+
+// Selection view on dense 3-d cubes holding float64 elements.
+//
+// Instances of this type are typically constructed via the ViewSelection
+// methods on some source cube. From a user point of view there is nothing
+// special about this type; it presents the same functionality with the
+// same signatures and semantics as its original cube while introducing
+// no additional functionality.
+//
+// The slice, row and column zeros and strides index into the offset
+// arrays rather than into the elements, so that part, flip, stride and
+// dice views of a selection remain selections. Cell addressing overhead
+// is 3 additional array index accesses per get/set.
+type SelectedDenseCub struct {
+	*DenseCub
+	sliceOffsets  []int // The offsets of the visible slices of this cube.
+	rowOffsets    []int // The offsets of the visible rows of this cube.
+	columnOffsets []int // The offsets of the visible columns of this cube.
+	offset        int   // The offset.
+}
+
+func (m *SelectedDenseCub) GetQuick(slice, row, column int) float64 {
+	return m.elements[m.Index(slice, row, column)]
+}
+
+func (m *SelectedDenseCub) SetQuick(slice, row, column int, value float64) {
+	m.elements[m.Index(slice, row, column)] = value
+}
+
+func (m *SelectedDenseCub) Index(slice, row, column int) int {
+	return m.offset + m.sliceOffsets[m.SliceZero()+slice*m.SliceStride()] +
+		m.rowOffsets[m.RowZero()+row*m.RowStride()] +
+		m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()]
+}
+
+func (m *SelectedDenseCub) View() Cub {
+	return &SelectedDenseCub{
+		&DenseCub{m.CoreCub.View(), m.elements},
+		m.sliceOffsets, m.rowOffsets, m.columnOffsets, m.offset,
+	}
+}
+
+// Permutes the axes and their offsets.
+func (m *SelectedDenseCub) VDice(axis0, axis1, axis2 int) error {
+	err := m.CoreCub.VDice(axis0, axis1, axis2)
+	if err != nil {
+		return err
+	}
+	offsets := [3][]int{m.sliceOffsets, m.rowOffsets, m.columnOffsets}
+	m.sliceOffsets, m.rowOffsets, m.columnOffsets = offsets[axis0], offsets[axis1], offsets[axis2]
+	return nil
+}
+
+// Returns a rows x columns selection view of the given slice.
+func (m *SelectedDenseCub) ViewSlice(slice int) Mat {
+	return &SelectedDenseMat{
+		&DenseMat{
+			common.NewCoreMat(true, m.Rows(), m.Columns(), m.RowStride(), m.ColumnStride(), m.RowZero(), m.ColumnZero()),
+			m.elements,
+		},
+		m.rowOffsets, m.columnOffsets,
+		m.offset + m.sliceOffsets[m.SliceZero()+slice*m.SliceStride()],
+	}
+}
+
+// Returns a slices x columns selection view of the given row.
+func (m *SelectedDenseCub) ViewRow(row int) Mat {
+	return &SelectedDenseMat{
+		&DenseMat{
+			common.NewCoreMat(true, m.Slices(), m.Columns(), m.SliceStride(), m.ColumnStride(), m.SliceZero(), m.ColumnZero()),
+			m.elements,
+		},
+		m.sliceOffsets, m.columnOffsets,
+		m.offset + m.rowOffsets[m.RowZero()+row*m.RowStride()],
+	}
+}
+
+// Returns a slices x rows selection view of the given column.
+func (m *SelectedDenseCub) ViewColumn(column int) Mat {
+	return &SelectedDenseMat{
+		&DenseMat{
+			common.NewCoreMat(true, m.Slices(), m.Rows(), m.SliceStride(), m.RowStride(), m.SliceZero(), m.RowZero()),
+			m.elements,
+		},
+		m.sliceOffsets, m.rowOffsets,
+		m.offset + m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()],
+	}
+}
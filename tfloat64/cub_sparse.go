@@ -23,3 +23,28 @@ func (m *SparseCub) SetQuick(slice, row, column int, value float64) {
 func (m *SparseCub) Elements() interface{} {
 	return m.elements
 }
+
+func (m *SparseCub) Like(slices, rows, columns int) Cub {
+	return NewSparseCube(slices, rows, columns).Cub
+}
+
+func (m *SparseCub) Like2D(rows, columns, rowZero, columnZero, rowStride, columnStride int) Mat {
+	return &SparseMat{
+		common.NewCoreMat(true, rows, columns, rowStride, columnStride, rowZero, columnZero),
+		m.elements,
+	}
+}
+
+func (m *SparseCub) View() Cub {
+	return &SparseCub{m.CoreCub.View(), m.elements}
+}
+
+func (m *SparseCub) ViewSelectionLike(sliceOffsets, rowOffsets, columnOffsets []int) Cub {
+	return &SelectedSparseCub{
+		&SparseCub{
+			common.NewCoreCub(true, len(sliceOffsets), len(rowOffsets), len(columnOffsets), 1, 1, 1, 0, 0, 0),
+			m.elements,
+		},
+		sliceOffsets, rowOffsets, columnOffsets, 0,
+	}
+}
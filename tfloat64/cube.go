@@ -1,5 +1,191 @@
 package tfloat64
 
+import (
+	"fmt"
+	"math"
+	"runtime"
+
+	"github.com/rwl/goshawk/common"
+)
+
 type Cube struct {
 	Cub
 }
+
+func (m *Cube) Get(slice, row, column int) (float64, error) {
+	if slice < 0 || slice >= m.Slices() || row < 0 || row >= m.Rows() || column < 0 || column >= m.Columns() {
+		return math.NaN(), fmt.Errorf("slice:%d, row:%d, column:%d", slice, row, column)
+	}
+	return m.GetQuick(slice, row, column), nil
+}
+
+func (m *Cube) Set(slice, row, column int, value float64) error {
+	if slice < 0 || slice >= m.Slices() || row < 0 || row >= m.Rows() || column < 0 || column >= m.Columns() {
+		return fmt.Errorf("slice:%d, row:%d, column:%d", slice, row, column)
+	}
+	m.SetQuick(slice, row, column, value)
+	return nil
+}
+
+// Returns a deep copy of the receiver.
+func (m *Cube) Copy() *Cube {
+	copy := &Cube{m.Like(m.Slices(), m.Rows(), m.Columns())}
+	copy.AssignCube(m)
+	return copy
+}
+
+// Returns the number of non-zero cells.
+func (m *Cube) Cardinality() int {
+	cardinality := 0
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				if m.GetQuick(s, r, c) != 0 {
+					cardinality += 1
+				}
+			}
+		}
+	}
+	return cardinality
+}
+
+func (m *Cube) Equals(value float64) bool {
+	return prop.CubeEqualsValue(m, value)
+}
+
+func (m *Cube) EqualsCube(other Cub) bool {
+	return prop.CubeEqualsCube(m, other)
+}
+
+// Returns the cell values as a slices x rows x columns array.
+func (m *Cube) ToArray() [][][]float64 {
+	values := make([][][]float64, m.Slices())
+	for s := 0; s < m.Slices(); s++ {
+		values[s] = make([][]float64, m.Rows())
+		for r := 0; r < m.Rows(); r++ {
+			values[s][r] = make([]float64, m.Columns())
+			currentRow := values[s][r]
+			for c := 0; c < m.Columns(); c++ {
+				currentRow[c] = m.GetQuick(s, r, c)
+			}
+		}
+	}
+	return values
+}
+
+// Returns the maximum cell value and its slice, row and column.
+func (m *Cube) MaxLocation() (float64, int, int, int) {
+	return m.location(func(a, b float64) bool { return a < b })
+}
+
+// Returns the minimum cell value and its slice, row and column.
+func (m *Cube) MinLocation() (float64, int, int, int) {
+	return m.location(func(a, b float64) bool { return a > b })
+}
+
+// Returns the first cell value v and its coordinate such that
+// replace(v, elem) is false for all cells, or NaN and -1 indexes if the
+// cube is empty.
+func (m *Cube) location(replace func(float64, float64) bool) (float64, int, int, int) {
+	if m.Size() == 0 {
+		return math.NaN(), -1, -1, -1
+	}
+	sliceLocation := 0
+	rowLocation := 0
+	columnLocation := 0
+	value := m.GetQuick(0, 0, 0)
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				elem := m.GetQuick(s, r, c)
+				if replace(value, elem) {
+					value = elem
+					sliceLocation = s
+					rowLocation = r
+					columnLocation = c
+				}
+			}
+		}
+	}
+	return value, sliceLocation, rowLocation, columnLocation
+}
+
+// Returns the sum of all cells; Sum(x[i,j,k]).
+func (m *Cube) ZSum() float64 {
+	if m.Size() == 0 {
+		return 0.0
+	}
+	return m.Aggregate(Plus, Identity)
+}
+
+// Normalizes the cube so that all cells are non-negative and sum to one.
+func (m *Cube) Normalize() *Cube {
+	min, _, _, _ := m.MinLocation()
+	if min < 0 {
+		m.AssignFunc(Subtract(min))
+	}
+	max, _, _, _ := m.MaxLocation()
+	if max == 0 {
+		m.Assign(1.0 / float64(m.Size()))
+	} else {
+		m.AssignFunc(Multiply(1.0 / m.ZSum()))
+	}
+	return m
+}
+
+func (m *Cube) checkShape(other Cub) error {
+	if m.Slices() != other.Slices() || m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return fmt.Errorf("Incompatible dimensions: %s and %s", m.StringShort(), other.StringShort())
+	}
+	return nil
+}
+
+func (m *Cube) checkSlice(slice int) error {
+	if slice < 0 || slice >= m.Slices() {
+		return fmt.Errorf("Attempted to access %s at slice=%d", m.StringShort(), slice)
+	}
+	return nil
+}
+
+func (m *Cube) checkRow(row int) error {
+	if row < 0 || row >= m.Rows() {
+		return fmt.Errorf("Attempted to access %s at row=%d", m.StringShort(), row)
+	}
+	return nil
+}
+
+func (m *Cube) checkColumn(column int) error {
+	if column < 0 || column >= m.Columns() {
+		return fmt.Errorf("Attempted to access %s at column=%d", m.StringShort(), column)
+	}
+	return nil
+}
+
+// Splits the slices of the cube into ranges [idx0, idx1) and calls f for
+// each range, concurrently if the cube is larger than
+// common.CubeThreshold. Returns the number of ranges.
+func (m *Cube) split(f func(j, idx0, idx1 int)) int {
+	n := runtime.GOMAXPROCS(-1)
+	if n <= 1 || m.Size() <= common.CubeThreshold || m.Slices() < 2 {
+		f(0, 0, m.Slices())
+		return 1
+	}
+	n = common.Min(n, m.Slices())
+	k := m.Slices() / n
+	done := make(chan bool, n)
+	for j := 0; j < n; j++ {
+		idx0 := j * k
+		idx1 := idx0 + k
+		if j == n-1 {
+			idx1 = m.Slices()
+		}
+		go func(j int) {
+			f(j, idx0, idx1)
+			done <- true
+		}(j)
+	}
+	for j := 0; j < n; j++ {
+		<-done
+	}
+	return n
+}
@@ -0,0 +1,76 @@
+package tfloat64
+
+import (
+	"math"
+	"runtime"
+)
+
+// Applies a function to each cell and aggregates the results. Returns
+// NaN if the cube is empty.
+func (m *Cube) Aggregate(aggr Float64Float64Func, f Float64Func) float64 {
+	if m.Size() == 0 {
+		return math.NaN()
+	}
+	return m.aggregate(aggr, func(s, r, c int) float64 {
+		return f(m.GetQuick(s, r, c))
+	})
+}
+
+// Applies a function to each cell that satisfies the condition and
+// aggregates the results, starting from zero. Returns NaN if the cube is
+// empty.
+func (m *Cube) AggregateProcedure(aggr Float64Float64Func, f Float64Func, cond Float64Procedure) float64 {
+	if m.Size() == 0 {
+		return math.NaN()
+	}
+	a := 0.0
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				elem := m.GetQuick(s, r, c)
+				if cond(elem) {
+					a = aggr(a, f(elem))
+				}
+			}
+		}
+	}
+	return a
+}
+
+// Applies a function to each corresponding pair of cells of the receiver
+// and other and aggregates the results. Returns an error if the cubes do
+// not have the same shape.
+func (m *Cube) AggregateCube(other Cub, aggr Float64Float64Func, f Float64Float64Func) (float64, error) {
+	err := m.checkShape(other)
+	if err != nil {
+		return math.NaN(), err
+	}
+	if m.Size() == 0 {
+		return math.NaN(), nil
+	}
+	return m.aggregate(aggr, func(s, r, c int) float64 {
+		return f(m.GetQuick(s, r, c), other.GetQuick(s, r, c))
+	}), nil
+}
+
+func (m *Cube) aggregate(aggr Float64Float64Func, f func(s, r, c int) float64) float64 {
+	partial := make([]float64, runtime.GOMAXPROCS(-1))
+	n := m.split(func(j, idx0, idx1 int) {
+		a := f(idx0, 0, 0)
+		d := 1 // First cell already done.
+		for s := idx0; s < idx1; s++ {
+			for r := 0; r < m.Rows(); r++ {
+				for c := d; c < m.Columns(); c++ {
+					a = aggr(a, f(s, r, c))
+				}
+				d = 0
+			}
+		}
+		partial[j] = a
+	})
+	a := partial[0]
+	for j := 1; j < n; j++ {
+		a = aggr(a, partial[j])
+	}
+	return a
+}
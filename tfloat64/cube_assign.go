@@ -0,0 +1,140 @@
+package tfloat64
+
+import "fmt"
+
+// Assigns the result of a function to each cell; x[i,j,k] = f(x[i,j,k]).
+func (m *Cube) AssignFunc(f Float64Func) *Cube {
+	m.split(func(j, idx0, idx1 int) {
+		for s := idx0; s < idx1; s++ {
+			for r := 0; r < m.Rows(); r++ {
+				for c := 0; c < m.Columns(); c++ {
+					m.SetQuick(s, r, c, f(m.GetQuick(s, r, c)))
+				}
+			}
+		}
+	})
+	return m
+}
+
+// Assigns the result of a function to each cell that satisfies the
+// condition.
+func (m *Cube) AssignProcedureFunc(cond Float64Procedure, f Float64Func) *Cube {
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				elem := m.GetQuick(s, r, c)
+				if cond(elem) {
+					m.SetQuick(s, r, c, f(elem))
+				}
+			}
+		}
+	}
+	return m
+}
+
+// Assigns a value to each cell that satisfies the condition.
+func (m *Cube) AssignProcedure(cond Float64Procedure, value float64) *Cube {
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				if cond(m.GetQuick(s, r, c)) {
+					m.SetQuick(s, r, c, value)
+				}
+			}
+		}
+	}
+	return m
+}
+
+// Sets all cells to the given value.
+func (m *Cube) Assign(value float64) *Cube {
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				m.SetQuick(s, r, c, value)
+			}
+		}
+	}
+	return m
+}
+
+// Sets all cells to the values of the given array, which must be in
+// slice major, then row major order.
+func (m *Cube) AssignVector(values []float64) (*Cube, error) {
+	if len(values) != m.Size() {
+		return m, fmt.Errorf("Must have same length: length=%d slices()*rows()*columns()=%d", len(values), m.Size())
+	}
+	idx := 0
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				m.SetQuick(s, r, c, values[idx])
+				idx++
+			}
+		}
+	}
+	return m, nil
+}
+
+// Sets all cells to the values of the given array, which must have the
+// form values[slice][row][column].
+func (m *Cube) AssignArray(values [][][]float64) (*Cube, error) {
+	if len(values) != m.Slices() {
+		return m, fmt.Errorf("Must have same number of slices: slices=%d slices()=%d", len(values), m.Slices())
+	}
+	for s := 0; s < m.Slices(); s++ {
+		currentSlice := values[s]
+		if len(currentSlice) != m.Rows() {
+			return m, fmt.Errorf("Must have same number of rows in every slice: rows=%d rows()=%d", len(currentSlice), m.Rows())
+		}
+		for r := 0; r < m.Rows(); r++ {
+			currentRow := currentSlice[r]
+			if len(currentRow) != m.Columns() {
+				return m, fmt.Errorf("Must have same number of columns in every row: columns=%d columns()=%d", len(currentRow), m.Columns())
+			}
+			for c := 0; c < m.Columns(); c++ {
+				m.SetQuick(s, r, c, currentRow[c])
+			}
+		}
+	}
+	return m, nil
+}
+
+// Sets all cells to the values of the corresponding cells of other.
+func (m *Cube) AssignCube(other Cub) (*Cube, error) {
+	if o, ok := other.(*Cube); ok {
+		other = o.Cub
+	}
+	if other == m.Cub {
+		return m, nil
+	}
+	err := m.checkShape(other)
+	if err != nil {
+		return m, err
+	}
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				m.SetQuick(s, r, c, other.GetQuick(s, r, c))
+			}
+		}
+	}
+	return m, nil
+}
+
+// Assigns the result of a function to each cell;
+// x[i,j,k] = f(x[i,j,k], y[i,j,k]).
+func (m *Cube) AssignCubeFunc(y Cub, f Float64Float64Func) (*Cube, error) {
+	err := m.checkShape(y)
+	if err != nil {
+		return m, err
+	}
+	for s := 0; s < m.Slices(); s++ {
+		for r := 0; r < m.Rows(); r++ {
+			for c := 0; c < m.Columns(); c++ {
+				m.SetQuick(s, r, c, f(m.GetQuick(s, r, c), y.GetQuick(s, r, c)))
+			}
+		}
+	}
+	return m, nil
+}
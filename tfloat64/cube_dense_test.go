@@ -0,0 +1,63 @@
+package tfloat64
+
+import "testing"
+
+func makeDenseCube() *Cube {
+	return fillCube(NewCube(nslices, nrows, ncolumns))
+}
+
+func TestDenseCubeGetSet(t *testing.T) {
+	testCubeGetSet(t, makeDenseCube())
+}
+
+func TestDenseCubeCopy(t *testing.T) {
+	testCubeCopy(t, makeDenseCube())
+}
+
+func TestDenseCubeCardinality(t *testing.T) {
+	testCubeCardinality(t, makeDenseCube())
+}
+
+func TestDenseCubeAssign(t *testing.T) {
+	testCubeAssign(t, makeDenseCube())
+}
+
+func TestDenseCubeAggregate(t *testing.T) {
+	testCubeAggregate(t, makeDenseCube())
+}
+
+func TestDenseCubeNormalize(t *testing.T) {
+	testCubeNormalize(t, makeDenseCube())
+}
+
+func TestDenseCubeEmpty(t *testing.T) {
+	testCubeEmpty(t, NewCube(0, 3, 4))
+}
+
+func TestDenseCubeViewSlice(t *testing.T) {
+	testCubeViewSlice(t, makeDenseCube())
+}
+
+func TestDenseCubeViewRowColumn(t *testing.T) {
+	testCubeViewRowColumn(t, makeDenseCube())
+}
+
+func TestDenseCubeViewDice(t *testing.T) {
+	testCubeViewDice(t, makeDenseCube())
+}
+
+func TestDenseCubeViewPartFlipStrides(t *testing.T) {
+	testCubeViewPartFlipStrides(t, makeDenseCube())
+}
+
+func TestDenseCubeString(t *testing.T) {
+	testCubeString(t, makeDenseCube())
+}
+
+func TestDenseCubeViewSelection(t *testing.T) {
+	testCubeViewSelection(t, makeDenseCube())
+}
+
+func TestDenseCubeViewSelectionProcedure(t *testing.T) {
+	testCubeViewSelectionProcedure(t, makeDenseCube())
+}
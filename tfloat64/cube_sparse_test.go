@@ -0,0 +1,63 @@
+package tfloat64
+
+import "testing"
+
+func makeSparseCube() *Cube {
+	return fillCube(NewSparseCube(nslices, nrows, ncolumns))
+}
+
+func TestSparseCubeGetSet(t *testing.T) {
+	testCubeGetSet(t, makeSparseCube())
+}
+
+func TestSparseCubeCopy(t *testing.T) {
+	testCubeCopy(t, makeSparseCube())
+}
+
+func TestSparseCubeCardinality(t *testing.T) {
+	testCubeCardinality(t, makeSparseCube())
+}
+
+func TestSparseCubeAssign(t *testing.T) {
+	testCubeAssign(t, makeSparseCube())
+}
+
+func TestSparseCubeAggregate(t *testing.T) {
+	testCubeAggregate(t, makeSparseCube())
+}
+
+func TestSparseCubeNormalize(t *testing.T) {
+	testCubeNormalize(t, makeSparseCube())
+}
+
+func TestSparseCubeEmpty(t *testing.T) {
+	testCubeEmpty(t, NewSparseCube(0, 3, 4))
+}
+
+func TestSparseCubeViewSlice(t *testing.T) {
+	testCubeViewSlice(t, makeSparseCube())
+}
+
+func TestSparseCubeViewRowColumn(t *testing.T) {
+	testCubeViewRowColumn(t, makeSparseCube())
+}
+
+func TestSparseCubeViewDice(t *testing.T) {
+	testCubeViewDice(t, makeSparseCube())
+}
+
+func TestSparseCubeViewPartFlipStrides(t *testing.T) {
+	testCubeViewPartFlipStrides(t, makeSparseCube())
+}
+
+func TestSparseCubeString(t *testing.T) {
+	testCubeString(t, makeSparseCube())
+}
+
+func TestSparseCubeViewSelection(t *testing.T) {
+	testCubeViewSelection(t, makeSparseCube())
+}
+
+func TestSparseCubeViewSelectionProcedure(t *testing.T) {
+	testCubeViewSelectionProcedure(t, makeSparseCube())
+}
@@ -0,0 +1,460 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const nslices = 5
+
+// Fills A with random values in (0, 1].
+func fillCube(A *Cube) *Cube {
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				A.SetQuick(s, r, c, 1-rand.Float64())
+			}
+		}
+	}
+	return A
+}
+
+func testCubeGetSet(t *testing.T, A *Cube) {
+	if err := A.Set(1, 2, 3, 4.5); err != nil {
+		t.Fatal(err)
+	}
+	value, err := A.Get(1, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 4.5 {
+		t.Errorf("expected:%g actual:%g", 4.5, value)
+	}
+	if _, err := A.Get(A.Slices(), 0, 0); err == nil {
+		t.Error("expected slice out of bounds error")
+	}
+	if err := A.Set(0, -1, 0, 1); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if err := A.Set(0, 0, A.Columns(), 1); err == nil {
+		t.Error("expected column out of bounds error")
+	}
+}
+
+func testCubeCopy(t *testing.T, A *Cube) {
+	B := A.Copy()
+	if !B.EqualsCube(A) {
+		t.Error("expected copy to equal original")
+	}
+	B.SetQuick(0, 0, 0, B.GetQuick(0, 0, 0)+1)
+	if B.EqualsCube(A) {
+		t.Error("expected copy to be independent of original")
+	}
+	if A.EqualsCube(&Cube{A.Like(A.Slices(), A.Rows(), A.Columns()+1)}) {
+		t.Error("expected cubes of different shape to differ")
+	}
+}
+
+func testCubeCardinality(t *testing.T, A *Cube) {
+	if card := A.Cardinality(); card != A.Size() {
+		t.Errorf("expected:%d actual:%d", A.Size(), card)
+	}
+	A.SetQuick(1, 1, 1, 0)
+	A.SetQuick(2, 0, 3, 0)
+	if card := A.Cardinality(); card != A.Size()-2 {
+		t.Errorf("expected:%d actual:%d", A.Size()-2, card)
+	}
+}
+
+func testCubeAssign(t *testing.T, A *Cube) {
+	A.Assign(2.5)
+	if !A.Equals(2.5) || A.Equals(2) {
+		t.Error("expected all cells equal to 2.5")
+	}
+	A.AssignFunc(Square)
+	if !A.Equals(6.25) {
+		t.Error("expected all cells equal to 6.25")
+	}
+	A.SetQuick(1, 2, 3, -1)
+	A.AssignProcedure(IsLessThan(0), 7)
+	if A.GetQuick(1, 2, 3) != 7 || A.GetQuick(0, 0, 0) != 6.25 {
+		t.Error("expected only negative cells assigned")
+	}
+	A.AssignProcedureFunc(IsGreaterThan(6.5), Neg)
+	if A.GetQuick(1, 2, 3) != -7 || A.GetQuick(0, 0, 0) != 6.25 {
+		t.Error("expected only cells greater than 6.5 negated")
+	}
+
+	values := make([]float64, A.Size())
+	for i := range values {
+		values[i] = float64(i)
+	}
+	if _, err := A.AssignVector(values); err != nil {
+		t.Fatal(err)
+	}
+	i := 0
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				if A.GetQuick(s, r, c) != float64(i) {
+					t.Errorf("expected:%g actual:%g", float64(i), A.GetQuick(s, r, c))
+				}
+				i++
+			}
+		}
+	}
+	if _, err := A.AssignVector(values[1:]); err == nil {
+		t.Error("expected length error")
+	}
+
+	B := A.Copy().AssignFunc(Neg)
+	if _, err := A.AssignArray(B.ToArray()); err != nil {
+		t.Fatal(err)
+	}
+	if !A.EqualsCube(B) {
+		t.Error("expected array assignment to equal source")
+	}
+	if _, err := A.AssignArray(B.ToArray()[1:]); err == nil {
+		t.Error("expected slices error")
+	}
+
+	C := fillCube(&Cube{A.Like(A.Slices(), A.Rows(), A.Columns())})
+	if _, err := A.AssignCube(C); err != nil {
+		t.Fatal(err)
+	}
+	if !A.EqualsCube(C) {
+		t.Error("expected cube assignment to equal source")
+	}
+	if _, err := A.AssignCubeFunc(C, Minus); err != nil {
+		t.Fatal(err)
+	}
+	if !A.Equals(0) {
+		t.Error("expected all cells zero")
+	}
+	if _, err := A.AssignCube(NewCube(1, 1, 1)); err == nil {
+		t.Error("expected shape error")
+	}
+}
+
+func testCubeAggregate(t *testing.T, A *Cube) {
+	expected := 0.0
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				expected += A.GetQuick(s, r, c) * A.GetQuick(s, r, c)
+			}
+		}
+	}
+	if result := A.Aggregate(Plus, Square); math.Abs(expected-result) > tol {
+		t.Errorf("expected:%g actual:%g", expected, result)
+	}
+	result, err := A.AggregateCube(A.Copy(), Plus, Mult)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(expected-result) > tol {
+		t.Errorf("expected:%g actual:%g", expected, result)
+	}
+	if _, err := A.AggregateCube(NewCube(1, 1, 1), Plus, Mult); err == nil {
+		t.Error("expected shape error")
+	}
+
+	A.SetQuick(2, 1, 0, -3)
+	if result := A.AggregateProcedure(Plus, Identity, IsLessThan(0)); result != -3 {
+		t.Errorf("expected:%g actual:%g", -3.0, result)
+	}
+}
+
+func testCubeNormalize(t *testing.T, A *Cube) {
+	A.SetQuick(3, 2, 1, 10)
+	A.SetQuick(1, 0, 4, -1)
+	max, s, r, c := A.MaxLocation()
+	if max != 10 || s != 3 || r != 2 || c != 1 {
+		t.Errorf("expected:10 at (3, 2, 1) actual:%g at (%d, %d, %d)", max, s, r, c)
+	}
+	min, s, r, c := A.MinLocation()
+	if min != -1 || s != 1 || r != 0 || c != 4 {
+		t.Errorf("expected:-1 at (1, 0, 4) actual:%g at (%d, %d, %d)", min, s, r, c)
+	}
+	A.Normalize()
+	if math.Abs(A.ZSum()-1) > tol {
+		t.Errorf("expected:1 actual:%g", A.ZSum())
+	}
+	if min, _, _, _ := A.MinLocation(); min < 0 {
+		t.Errorf("expected non-negative actual:%g", min)
+	}
+}
+
+func testCubeEmpty(t *testing.T, A *Cube) {
+	max, s, r, c := A.MaxLocation()
+	if !math.IsNaN(max) || s != -1 || r != -1 || c != -1 {
+		t.Errorf("expected:NaN at (-1, -1, -1) actual:%g at (%d, %d, %d)", max, s, r, c)
+	}
+	min, s, r, c := A.MinLocation()
+	if !math.IsNaN(min) || s != -1 || r != -1 || c != -1 {
+		t.Errorf("expected:NaN at (-1, -1, -1) actual:%g at (%d, %d, %d)", min, s, r, c)
+	}
+	A.Normalize()
+	if A.Size() != 0 {
+		t.Errorf("expected:%d actual:%d", 0, A.Size())
+	}
+}
+
+func testCubeViewSlice(t *testing.T, A *Cube) {
+	for _, s := range []int{0, A.Slices() - 1} {
+		B, err := A.ViewSlice(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if B.Rows() != A.Rows() || B.Columns() != A.Columns() {
+			t.Fatalf("expected:%d x %d actual:%s", A.Rows(), A.Columns(), B.StringShort())
+		}
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				if B.GetQuick(r, c) != A.GetQuick(s, r, c) {
+					t.Errorf("expected:%g actual:%g", A.GetQuick(s, r, c), B.GetQuick(r, c))
+				}
+			}
+		}
+		B.SetQuick(1, 2, -1)
+		if A.GetQuick(s, 1, 2) != -1 {
+			t.Error("expected view to share cells with cube")
+		}
+	}
+	if _, err := A.ViewSlice(A.Slices()); err == nil {
+		t.Error("expected slice out of bounds error")
+	}
+}
+
+func testCubeViewRowColumn(t *testing.T, A *Cube) {
+	row := 2
+	B, err := A.ViewRow(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for s := 0; s < A.Slices(); s++ {
+		for c := 0; c < A.Columns(); c++ {
+			if B.GetQuick(s, c) != A.GetQuick(s, row, c) {
+				t.Errorf("expected:%g actual:%g", A.GetQuick(s, row, c), B.GetQuick(s, c))
+			}
+		}
+	}
+	B.SetQuick(3, 4, -2)
+	if A.GetQuick(3, row, 4) != -2 {
+		t.Error("expected row view to share cells with cube")
+	}
+
+	column := 5
+	C, err := A.ViewColumn(column)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			if C.GetQuick(s, r) != A.GetQuick(s, r, column) {
+				t.Errorf("expected:%g actual:%g", A.GetQuick(s, r, column), C.GetQuick(s, r))
+			}
+		}
+	}
+	C.SetQuick(1, 7, -3)
+	if A.GetQuick(1, 7, column) != -3 {
+		t.Error("expected column view to share cells with cube")
+	}
+
+	if _, err := A.ViewRow(-1); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if _, err := A.ViewColumn(A.Columns()); err == nil {
+		t.Error("expected column out of bounds error")
+	}
+}
+
+func testCubeViewDice(t *testing.T, A *Cube) {
+	B, err := A.ViewDice(2, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if B.Slices() != A.Columns() || B.Rows() != A.Slices() || B.Columns() != A.Rows() {
+		t.Fatalf("unexpected shape: %s", B.StringShort())
+	}
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				if B.GetQuick(c, s, r) != A.GetQuick(s, r, c) {
+					t.Errorf("expected:%g actual:%g", A.GetQuick(s, r, c), B.GetQuick(c, s, r))
+				}
+			}
+		}
+	}
+	if !B.IsView() || A.IsView() {
+		t.Error("expected only the diced cube to be a view")
+	}
+	if _, err := A.ViewDice(0, 1, 1); err == nil {
+		t.Error("expected illegal axes error")
+	}
+
+	// Slices of a diced view are views of the original cube.
+	S, err := B.ViewSlice(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	S.SetQuick(1, 2, -4)
+	if A.GetQuick(1, 2, 3) != -4 {
+		t.Error("expected slice of diced view to share cells with cube")
+	}
+}
+
+func testCubeViewPartFlipStrides(t *testing.T, A *Cube) {
+	B, err := A.ViewPart(1, 2, 3, 3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if B.Slices() != 3 || B.Rows() != 4 || B.Columns() != 5 {
+		t.Fatalf("unexpected shape: %s", B.StringShort())
+	}
+	if B.GetQuick(2, 3, 4) != A.GetQuick(3, 5, 7) {
+		t.Errorf("expected:%g actual:%g", A.GetQuick(3, 5, 7), B.GetQuick(2, 3, 4))
+	}
+	if _, err := A.ViewPart(1, 0, 0, A.Slices(), 1, 1); err == nil {
+		t.Error("expected box out of bounds error")
+	}
+
+	F := B.ViewSliceFlip().ViewRowFlip().ViewColumnFlip()
+	if F.GetQuick(0, 0, 0) != B.GetQuick(2, 3, 4) || F.GetQuick(2, 3, 4) != A.GetQuick(1, 2, 3) {
+		t.Error("expected flipped view to reverse all axes")
+	}
+
+	S, err := A.ViewStrides(2, 3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Slices() != 3 || S.Rows() != 5 || S.Columns() != 5 {
+		t.Fatalf("unexpected shape: %s", S.StringShort())
+	}
+	S.SetQuick(2, 4, 4, -5)
+	if A.GetQuick(4, 12, 16) != -5 {
+		t.Error("expected strided view to share cells with cube")
+	}
+	if _, err := A.ViewStrides(1, 0, 1); err == nil {
+		t.Error("expected illegal strides error")
+	}
+}
+
+func testCubeString(t *testing.T, A *Cube) {
+	A = &Cube{A.Like(2, 2, 3)}
+	i := -7.0
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				A.SetQuick(s, r, c, i)
+				i += 1.5
+			}
+		}
+	}
+	expected := "2 x 2 x 3 cube\n" +
+		"-7   -5.5 -4\n" +
+		"-2.5 -1    0.5\n" +
+		"\n" +
+		"2   3.5 5\n" +
+		"6.5 8   9.5"
+	if actual := A.String(); actual != expected {
+		t.Errorf("expected:%q actual:%q", expected, actual)
+	}
+}
+
+func testCubeViewSelection(t *testing.T, A *Cube) {
+	slices := []int{4, 0, 2, 0}
+	rows := []int{1, 12, 5}
+	columns := []int{16, 3}
+	B, err := A.ViewSelection(slices, rows, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if B.Slices() != 4 || B.Rows() != 3 || B.Columns() != 2 {
+		t.Fatalf("unexpected shape: %s", B.StringShort())
+	}
+	for s, si := range slices {
+		for r, ri := range rows {
+			for c, ci := range columns {
+				if B.GetQuick(s, r, c) != A.GetQuick(si, ri, ci) {
+					t.Errorf("expected:%g actual:%g", A.GetQuick(si, ri, ci), B.GetQuick(s, r, c))
+				}
+			}
+		}
+	}
+	B.SetQuick(2, 1, 0, -1)
+	if A.GetQuick(2, 12, 16) != -1 {
+		t.Error("expected selection to share cells with cube")
+	}
+
+	// Views of a selection remain selections.
+	D, err := B.ViewDice(2, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if D.GetQuick(0, 1, 2) != -1 {
+		t.Errorf("expected:%g actual:%g", -1.0, D.GetQuick(0, 1, 2))
+	}
+	F := B.ViewSliceFlip()
+	if F.GetQuick(0, 2, 1) != A.GetQuick(0, 5, 3) {
+		t.Errorf("expected:%g actual:%g", A.GetQuick(0, 5, 3), F.GetQuick(0, 2, 1))
+	}
+	S, err := B.ViewSlice(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if S.Rows() != 3 || S.Columns() != 2 || S.GetQuick(1, 0) != -1 {
+		t.Error("expected slice of selection to select the same cells")
+	}
+	S.SetQuick(2, 1, -2)
+	if A.GetQuick(2, 5, 3) != -2 {
+		t.Error("expected slice of selection to share cells with cube")
+	}
+	R, err := B.ViewRow(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if R.Rows() != 4 || R.GetQuick(0, 1) != A.GetQuick(4, 1, 3) {
+		t.Error("expected row of selection to select the same cells")
+	}
+
+	// nil selects all indexes of an axis.
+	C, err := A.ViewSelection(nil, rows, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if C.Slices() != A.Slices() || C.Columns() != A.Columns() {
+		t.Fatalf("unexpected shape: %s", C.StringShort())
+	}
+	if C.GetQuick(3, 2, 7) != A.GetQuick(3, 5, 7) {
+		t.Errorf("expected:%g actual:%g", A.GetQuick(3, 5, 7), C.GetQuick(3, 2, 7))
+	}
+
+	if _, err := A.ViewSelection([]int{A.Slices()}, nil, nil); err == nil {
+		t.Error("expected slice out of bounds error")
+	}
+	if _, err := A.ViewSelection(nil, []int{-1}, nil); err == nil {
+		t.Error("expected row out of bounds error")
+	}
+	if _, err := A.ViewSelection(nil, nil, []int{A.Columns()}); err == nil {
+		t.Error("expected column out of bounds error")
+	}
+}
+
+func testCubeViewSelectionProcedure(t *testing.T, A *Cube) {
+	for s := 0; s < A.Slices(); s++ {
+		A.SetQuick(s, 0, 0, float64(s%2))
+	}
+	B := A.ViewSelectionProcedure(func(a Mat) bool {
+		return a.GetQuick(0, 0) == 1
+	})
+	if B.Slices() != A.Slices()/2 || B.Rows() != A.Rows() || B.Columns() != A.Columns() {
+		t.Fatalf("unexpected shape: %s", B.StringShort())
+	}
+	for s := 0; s < B.Slices(); s++ {
+		if B.GetQuick(s, 4, 6) != A.GetQuick(2*s+1, 4, 6) {
+			t.Errorf("expected:%g actual:%g", A.GetQuick(2*s+1, 4, 6), B.GetQuick(s, 4, 6))
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package tfloat64
+
+// Returns a string representation using default formatting.
+func (m *Cube) String() string {
+	return fmtr.CubeToString(m)
+}
+
+// Returns a rows x columns matrix view of the given slice. The view
+// shares the cells of the cube; changes to either are reflected in the
+// other.
+func (m *Cube) ViewSlice(slice int) (*Matrix, error) {
+	err := m.checkSlice(slice)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := m.Cub.(planeViewCub); ok {
+		return &Matrix{p.ViewSlice(slice)}, nil
+	}
+	return &Matrix{m.Like2D(m.Rows(), m.Columns(),
+		m.SliceZero()+slice*m.SliceStride()+m.RowZero(), m.ColumnZero(),
+		m.RowStride(), m.ColumnStride())}, nil
+}
+
+// Returns a slices x columns matrix view of the given row across all
+// slices. The view shares the cells of the cube.
+func (m *Cube) ViewRow(row int) (*Matrix, error) {
+	err := m.checkRow(row)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := m.Cub.(planeViewCub); ok {
+		return &Matrix{p.ViewRow(row)}, nil
+	}
+	return &Matrix{m.Like2D(m.Slices(), m.Columns(),
+		m.SliceZero()+m.RowZero()+row*m.RowStride(), m.ColumnZero(),
+		m.SliceStride(), m.ColumnStride())}, nil
+}
+
+// Returns a slices x rows matrix view of the given column across all
+// slices. The view shares the cells of the cube.
+func (m *Cube) ViewColumn(column int) (*Matrix, error) {
+	err := m.checkColumn(column)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := m.Cub.(planeViewCub); ok {
+		return &Matrix{p.ViewColumn(column)}, nil
+	}
+	return &Matrix{m.Like2D(m.Slices(), m.Rows(),
+		m.SliceZero(), m.RowZero()+m.ColumnZero()+column*m.ColumnStride(),
+		m.SliceStride(), m.RowStride())}, nil
+}
+
+// Returns a view with the axes permuted; axis0, axis1 and axis2 give the
+// axes of the receiver (0 for slices, 1 for rows and 2 for columns) that
+// become the slices, rows and columns of the view. For example,
+// ViewDice(2, 1, 0) swaps slices and columns.
+func (m *Cube) ViewDice(axis0, axis1, axis2 int) (*Cube, error) {
+	v := m.View()
+	err := v.VDice(axis0, axis1, axis2)
+	if err != nil {
+		return nil, err
+	}
+	return &Cube{v}, nil
+}
+
+// Returns a depth x height x width view of the sub-range of cells
+// starting at [slice,row,column].
+func (m *Cube) ViewPart(slice, row, column, depth, height, width int) (*Cube, error) {
+	v := m.View()
+	err := v.VPart(slice, row, column, depth, height, width)
+	if err != nil {
+		return nil, err
+	}
+	return &Cube{v}, nil
+}
+
+// Returns a view with the order of the slices reversed.
+func (m *Cube) ViewSliceFlip() *Cube {
+	v := m.View()
+	v.VSliceFlip()
+	return &Cube{v}
+}
+
+// Returns a view with the order of the rows reversed.
+func (m *Cube) ViewRowFlip() *Cube {
+	v := m.View()
+	v.VRowFlip()
+	return &Cube{v}
+}
+
+// Returns a view with the order of the columns reversed.
+func (m *Cube) ViewColumnFlip() *Cube {
+	v := m.View()
+	v.VColumnFlip()
+	return &Cube{v}
+}
+
+// Returns a view of every sliceStride-th slice, rowStride-th row and
+// columnStride-th column. The strides must be positive.
+func (m *Cube) ViewStrides(sliceStride, rowStride, columnStride int) (*Cube, error) {
+	v := m.View()
+	err := v.VStrides(sliceStride, rowStride, columnStride)
+	if err != nil {
+		return nil, err
+	}
+	return &Cube{v}, nil
+}
+
+// Returns a selection view holding the indicated slices, rows and
+// columns, with view.Get(s,r,c) == m.Get(sliceIndexes[s], rowIndexes[r],
+// columnIndexes[c]). Indexes can occur multiple times and can be in
+// arbitrary order. A nil list selects all indexes of that axis. The view
+// shares the cells of the cube; modifying the index lists after the call
+// has no effect on the view.
+func (m *Cube) ViewSelection(sliceIndexes, rowIndexes, columnIndexes []int) (*Cube, error) {
+	sliceIndexes, err := selectionIndexes(sliceIndexes, m.Slices(), m.checkSlice)
+	if err != nil {
+		return nil, err
+	}
+	rowIndexes, err = selectionIndexes(rowIndexes, m.Rows(), m.checkRow)
+	if err != nil {
+		return nil, err
+	}
+	columnIndexes, err = selectionIndexes(columnIndexes, m.Columns(), m.checkColumn)
+	if err != nil {
+		return nil, err
+	}
+	sliceOffsets := make([]int, len(sliceIndexes))
+	rowOffsets := make([]int, len(rowIndexes))
+	columnOffsets := make([]int, len(columnIndexes))
+	if len(sliceIndexes) > 0 && len(rowIndexes) > 0 && len(columnIndexes) > 0 {
+		base := m.Index(0, 0, 0)
+		for i, s := range sliceIndexes {
+			sliceOffsets[i] = m.Index(s, 0, 0)
+		}
+		for i, r := range rowIndexes {
+			rowOffsets[i] = m.Index(0, r, 0) - base
+		}
+		for i, c := range columnIndexes {
+			columnOffsets[i] = m.Index(0, 0, c) - base
+		}
+	}
+	return &Cube{m.ViewSelectionLike(sliceOffsets, rowOffsets, columnOffsets)}, nil
+}
+
+// Returns a selection view holding the slices for which condition
+// yields true when applied to the slice view, together with all rows and
+// columns.
+//
+// Example:
+//
+//	// extract and view all slices whose top left cell is positive
+//	m.ViewSelectionProcedure(func(a Mat) bool {
+//	   return a.GetQuick(0, 0) > 0
+//	})
+func (m *Cube) ViewSelectionProcedure(condition MatrixProcedure) *Cube {
+	matches := make([]int, 0)
+	for s := 0; s < m.Slices(); s++ {
+		slice, _ := m.ViewSlice(s)
+		if condition(slice.Mat) {
+			matches = append(matches, s)
+		}
+	}
+	view, _ := m.ViewSelection(matches, nil, nil) // take all rows and columns
+	return view
+}
+
+// Returns indexes, or 0..n-1 if indexes is nil, after checking each
+// index with check.
+func selectionIndexes(indexes []int, n int, check func(int) error) ([]int, error) {
+	if indexes == nil {
+		indexes = make([]int, n)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes, nil
+	}
+	for _, index := range indexes {
+		if err := check(index); err != nil {
+			return nil, err
+		}
+	}
+	return indexes, nil
+}
@@ -0,0 +1,857 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+)
+
+// Eigenvalues and eigenvectors of a real matrix A.
+//
+// If A is symmetric, then A = V*D*V' where the eigenvalue matrix D is
+// diagonal and the eigenvector matrix V is orthogonal. I.e. A = V*D*V'
+// and V*V' = I.
+//
+// If A is not symmetric, then the eigenvalue matrix D is block diagonal
+// with the real eigenvalues in 1-by-1 blocks and any complex eigenvalues,
+// lambda + i*mu, in 2-by-2 blocks, [lambda, mu; -mu, lambda]. The columns
+// of V represent the eigenvectors in the sense that A*V = V*D. The matrix V
+// may be badly conditioned, or even singular, so the validity of the
+// equation A = V*D*inverse(V) depends upon V.cond().
+type DenseEigenvalueDecomposition struct {
+	n           int         // Row and column dimension (square matrix).
+	isSymmetric bool        // Whether the matrix is symmetric.
+	d, e        []float64   // Storage of the real and imaginary parts of the eigenvalues.
+	v           [][]float64 // Storage of the eigenvectors.
+	h           [][]float64 // Storage of the nonsymmetric Hessenberg form.
+	ort         []float64   // Working storage for the nonsymmetric algorithm.
+	like        Mat         // Backend used to construct result matrices.
+}
+
+// Constructs and returns a new eigenvalue decomposition of the given
+// matrix. Symmetric matrices are reduced to tridiagonal form and
+// diagonalized by the QL algorithm, other matrices are reduced to
+// Hessenberg form and then to real Schur form. The default tolerance of the
+// package Property decides whether A is symmetric. Returns an error if A is
+// not square.
+func NewDenseEigenvalueDecomposition(A *Matrix) (*DenseEigenvalueDecomposition, error) {
+	return NewDenseEigenvalueDecompositionTolerance(A, prop.tolerance)
+}
+
+// Constructs and returns a new eigenvalue decomposition of the given
+// matrix which uses the given tolerance to determine whether A is
+// symmetric. Returns an error if A is not square.
+func NewDenseEigenvalueDecompositionTolerance(A *Matrix, tolerance float64) (*DenseEigenvalueDecomposition, error) {
+	n := A.Rows()
+	if A.Columns() != n {
+		return nil, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	d := &DenseEigenvalueDecomposition{
+		n:    n,
+		d:    make([]float64, n),
+		e:    make([]float64, n),
+		like: A.Mat,
+	}
+
+	d.isSymmetric = true
+	for j := 0; j < n && d.isSymmetric; j++ {
+		for i := 0; i < n && d.isSymmetric; i++ {
+			d.isSymmetric = math.Abs(A.GetQuick(i, j)-A.GetQuick(j, i)) <= tolerance
+		}
+	}
+
+	if d.isSymmetric {
+		d.v = A.ToArray()
+		// Tridiagonalize.
+		d.tred2()
+		// Diagonalize.
+		d.tql2()
+	} else {
+		d.v = make([][]float64, n)
+		for i := range d.v {
+			d.v[i] = make([]float64, n)
+		}
+		d.h = A.ToArray()
+		d.ort = make([]float64, n)
+		// Reduce to Hessenberg form.
+		d.orthes()
+		// Reduce Hessenberg to real Schur form.
+		d.hqr2()
+	}
+	return d, nil
+}
+
+// Returns whether the decomposed matrix was symmetric.
+func (d *DenseEigenvalueDecomposition) IsSymmetric() bool {
+	return d.isSymmetric
+}
+
+// Returns the block diagonal eigenvalue matrix, D.
+func (d *DenseEigenvalueDecomposition) D() *Matrix {
+	D := &Matrix{d.like.Like(d.n, d.n)}
+	for i := 0; i < d.n; i++ {
+		D.SetQuick(i, i, d.d[i])
+		if d.e[i] > 0 {
+			D.SetQuick(i, i+1, d.e[i])
+		} else if d.e[i] < 0 {
+			D.SetQuick(i, i-1, d.e[i])
+		}
+	}
+	return D
+}
+
+// Returns the real parts of the eigenvalues.
+func (d *DenseEigenvalueDecomposition) RealEigenvalues() *Vector {
+	v := &Vector{d.like.LikeVector(d.n)}
+	v.AssignArray(d.d)
+	return v
+}
+
+// Returns the imaginary parts of the eigenvalues.
+func (d *DenseEigenvalueDecomposition) ImagEigenvalues() *Vector {
+	v := &Vector{d.like.LikeVector(d.n)}
+	v.AssignArray(d.e)
+	return v
+}
+
+// Returns the eigenvector matrix, V.
+func (d *DenseEigenvalueDecomposition) V() *Matrix {
+	V := &Matrix{d.like.Like(d.n, d.n)}
+	V.AssignArray(d.v)
+	return V
+}
+
+// Symmetric Householder reduction to tridiagonal form.
+//
+// This is derived from the Algol procedures tred2 by Bowdler, Martin,
+// Reinsch, and Wilkinson, Handbook for Auto. Comp., Vol.ii-Linear Algebra,
+// and the corresponding Fortran subroutine in EISPACK.
+func (d *DenseEigenvalueDecomposition) tred2() {
+	n := d.n
+	V, dd, e := d.v, d.d, d.e
+	if n == 0 {
+		return
+	}
+	for j := 0; j < n; j++ {
+		dd[j] = V[n-1][j]
+	}
+
+	// Householder reduction to tridiagonal form.
+	for i := n - 1; i > 0; i-- {
+		// Scale to avoid under/overflow.
+		scale := 0.0
+		h := 0.0
+		for k := 0; k < i; k++ {
+			scale += math.Abs(dd[k])
+		}
+		if scale == 0 {
+			e[i] = dd[i-1]
+			for j := 0; j < i; j++ {
+				dd[j] = V[i-1][j]
+				V[i][j] = 0
+				V[j][i] = 0
+			}
+		} else {
+			// Generate Householder vector.
+			for k := 0; k < i; k++ {
+				dd[k] /= scale
+				h += dd[k] * dd[k]
+			}
+			f := dd[i-1]
+			g := math.Sqrt(h)
+			if f > 0 {
+				g = -g
+			}
+			e[i] = scale * g
+			h -= f * g
+			dd[i-1] = f - g
+			for j := 0; j < i; j++ {
+				e[j] = 0
+			}
+
+			// Apply similarity transformation to remaining columns.
+			for j := 0; j < i; j++ {
+				f = dd[j]
+				V[j][i] = f
+				g = e[j] + V[j][j]*f
+				for k := j + 1; k <= i-1; k++ {
+					g += V[k][j] * dd[k]
+					e[k] += V[k][j] * f
+				}
+				e[j] = g
+			}
+			f = 0
+			for j := 0; j < i; j++ {
+				e[j] /= h
+				f += e[j] * dd[j]
+			}
+			hh := f / (h + h)
+			for j := 0; j < i; j++ {
+				e[j] -= hh * dd[j]
+			}
+			for j := 0; j < i; j++ {
+				f = dd[j]
+				g = e[j]
+				for k := j; k <= i-1; k++ {
+					V[k][j] -= f*e[k] + g*dd[k]
+				}
+				dd[j] = V[i-1][j]
+				V[i][j] = 0
+			}
+		}
+		dd[i] = h
+	}
+
+	// Accumulate transformations.
+	for i := 0; i < n-1; i++ {
+		V[n-1][i] = V[i][i]
+		V[i][i] = 1
+		h := dd[i+1]
+		if h != 0 {
+			for k := 0; k <= i; k++ {
+				dd[k] = V[k][i+1] / h
+			}
+			for j := 0; j <= i; j++ {
+				g := 0.0
+				for k := 0; k <= i; k++ {
+					g += V[k][i+1] * V[k][j]
+				}
+				for k := 0; k <= i; k++ {
+					V[k][j] -= g * dd[k]
+				}
+			}
+		}
+		for k := 0; k <= i; k++ {
+			V[k][i+1] = 0
+		}
+	}
+	for j := 0; j < n; j++ {
+		dd[j] = V[n-1][j]
+		V[n-1][j] = 0
+	}
+	V[n-1][n-1] = 1
+	e[0] = 0
+}
+
+// Symmetric tridiagonal QL algorithm.
+//
+// This is derived from the Algol procedures tql2, by Bowdler, Martin,
+// Reinsch, and Wilkinson, Handbook for Auto. Comp., Vol.ii-Linear Algebra,
+// and the corresponding Fortran subroutine in EISPACK.
+func (d *DenseEigenvalueDecomposition) tql2() {
+	n := d.n
+	V, dd, e := d.v, d.d, d.e
+	if n == 0 {
+		return
+	}
+	for i := 1; i < n; i++ {
+		e[i-1] = e[i]
+	}
+	e[n-1] = 0
+
+	f := 0.0
+	tst1 := 0.0
+	eps := math.Pow(2, -52)
+	for l := 0; l < n; l++ {
+		// Find small subdiagonal element.
+		tst1 = math.Max(tst1, math.Abs(dd[l])+math.Abs(e[l]))
+		m := l
+		for m < n {
+			if math.Abs(e[m]) <= eps*tst1 {
+				break
+			}
+			m++
+		}
+
+		// If m == l, dd[l] is an eigenvalue,
+		// otherwise, iterate.
+		if m > l {
+			for {
+				// Compute implicit shift.
+				g := dd[l]
+				p := (dd[l+1] - g) / (2 * e[l])
+				r := math.Hypot(p, 1)
+				if p < 0 {
+					r = -r
+				}
+				dd[l] = e[l] / (p + r)
+				dd[l+1] = e[l] * (p + r)
+				dl1 := dd[l+1]
+				h := g - dd[l]
+				for i := l + 2; i < n; i++ {
+					dd[i] -= h
+				}
+				f += h
+
+				// Implicit QL transformation.
+				p = dd[m]
+				c := 1.0
+				c2 := c
+				c3 := c
+				el1 := e[l+1]
+				s := 0.0
+				s2 := 0.0
+				for i := m - 1; i >= l; i-- {
+					c3 = c2
+					c2 = c
+					s2 = s
+					g = c * e[i]
+					h = c * p
+					r = math.Hypot(p, e[i])
+					e[i+1] = s * r
+					s = e[i] / r
+					c = p / r
+					p = c*dd[i] - s*g
+					dd[i+1] = h + s*(c*g+s*dd[i])
+
+					// Accumulate transformation.
+					for k := 0; k < n; k++ {
+						h = V[k][i+1]
+						V[k][i+1] = s*V[k][i] + c*h
+						V[k][i] = c*V[k][i] - s*h
+					}
+				}
+				p = -s * s2 * c3 * el1 * e[l] / dl1
+				e[l] = s * p
+				dd[l] = c * p
+
+				// Check for convergence.
+				if math.Abs(e[l]) <= eps*tst1 {
+					break
+				}
+			}
+		}
+		dd[l] += f
+		e[l] = 0
+	}
+
+	// Sort eigenvalues and corresponding vectors.
+	for i := 0; i < n-1; i++ {
+		k := i
+		p := dd[i]
+		for j := i + 1; j < n; j++ {
+			if dd[j] < p {
+				k = j
+				p = dd[j]
+			}
+		}
+		if k != i {
+			dd[k] = dd[i]
+			dd[i] = p
+			for j := 0; j < n; j++ {
+				V[j][i], V[j][k] = V[j][k], V[j][i]
+			}
+		}
+	}
+}
+
+// Nonsymmetric reduction to Hessenberg form.
+//
+// This is derived from the Algol procedures orthes and ortran, by Martin
+// and Wilkinson, Handbook for Auto. Comp., Vol.ii-Linear Algebra, and the
+// corresponding Fortran subroutines in EISPACK.
+func (d *DenseEigenvalueDecomposition) orthes() {
+	n := d.n
+	H, V, ort := d.h, d.v, d.ort
+	low := 0
+	high := n - 1
+
+	for m := low + 1; m <= high-1; m++ {
+		// Scale column.
+		scale := 0.0
+		for i := m; i <= high; i++ {
+			scale += math.Abs(H[i][m-1])
+		}
+		if scale != 0 {
+			// Compute Householder transformation.
+			h := 0.0
+			for i := high; i >= m; i-- {
+				ort[i] = H[i][m-1] / scale
+				h += ort[i] * ort[i]
+			}
+			g := math.Sqrt(h)
+			if ort[m] > 0 {
+				g = -g
+			}
+			h -= ort[m] * g
+			ort[m] -= g
+
+			// Apply Householder similarity transformation
+			// H = (I-u*u'/h)*H*(I-u*u')/h)
+			for j := m; j < n; j++ {
+				f := 0.0
+				for i := high; i >= m; i-- {
+					f += ort[i] * H[i][j]
+				}
+				f /= h
+				for i := m; i <= high; i++ {
+					H[i][j] -= f * ort[i]
+				}
+			}
+			for i := 0; i <= high; i++ {
+				f := 0.0
+				for j := high; j >= m; j-- {
+					f += ort[j] * H[i][j]
+				}
+				f /= h
+				for j := m; j <= high; j++ {
+					H[i][j] -= f * ort[j]
+				}
+			}
+			ort[m] = scale * ort[m]
+			H[m][m-1] = scale * g
+		}
+	}
+
+	// Accumulate transformations (Algorithm page 200).
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				V[i][j] = 1
+			} else {
+				V[i][j] = 0
+			}
+		}
+	}
+	for m := high - 1; m >= low+1; m-- {
+		if H[m][m-1] != 0 {
+			for i := m + 1; i <= high; i++ {
+				ort[i] = H[i][m-1]
+			}
+			for j := m; j <= high; j++ {
+				g := 0.0
+				for i := m; i <= high; i++ {
+					g += ort[i] * V[i][j]
+				}
+				// Double division avoids possible underflow.
+				g = (g / ort[m]) / H[m][m-1]
+				for i := m; i <= high; i++ {
+					V[i][j] += g * ort[i]
+				}
+			}
+		}
+	}
+}
+
+// Complex scalar division.
+func cdiv(xr, xi, yr, yi float64) (float64, float64) {
+	var r, d float64
+	if math.Abs(yr) > math.Abs(yi) {
+		r = yi / yr
+		d = yr + r*yi
+		return (xr + r*xi) / d, (xi - r*xr) / d
+	}
+	r = yr / yi
+	d = yi + r*yr
+	return (r*xr + xi) / d, (r*xi - xr) / d
+}
+
+// Nonsymmetric reduction from Hessenberg to real Schur form.
+//
+// This is derived from the Algol procedure hqr2, by Martin and Wilkinson,
+// Handbook for Auto. Comp., Vol.ii-Linear Algebra, and the corresponding
+// Fortran subroutine in EISPACK.
+func (d *DenseEigenvalueDecomposition) hqr2() {
+	H, V, dd, e := d.h, d.v, d.d, d.e
+
+	// Initialize
+	nn := d.n
+	n := nn - 1
+	low := 0
+	high := nn - 1
+	eps := math.Pow(2, -52)
+	exshift := 0.0
+	var p, q, r, s, z, t, w, x, y float64
+
+	// Store roots isolated by balanc and compute matrix norm
+	norm := 0.0
+	for i := 0; i < nn; i++ {
+		if i < low || i > high {
+			dd[i] = H[i][i]
+			e[i] = 0
+		}
+		j := i - 1
+		if j < 0 {
+			j = 0
+		}
+		for ; j < nn; j++ {
+			norm += math.Abs(H[i][j])
+		}
+	}
+
+	// Outer loop over eigenvalue index
+	iter := 0
+	for n >= low {
+		// Look for single small sub-diagonal element
+		l := n
+		for l > low {
+			s = math.Abs(H[l-1][l-1]) + math.Abs(H[l][l])
+			if s == 0 {
+				s = norm
+			}
+			if math.Abs(H[l][l-1]) < eps*s {
+				break
+			}
+			l--
+		}
+
+		// Check for convergence
+		if l == n {
+			// One root found
+			H[n][n] += exshift
+			dd[n] = H[n][n]
+			e[n] = 0
+			n--
+			iter = 0
+		} else if l == n-1 {
+			// Two roots found
+			w = H[n][n-1] * H[n-1][n]
+			p = (H[n-1][n-1] - H[n][n]) / 2
+			q = p*p + w
+			z = math.Sqrt(math.Abs(q))
+			H[n][n] += exshift
+			H[n-1][n-1] += exshift
+			x = H[n][n]
+
+			if q >= 0 {
+				// Real pair
+				if p >= 0 {
+					z = p + z
+				} else {
+					z = p - z
+				}
+				dd[n-1] = x + z
+				dd[n] = dd[n-1]
+				if z != 0 {
+					dd[n] = x - w/z
+				}
+				e[n-1] = 0
+				e[n] = 0
+				x = H[n][n-1]
+				s = math.Abs(x) + math.Abs(z)
+				p = x / s
+				q = z / s
+				r = math.Sqrt(p*p + q*q)
+				p /= r
+				q /= r
+
+				// Row modification
+				for j := n - 1; j < nn; j++ {
+					z = H[n-1][j]
+					H[n-1][j] = q*z + p*H[n][j]
+					H[n][j] = q*H[n][j] - p*z
+				}
+
+				// Column modification
+				for i := 0; i <= n; i++ {
+					z = H[i][n-1]
+					H[i][n-1] = q*z + p*H[i][n]
+					H[i][n] = q*H[i][n] - p*z
+				}
+
+				// Accumulate transformations
+				for i := low; i <= high; i++ {
+					z = V[i][n-1]
+					V[i][n-1] = q*z + p*V[i][n]
+					V[i][n] = q*V[i][n] - p*z
+				}
+			} else {
+				// Complex pair
+				dd[n-1] = x + p
+				dd[n] = x + p
+				e[n-1] = z
+				e[n] = -z
+			}
+			n -= 2
+			iter = 0
+		} else {
+			// No convergence yet
+
+			// Form shift
+			x = H[n][n]
+			y = 0
+			w = 0
+			if l < n {
+				y = H[n-1][n-1]
+				w = H[n][n-1] * H[n-1][n]
+			}
+
+			// Wilkinson's original ad hoc shift
+			if iter == 10 {
+				exshift += x
+				for i := low; i <= n; i++ {
+					H[i][i] -= x
+				}
+				s = math.Abs(H[n][n-1]) + math.Abs(H[n-1][n-2])
+				x = 0.75 * s
+				y = x
+				w = -0.4375 * s * s
+			}
+
+			// MATLAB's new ad hoc shift
+			if iter == 30 {
+				s = (y - x) / 2
+				s = s*s + w
+				if s > 0 {
+					s = math.Sqrt(s)
+					if y < x {
+						s = -s
+					}
+					s = x - w/((y-x)/2+s)
+					for i := low; i <= n; i++ {
+						H[i][i] -= s
+					}
+					exshift += s
+					x = 0.964
+					y = x
+					w = x
+				}
+			}
+
+			iter++
+
+			// Look for two consecutive small sub-diagonal elements
+			m := n - 2
+			for m >= l {
+				z = H[m][m]
+				r = x - z
+				s = y - z
+				p = (r*s-w)/H[m+1][m] + H[m][m+1]
+				q = H[m+1][m+1] - z - r - s
+				r = H[m+2][m+1]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				if math.Abs(H[m][m-1])*(math.Abs(q)+math.Abs(r)) <
+					eps*(math.Abs(p)*(math.Abs(H[m-1][m-1])+math.Abs(z)+math.Abs(H[m+1][m+1]))) {
+					break
+				}
+				m--
+			}
+
+			for i := m + 2; i <= n; i++ {
+				H[i][i-2] = 0
+				if i > m+2 {
+					H[i][i-3] = 0
+				}
+			}
+
+			// Double QR step involving rows l:n and columns m:n
+			for k := m; k <= n-1; k++ {
+				notlast := k != n-1
+				if k != m {
+					p = H[k][k-1]
+					q = H[k+1][k-1]
+					r = 0
+					if notlast {
+						r = H[k+2][k-1]
+					}
+					x = math.Abs(p) + math.Abs(q) + math.Abs(r)
+					if x == 0 {
+						continue
+					}
+					p /= x
+					q /= x
+					r /= x
+				}
+
+				s = math.Sqrt(p*p + q*q + r*r)
+				if p < 0 {
+					s = -s
+				}
+				if s != 0 {
+					if k != m {
+						H[k][k-1] = -s * x
+					} else if l != m {
+						H[k][k-1] = -H[k][k-1]
+					}
+					p += s
+					x = p / s
+					y = q / s
+					z = r / s
+					q /= p
+					r /= p
+
+					// Row modification
+					for j := k; j < nn; j++ {
+						p = H[k][j] + q*H[k+1][j]
+						if notlast {
+							p += r * H[k+2][j]
+							H[k+2][j] -= p * z
+						}
+						H[k][j] -= p * x
+						H[k+1][j] -= p * y
+					}
+
+					// Column modification
+					imax := k + 3
+					if n < imax {
+						imax = n
+					}
+					for i := 0; i <= imax; i++ {
+						p = x*H[i][k] + y*H[i][k+1]
+						if notlast {
+							p += z * H[i][k+2]
+							H[i][k+2] -= p * r
+						}
+						H[i][k] -= p
+						H[i][k+1] -= p * q
+					}
+
+					// Accumulate transformations
+					for i := low; i <= high; i++ {
+						p = x*V[i][k] + y*V[i][k+1]
+						if notlast {
+							p += z * V[i][k+2]
+							V[i][k+2] -= p * r
+						}
+						V[i][k] -= p
+						V[i][k+1] -= p * q
+					}
+				}
+			}
+		}
+	}
+
+	// Backsubstitute to find vectors of upper triangular form
+	if norm == 0 {
+		return
+	}
+
+	for n = nn - 1; n >= 0; n-- {
+		p = dd[n]
+		q = e[n]
+
+		if q == 0 {
+			// Real vector
+			l := n
+			H[n][n] = 1
+			for i := n - 1; i >= 0; i-- {
+				w = H[i][i] - p
+				r = 0
+				for j := l; j <= n; j++ {
+					r += H[i][j] * H[j][n]
+				}
+				if e[i] < 0 {
+					z = w
+					s = r
+				} else {
+					l = i
+					if e[i] == 0 {
+						if w != 0 {
+							H[i][n] = -r / w
+						} else {
+							H[i][n] = -r / (eps * norm)
+						}
+					} else {
+						// Solve real equations
+						x = H[i][i+1]
+						y = H[i+1][i]
+						q = (dd[i]-p)*(dd[i]-p) + e[i]*e[i]
+						t = (x*s - z*r) / q
+						H[i][n] = t
+						if math.Abs(x) > math.Abs(z) {
+							H[i+1][n] = (-r - w*t) / x
+						} else {
+							H[i+1][n] = (-s - y*t) / z
+						}
+					}
+
+					// Overflow control
+					t = math.Abs(H[i][n])
+					if (eps*t)*t > 1 {
+						for j := i; j <= n; j++ {
+							H[j][n] /= t
+						}
+					}
+				}
+			}
+		} else if q < 0 {
+			// Complex vector
+			l := n - 1
+
+			// Last vector component imaginary so matrix is triangular
+			if math.Abs(H[n][n-1]) > math.Abs(H[n-1][n]) {
+				H[n-1][n-1] = q / H[n][n-1]
+				H[n-1][n] = -(H[n][n] - p) / H[n][n-1]
+			} else {
+				H[n-1][n-1], H[n-1][n] = cdiv(0, -H[n-1][n], H[n-1][n-1]-p, q)
+			}
+			H[n][n-1] = 0
+			H[n][n] = 1
+			for i := n - 2; i >= 0; i-- {
+				ra := 0.0
+				sa := 0.0
+				for j := l; j <= n; j++ {
+					ra += H[i][j] * H[j][n-1]
+					sa += H[i][j] * H[j][n]
+				}
+				w = H[i][i] - p
+
+				if e[i] < 0 {
+					z = w
+					r = ra
+					s = sa
+				} else {
+					l = i
+					if e[i] == 0 {
+						H[i][n-1], H[i][n] = cdiv(-ra, -sa, w, q)
+					} else {
+						// Solve complex equations
+						x = H[i][i+1]
+						y = H[i+1][i]
+						vr := (dd[i]-p)*(dd[i]-p) + e[i]*e[i] - q*q
+						vi := (dd[i] - p) * 2 * q
+						if vr == 0 && vi == 0 {
+							vr = eps * norm * (math.Abs(w) + math.Abs(q) +
+								math.Abs(x) + math.Abs(y) + math.Abs(z))
+						}
+						H[i][n-1], H[i][n] = cdiv(x*r-z*ra+q*sa, x*s-z*sa-q*ra, vr, vi)
+						if math.Abs(x) > math.Abs(z)+math.Abs(q) {
+							H[i+1][n-1] = (-ra - w*H[i][n-1] + q*H[i][n]) / x
+							H[i+1][n] = (-sa - w*H[i][n] - q*H[i][n-1]) / x
+						} else {
+							H[i+1][n-1], H[i+1][n] = cdiv(-r-y*H[i][n-1], -s-y*H[i][n], z, q)
+						}
+					}
+
+					// Overflow control
+					t = math.Max(math.Abs(H[i][n-1]), math.Abs(H[i][n]))
+					if (eps*t)*t > 1 {
+						for j := i; j <= n; j++ {
+							H[j][n-1] /= t
+							H[j][n] /= t
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Vectors of isolated roots
+	for i := 0; i < nn; i++ {
+		if i < low || i > high {
+			for j := i; j < nn; j++ {
+				V[i][j] = H[i][j]
+			}
+		}
+	}
+
+	// Back transformation to get eigenvectors of original matrix
+	for j := nn - 1; j >= low; j-- {
+		for i := low; i <= high; i++ {
+			z = 0
+			kmax := j
+			if high < kmax {
+				kmax = high
+			}
+			for k := low; k <= kmax; k++ {
+				z += V[i][k] * H[k][j]
+			}
+			V[i][j] = z
+		}
+	}
+}
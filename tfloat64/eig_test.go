@@ -0,0 +1,131 @@
+package tfloat64
+
+import (
+	"math"
+	"testing"
+)
+
+func testEigenvalueDecomposition(t *testing.T, A *Matrix) {
+	eig, err := NewDenseEigenvalueDecomposition(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	V := eig.V()
+	D := eig.D()
+
+	// A*V == V*D
+	AV, _ := A.ZMultMatrix(V, nil)
+	VD, _ := V.ZMultMatrix(D, nil)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if math.Abs(AV.GetQuick(r, c)-VD.GetQuick(r, c)) > 1e-9 {
+				t.Errorf("expected:%g actual:%g", AV.GetQuick(r, c), VD.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func testEigenvalueDecompositionSymmetric(t *testing.T, A *Matrix) {
+	// Westlake (1968), p.150
+	A.AssignArray([][]float64{
+		{611, 196, -192, 407, -8, -52, -49, 29},
+		{196, 899, 113, -192, -71, -43, -8, -44},
+		{-192, 113, 899, 196, 61, 49, 8, 52},
+		{407, -192, 196, 611, 8, 44, 59, -23},
+		{-8, -71, 61, 8, 411, -599, 208, 208},
+		{-52, -43, 49, 44, -599, 411, 208, 208},
+		{-49, -8, 8, 59, 208, 208, 99, -911},
+		{29, -44, 52, -23, 208, 208, -911, 99},
+	})
+	eig, err := NewDenseEigenvalueDecomposition(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !eig.IsSymmetric() {
+		t.Errorf("expected symmetric")
+	}
+	a := math.Sqrt(10405)
+	b := math.Sqrt(26)
+	expected := []float64{-10 * a, 0, 510 - 100*b, 1000, 1000, 510 + 100*b, 1020, 10 * a}
+	re := eig.RealEigenvalues()
+	im := eig.ImagEigenvalues()
+	for i, e := range expected {
+		if math.Abs(e-re.GetQuick(i)) > 1e-9 {
+			t.Errorf("expected:%g actual:%g", e, re.GetQuick(i))
+		}
+		if im.GetQuick(i) != 0 {
+			t.Errorf("expected:%g actual:%g", 0.0, im.GetQuick(i))
+		}
+	}
+	testEigenvalueDecomposition(t, A)
+
+	// V is orthogonal.
+	V := eig.V()
+	VtV, _ := V.ZMultMatrixConst(V, nil, 1, 0, true, false)
+	for r := 0; r < VtV.Rows(); r++ {
+		for c := 0; c < VtV.Columns(); c++ {
+			expected := 0.0
+			if r == c {
+				expected = 1
+			}
+			if math.Abs(expected-VtV.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", expected, VtV.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func testEigenvalueDecompositionNonsymmetric(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{0, 1, 0, 0},
+		{1, 0, 2e-7, 0},
+		{0, -2e-7, 0, 1},
+		{0, 0, 1, 0},
+	})
+	testEigenvalueDecomposition(t, A)
+
+	// Rotation by 90 degrees has eigenvalues +i and -i.
+	R := &Matrix{A.Like(2, 2)}
+	R.AssignArray([][]float64{
+		{0, -1},
+		{1, 0},
+	})
+	eig, err := NewDenseEigenvalueDecomposition(R)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eig.IsSymmetric() {
+		t.Errorf("expected nonsymmetric")
+	}
+	re := eig.RealEigenvalues()
+	im := eig.ImagEigenvalues()
+	for i := 0; i < 2; i++ {
+		if math.Abs(re.GetQuick(i)) > tol {
+			t.Errorf("expected:%g actual:%g", 0.0, re.GetQuick(i))
+		}
+		if math.Abs(math.Abs(im.GetQuick(i))-1) > tol {
+			t.Errorf("expected:%g actual:%g", 1.0, math.Abs(im.GetQuick(i)))
+		}
+	}
+	testEigenvalueDecomposition(t, R)
+}
+
+func TestDenseEigenvalueDecomposition(t *testing.T) {
+	testEigenvalueDecomposition(t, makeSquareMatrix(NewMatrix(nsquare, nsquare)))
+}
+
+func TestDenseEigenvalueDecompositionSymmetric(t *testing.T) {
+	testEigenvalueDecompositionSymmetric(t, NewMatrix(8, 8))
+}
+
+func TestDenseEigenvalueDecompositionNonsymmetric(t *testing.T) {
+	testEigenvalueDecompositionNonsymmetric(t, NewMatrix(4, 4))
+}
+
+func TestSparseEigenvalueDecomposition(t *testing.T) {
+	testEigenvalueDecomposition(t, makeSquareMatrix(NewSparseMatrix(nsquare, nsquare)))
+}
+
+func TestSparseEigenvalueDecompositionSymmetric(t *testing.T) {
+	testEigenvalueDecompositionSymmetric(t, NewSparseMatrix(8, 8))
+}
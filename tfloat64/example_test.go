@@ -1,823 +1,708 @@
-
 package tfloat64_test
 
 import (
 	"fmt"
 	"math"
-	"time"
+
+	"github.com/rwl/goshawk/tfloat64"
+)
+
+var (
+	alg  = tfloat64.NewAlgebra(1e-9)
+	prop = alg.Property()
 )
 
-func ExampleBasic() {
+// Returns a rows x columns matrix with cells 0, 1, 2, ... in row major
+// order.
+func ascendingMatrix(rows, columns int) *tfloat64.Matrix {
+	A := tfloat64.NewMatrix(rows, columns)
+	i := 0.0
+	for r := 0; r < rows; r++ {
+		for c := 0; c < columns; c++ {
+			A.SetQuick(r, c, i)
+			i++
+		}
+	}
+	return A
+}
+
+func Example_basic() {
 	rows, columns := 4, 5
 
 	// Make a 4*5 matrix.
-	master := NewMatrix(rows, columns)
-	fmt.Println(master)
+	master := tfloat64.NewMatrix(rows, columns)
 
 	// Set all cells to 1.
 	master.Assign(1)
-	fmt.Println("\n" + master.String())
 
 	// Set [2,1] .. [3,3] to 2
-	master.ViewPart(2, 1, 2, 3).Assign(2)
-	fmt.Println("\n" + master)
+	part, _ := master.ViewPart(2, 1, 2, 3)
+	part.Assign(2)
+	fmt.Println(master.ToArray())
 
-	copyPart := master.ViewPart(2, 1, 2, 3).Copy()
 	// Modify an independent copy.
+	copyPart := part.Copy()
 	copyPart.Assign(3)
 	copyPart.Set(0, 0, 4)
-	fmt.Println("\n" + copyPart) // Has changed.
-	fmt.Println("\n" + master) // Master has not changed.
-
-	view1 := master.ViewPart(0, 3, 4, 2) // [0,3] .. [3,4]
-	view2 := view1.ViewPart(0, 0, 4, 1) // A view from a view.
-	fmt.Println("\n" + view1)
-	fmt.Println("\n" + view2)
+	fmt.Println(copyPart.ToArray()) // Has changed.
+	fmt.Println(master.ToArray())   // Master has not changed.
+
+	view1, _ := master.ViewPart(0, 3, 4, 2) // [0,3] .. [3,4]
+	view2, _ := view1.ViewPart(0, 0, 4, 1)  // A view from a view.
+	fmt.Println(view1.ToArray())
+	fmt.Println(view2.ToArray())
+	// Output:
+	// [[1 1 1 1 1] [1 1 1 1 1] [1 2 2 2 1] [1 2 2 2 1]]
+	// [[4 3 3] [3 3 3]]
+	// [[1 1 1 1 1] [1 1 1 1 1] [1 2 2 2 1] [1 2 2 2 1]]
+	// [[1 1] [1 1] [2 1] [2 1]]
+	// [[1] [1] [2] [2]]
 }
 
-func ExampleSet_sparse() {
+func ExampleNewSparseMatrix() {
 	rows, columns := 4, 5
-	matrix := NewSparseMatrix(rows, columns)
-	fmt.Println(matrix)
+	matrix := tfloat64.NewSparseMatrix(rows, columns)
 
 	// Add elements.
-	i := 0
+	i := 0.0
 	for column := 0; column < columns; column++ {
 		for row := 0; row < rows; row++ {
-			// if i%1000 == 0 {
 			matrix.Set(row, column, i)
-			// }
 			i++
 		}
 	}
-	fmt.Println(matrix)
+	fmt.Println(matrix.Cardinality())
 
 	// Remove elements.
 	for column := 0; column < columns; column++ {
 		for row := 0; row < rows; row++ {
-			// if i%1000 == 0 {
 			matrix.Set(row, column, 0)
-			// }
 		}
 	}
-
-	fmt.Println(matrix)
+	fmt.Println(matrix.Cardinality())
+	// Output:
+	// 19
+	// 0
 }
 
-func ExampleView() {
-	rows, columns := 6, 7
-
-	// Make a 6*7 matrix
-	master := AscendingMatrix(rows, columns)
-	master.AssignFunc(Multiply(math.Sin(0.3)))
-	fmt.Println("\n" + master)
-
-	// Set [2,1] .. [3,3] to 2
-	// master.ViewPart(2,0,2,3).Assign(2)
-	// fmt.Println("\n"+master)
-
-	rowIndexes := []int{ 0, 1, 2, 3 }
-	columnIndexes := []int{ 0, 1, 2, 3 }
-
-	rowIndexes2 := []int{ 3, 0, 3 }
-	columnIndexes2 := []int{ 3, 0, 3 }
-	
-	view1 = master.ViewPart(1, 1, 4, 5).ViewSelection(rowIndexes, columnIndexes)
-	fmt.Println("\nview1=" + view1.String())
-
-	view9 := view1.ViewStrides(2, 2).ViewStrides(2, 1)
-	fmt.Println("\nview9=" + view9.String())
-
-	view1 = view1.ViewSelection(rowIndexes2, columnIndexes2)
-	fmt.Println("\nview1=" + view1.String())
-
-	view2 = view1.ViewPart(1, 1, 2, 2)
-	fmt.Println("\nview2=" + view2.String())
-
-	view3 = view2.ViewRowFlip()
-	fmt.Println("\nview3=" + view3.String())
-
-	view3.Assign(AscendingMatrix(view3.Rows(), view3.Columns()))
-	fmt.Println("\nview3=" + view3.String())
-
-	// view2.Assign(-1)
-	fmt.Println("\nmaster replaced" + master.String())
-	fmt.Println("\nview1 replaced" + view1.String())
-	fmt.Println("\nview2 replaced" + view2.String())
-	fmt.Println("\nview3 replaced" + view3.String())
+func ExampleMatrix_ViewStrides() {
+	master := ascendingMatrix(6, 7)
+
+	rowIndexes := []int{0, 1, 2, 3}
+	columnIndexes := []int{0, 1, 2, 3}
+	part, _ := master.ViewPart(1, 1, 4, 5)
+	view1, _ := part.ViewSelection(rowIndexes, columnIndexes)
+	fmt.Println(view1.ToArray())
+
+	strided, _ := view1.ViewStrides(2, 2)
+	view9, _ := strided.ViewStrides(2, 1)
+	fmt.Println(view9.ToArray())
+
+	view1, _ = view1.ViewSelection([]int{3, 0, 3}, []int{3, 0, 3})
+	fmt.Println(view1.ToArray())
+
+	view2, _ := view1.ViewPart(1, 1, 2, 2)
+	view3 := view2.ViewRowFlip()
+	fmt.Println(view3.ToArray())
+	// Output:
+	// [[8 9 10 11] [15 16 17 18] [22 23 24 25] [29 30 31 32]]
+	// [[8 10]]
+	// [[32 29 32] [11 8 11] [32 29 32]]
+	// [[29 32] [8 11]]
 }
 
-func ExampleViewSelection() {
-	rows, columns = 4, 5
-
-	// make a 1*1 matrix
-	master := NewMatrix(1, 1)
+func ExampleMatrix_ViewSelection_repeated() {
+	// Make a 1*1 matrix.
+	master := tfloat64.NewMatrix(1, 1)
 	master.Assign(2)
-	fmt.Println("\n" + master.String())
-
-	rowIndexes := make([]int, rows)
-	columnIndexes := make([]int, columns)
 
-	view1 := master.ViewSelection(rowIndexes, columnIndexes)
-	fmt.Println(view1)
+	rowIndexes := make([]int, 4)
+	columnIndexes := make([]int, 5)
+	view1, _ := master.ViewSelection(rowIndexes, columnIndexes)
+	fmt.Println(view1.ToArray())
 
 	master.Assign(1)
-	fmt.Println("\n" + master.String())
-	fmt.Println(view1)
+	fmt.Println(view1.ToArray())
+	// Output:
+	// [[2 2 2 2 2] [2 2 2 2 2] [2 2 2 2 2] [2 2 2 2 2]]
+	// [[1 1 1 1 1] [1 1 1 1 1] [1 1 1 1 1] [1 1 1 1 1]]
 }
 
-func ExampleFactory() {
-	var A, B, C, D, E, F, G, H, I, J *Matrix
-	A = MakeMatrix(2, 3, 9.0)
-	B = MakeMatrix(4, 3, 8.0)
-	C = AppendRows(A, B)
-	fmt.Println("\nA=" + A)
-	fmt.Println("\nB=" + B)
-	fmt.Println("\nC=" + C)
-	D = MakeMatrix(3, 2, 7)
-	E = MakeMatrix(3, 4, 6)
-	F = AppendColumns(D, E)
-	fmt.Println("\nD=" + D)
-	fmt.Println("\nE=" + E)
-	fmt.Println("\nF=" + F)
-	G = AppendRows(C, F)
-	fmt.Println("\nG=" + G)
-	H = AscendingMatrix(2, 3)
-	fmt.Println("\nH=" + H)
-	I = RepeatMatrix(H, 2, 3)
-	fmt.Println("\nI=" + I)
+func Example_factory() {
+	A := tfloat64.NewVectorInitial(2, 9)
+	B := tfloat64.NewVectorInitial(4, 8)
+	C := tfloat64.AppendVectors(A, B)
+	fmt.Println(C.ToArray())
+
+	H := tfloat64.Ascending(3)
+	I := tfloat64.RepeatVector(H, 2)
+	fmt.Println(I.ToArray())
+	// Output:
+	// [9 9 8 8 8 8]
+	// [0 1 2 0 1 2]
 }
 
-func ExampleAggregate() {
-	values := []float64{0, 1, 2, 3}
-	matrix := NewMatrixArray(values)
-	fmt.Println(matrix)
+func ExampleVector_Aggregate() {
+	vector := tfloat64.NewVectorArray([]float64{0, 1, 2, 3})
 
 	// Sum( x[i]*x[i] )
-	fmt.Println(matrix.ViewSelectionFunc(func (a float64) bool {
-		return a % 2 == 0
-	})
-	// --> 14
-
-	// Sum( x[i]*x[i] )
-	fmt.Println(matrix.Aggregate(Plus, Square))
-	// --> 14
+	fmt.Println(vector.Aggregate(tfloat64.Plus, tfloat64.Square))
 
 	// Sum( x[i]*x[i]*x[i] )
-	fmt.Println(matrix.Aggregate(Plus, Pow(3)))
-	// --> 36
+	fmt.Println(vector.Aggregate(tfloat64.Plus, tfloat64.Pow(3)))
 
 	// Sum( x[i] )
-	fmt.Println(matrix.Aggregate(Plus, Identity))
-	// --> 6
+	fmt.Println(vector.Aggregate(tfloat64.Plus, tfloat64.Identity))
 
 	// Min( x[i] )
-	fmt.Println(matrix.Aggregate(math.Min, Identity))
-	// --> 0
+	fmt.Println(vector.Aggregate(math.Min, tfloat64.Identity))
 
 	// Max( Sqrt(x[i]) / 2 )
-	fmt.Println(matrix.Aggregate(math.Max, Chain(Div(2), Sqrt)))
-	// --> 0.8660254037844386
+	fmt.Println(vector.Aggregate(math.Max, tfloat64.ChainUnary(tfloat64.Divide(2), math.Sqrt)))
 
 	// Number of all cells with 0 <= value <= 2
-	fmt.Println(matrix.Aggregate(Plus, Between(0, 2)))
-	// --> 3
-
-	// Number of all cells with 0.8 <= Log2(value) <= 1.2
-	fmt.Println(matrix.Aggregate(Plus, ChainBinary(Between(0.8, 1.2), Log2)))
-	// --> 1
+	fmt.Println(vector.Aggregate(tfloat64.Plus, tfloat64.Between(0, 2)))
 
 	// Product( x[i] )
-	fmt.Println(matrix.Aggregate(Mult, Identity))
-	// --> 0
+	fmt.Println(vector.Aggregate(tfloat64.Mult, tfloat64.Identity))
 
 	// Product( x[i] ) of all x[i] > limit
 	limit := 1.0
-	f := func(a float64) float64 {
+	fmt.Println(vector.Aggregate(tfloat64.Mult, func(a float64) float64 {
 		if a > limit {
 			return a
-		} else {
-			return 1
 		}
-	}
-	fmt.Println(matrix.Aggregate(Mult, f))
-	// --> 6
-
-	// Sum( (x[i]+y[i])^2 )
-	otherMatrix1D := matrix.Copy()
-	fmt.Println(matrix.Aggregate(otherMatrix1D, Plus, ChainBinary(Square, Plus)))
-	// --> 56
-
-	matrix.Assign(Plus(1))
-	otherMatrix1D = matrix.Copy()
-	// otherMatrix1D.ZMult(3)
-	fmt.Println(matrix)
-	fmt.Println(otherMatrix1D)
-
-	// Sum(Math.PI * Math.log(otherMatrix1D[i] / matrix[i]))
-	fmt.Println(matrix.Aggregate(otherMatrix1D, Plus, ChainBinary(Multiply(math.PI), ChainBinary(Log, SwapArgs(Div)))))
-
-	// or, perhaps less error prone and more readable:
-	fmt.Println(matrix.Aggregate(otherMatrix1D, Plus, func(a, b float64) float64 {
-		return math.PI * math.Log(b/a)
+		return 1
 	}))
 
-	x := AscendingCube(2, 2, 2)
-	fmt.Println(x)
-
-	// Sum( x[slice,row,col]*x[slice,row,col] )
-	fmt.Println(x.Aggregate(Plus, Square))
-	// --> 140
-
-	y := x.Copy()
 	// Sum( (x[i]+y[i])^2 )
-	fmt.Println(x.Aggregate(y, Plus, ChainBinary(Square, Plus)))
-	// --> 560
-
-	fmt.Println(matrix.Assign(Random()))
-	fmt.Println(matrix.Assign(random.NewPoisson(5, random.MakeDefaultPoissonGenerator())))
+	other := vector.Copy()
+	s, _ := vector.AggregateVector(other, tfloat64.Plus, tfloat64.ChainBinary(tfloat64.Square, tfloat64.Plus))
+	fmt.Println(s)
+	// Output:
+	// 14
+	// 36
+	// 6
+	// 0
+	// 0.8660254037844386
+	// 3
+	// 0
+	// 6
+	// 56
 }
 
-func ExampleMatrixMult() {
-	var r1, c, r2 int
-	
-	values := []float64{ 0, 1, 2, 3 }
-	a = AscendingMatrix(r1, c)
-	b = AscendingMatrix(c, r2).Assign(Multiply(-1))
-	
-	// fmt.Println(a)
-	// fmt.Println(b)
-	//a.assign(0)
-	//b.assign(0)
-	
-	//now := time.Now()
-	linalg.Mult(a, b)
-	//time.Time() - now
-}
+func ExampleAlgebra_Mult() {
+	a := ascendingMatrix(2, 3)
+	b := ascendingMatrix(3, 2)
+	b.AssignFunc(tfloat64.Multiply(-1))
 
-func ExampleInverse() {
-	size, runs := 6, 1
-	values := [][]float64{
-		[]float64{ 0, 5, 9 },
-		[]float64{ 2, 6, 10 },
-		[]float64{ 3, 7, 11 },
-	}
+	c, _ := alg.Mult(a, b)
+	fmt.Println(c.ToArray())
+	// Output:
+	// [[-10 -13] [-28 -40]]
+}
 
-	// A := MakeMatrixArray(values)
-	A := MakeMatrix(size, size)
+func ExampleAlgebra_Inverse() {
+	size := 6
+	A := tfloat64.NewMatrix(size, size)
 	value := 5.0
-	for i := size0; i < size; i++ {
+	for i := 0; i < size; i++ {
 		A.SetQuick(i, i, value)
 	}
-	A.ViewRow(0).Assign(value)
+	row, _ := A.ViewRow(0)
+	row.Assign(value)
 
-	// A := MakeIdentity(size, size)
+	inv, _ := alg.Inverse(A)
+	fmt.Println(inv.ToArray()[0])
+	// Output:
+	// [0.2 -0.2 -0.2 -0.2 -0.2 -0.2]
+}
 
-	// A := MakeAscendingMatrix(size, size).Assign(NewMersenneTwister())
-	now := time.Now()
-	var inv *Matrix
-	for run = 0; run < runs; run++ {
-		inv = linalg.Inverse(A)
+func Example_diag() {
+	A := ascendingMatrix(3, 4)
+	B := ascendingMatrix(2, 3)
+	C := ascendingMatrix(1, 2)
+	B.AssignFunc(tfloat64.Add(A.ZSum()))
+	C.AssignFunc(tfloat64.Add(B.ZSum()))
+
+	// Place A, B and C along the diagonal of a block matrix.
+	D := tfloat64.NewMatrix(6, 9)
+	row, column := 0, 0
+	for _, block := range []*tfloat64.Matrix{A, B, C} {
+		part, _ := D.ViewPart(row, column, block.Rows(), block.Columns())
+		part.AssignMatrix(block)
+		row += block.Rows()
+		column += block.Columns()
+	}
+	for _, r := range D.ToArray() {
+		fmt.Println(r)
 	}
-	time.Now() - now
+	// Output:
+	// [0 1 2 3 0 0 0 0 0]
+	// [4 5 6 7 0 0 0 0 0]
+	// [8 9 10 11 0 0 0 0 0]
+	// [0 0 0 0 66 67 68 0 0]
+	// [0 0 0 0 69 70 71 0 0]
+	// [0 0 0 0 0 0 0 411 412]
 }
 
-func ExampleDiag() {
-	A := AscendingMatrix(3, 4)
-	B := AscendingMatrix(2, 3)
-	C := AscendingMatrix(1, 2)
-	B.Assign(F.Plus(A.ZSum()))
-	C.Assign(F.Plus(B.zSum()))
-
-	fmt.Println("\n"+A)
-	fmt.Println("\n"+B)
-	fmt.Println("\n"+C)
-	fmt.Println("\n"+Diag(A,B,C))
+func ExampleProperty_SemiBandwidth() {
+	A := tfloat64.NewMatrix(4, 4)
+	A.AssignArray([][]float64{
+		{1, 1, 0, 0},
+		{1, 1, 1, 0},
+		{0, 1, 1, 1},
+		{0, 0, 1, 1},
+	})
+	fmt.Println(prop.UpperBandwidth(A))
+	fmt.Println(prop.LowerBandwidth(A))
+	fmt.Println(prop.SemiBandwidth(A))
+	// Output:
+	// 1
+	// 1
+	// 2
 }
 
-func ExampleBandwidth() {
-	var A *Matrix
-	var k, uk, lk int
-
-	values5 := [][]float64{
-		[]float64{ 0, 0, 0, 0 },
-		[]float64{ 0, 0, 0, 0 },
-		[]float64{ 0, 0, 0, 0 },
-		[]float64{ 0, 0, 0, 0 },
-	}
-	A = MakeMatrixValues(values5)
-	k = prop.SemiBandwidth(A)
-	uk = prop.UpperBandwidth(A)
-	lk = prop.LowerBandwidth(A)
-
-	fmt.Println("\n\nupperBandwidth=" + uk)
-	fmt.Println("lowerBandwidth=" + lk)
-	fmt.Println("bandwidth=" + k + " " + A)
-
-	values4 := [][]float64{
-		[]float64{ 1, 0, 0, 0 },
-		[]float64{ 0, 0, 0, 0 },
-		[]float64{ 0, 0, 0, 0 },
-		[]float64{ 0, 0, 0, 1 },
-	}
-	A = MakeMatrixValues(values4)
-	k = prop.SemiBandwidth(A)
-	uk = prop.UpperBandwidth(A)
-	lk = prop.LowerBandwidth(A)
-	fmt.Println("\n\nupperBandwidth=" + uk)
-	fmt.Println("lowerBandwidth=" + lk)
-	fmt.Println("bandwidth=" + k + " " + A)
-
-	values1 := [][]float64{
-		[]float64{ 1, 1, 0, 0 },
-		[]float64{ 1, 1, 1, 0 },
-		[]float64{ 0, 1, 1, 1 },
-		[]float64{ 0, 0, 1, 1 },
-	}
-	A = MakeMatrixValues(values1)
-	k = prop.SemiBandwidth(A)
-	uk = prop.UpperBandwidth(A)
-	lk = prop.LowerBandwidth(A)
-	fmt.Println("\n\nupperBandwidth=" + uk)
-	fmt.Println("lowerBandwidth=" + lk)
-	fmt.Println("bandwidth=" + k + " " + A)
-
-	values6 := [][]float64{
-		[]float64{ 0, 1, 1, 1 },
-		[]float64{ 0, 1, 1, 1 },
-		[]float64{ 0, 0, 0, 1 },
-		[]float64{ 0, 0, 0, 1 },
-	}
-	A = MakeMatrixValues(values6)
-	k = prop.SemiBandwidth(A)
-	uk = prop.UpperBandwidth(A)
-	lk = prop.LowerBandwidth(A)
-	fmt.Println("\n\nupperBandwidth=" + uk)
-	fmt.Println("lowerBandwidth=" + lk)
-	fmt.Println("bandwidth=" + k + " " + A)
-
-	values7 := [][]float64{
-		[]float64{ 0, 0, 0, 0 },
-		[]float64{ 1, 1, 0, 0 },
-		[]float64{ 1, 1, 0, 0 },
-		[]float64{ 1, 1, 1, 1 },
-	}
-	A = MakeMatrixValues(values7)
-	k = prop.SemiBandwidth(A)
-	uk = prop.UpperBandwidth(A)
-	lk = prop.LowerBandwidth(A)
-	fmt.Println("\n\nupperBandwidth=" + uk)
-	fmt.Println("lowerBandwidth=" + lk)
-	fmt.Println("bandwidth=" + k + " " + A)
-
-	values2 := [][]float64{
-		[]float64{ 1, 1, 0, 0 },
-		[]float64{ 0, 1, 1, 0 },
-		[]float64{ 0, 1, 0, 1 },
-		[]float64{ 1, 0, 1, 1 },
-	}
-	A = MakeMatrixValues(values2)
-	k = prop.SemiBandwidth(A)
-	uk = prop.UpperBandwidth(A)
-	lk = prop.LowerBandwidth(A)
-	fmt.Println("\n\nupperBandwidth=" + uk)
-	fmt.Println("lowerBandwidth=" + lk)
-	fmt.Println("bandwidth=" + k + " " + A)
-
-	values3 := [][]float64{
-		[]float64{ 1, 1, 1, 0 },
-		[]float64{ 0, 1, 0, 0 },
-		[]float64{ 1, 1, 0, 1 },
-		[]float64{ 0, 0, 1, 1 },
-	}
-	A = MakeMatrixValues(values3)
-	k = prop.SemiBandwidth(A)
-	uk = prop.UpperBandwidth(A)
-	lk = prop.LowerBandwidth(A)
-	fmt.Println("\n\nupperBandwidth=" + uk)
-	fmt.Println("lowerBandwidth=" + lk)
-	fmt.Println("bandwidth=" + k + " " + A)
+func ExampleAlgebra_VerboseString() {
+	A := tfloat64.NewMatrix(2, 2)
+	A.AssignArray([][]float64{
+		{2, 1},
+		{1, 2},
+	})
+	fmt.Print(alg.VerboseString(A))
+	// Output:
+	// A = 2 x 2 matrix
+	// 2 1
+	// 1 2
+	//
+	// Properties:
+	// rows                        : 2
+	// columns                     : 2
+	// density                     : 1
+	// isSquare                    : true
+	// isDiagonal                  : false
+	// isDiagonallyDominantByColumn: true
+	// isDiagonallyDominantByRow   : true
+	// isIdentity                  : false
+	// isLowerTriangular           : false
+	// isOrthogonal                : false
+	// isSkewSymmetric             : false
+	// isSymmetric                 : true
+	// isTridiagonal               : true
+	// isUpperTriangular           : false
+	// lowerBandwidth              : 1
+	// semiBandwidth               : 2
+	// upperBandwidth              : 1
+	//
+	// Summary of decompositions and norms:
+	// cond                        : 3
+	// det                         : 3
+	// norm1                       : 3
+	// norm2                       : 3
+	// normF                       : 3.16228
+	// normInfinity                : 3
+	// rank                        : 2
+	// trace                       : 4
+	//
+	// LU decomposition:
+	// isNonSingular               : true
+	// pivot                       : [0 1]
+	// L                           : 2 x 2 matrix
+	// 1   0
+	// 0.5 1
+	//
+	// U                           : 2 x 2 matrix
+	// 2 1
+	// 0 1.5
+	//
+	// inverse(A)                  : 2 x 2 matrix
+	//  0.666667 -0.333333
+	// -0.333333  0.666667
+	//
+	// QR decomposition:
+	// isFullRank                  : true
+	// Q                           : 2 x 2 matrix
+	// -0.894427  0.447214
+	// -0.447214 -0.894427
+	//
+	// R                           : 2 x 2 matrix
+	// -2.23607 -1.78885
+	//  0       -1.34164
+	//
+	// Cholesky decomposition:
+	// isSPD                       : true
+	// L                           : 2 x 2 matrix
+	// 1.41421  0
+	// 0.707107 1.22474
+	//
+	// Eigenvalue decomposition:
+	// realEigenvalues             : 2 vector
+	// 1 3
+	//
+	// imagEigenvalues             : 2 vector
+	// 0 0
+	//
+	// D                           : 2 x 2 matrix
+	// 1 0
+	// 0 3
+	//
+	// V                           : 2 x 2 matrix
+	//  0.707107 0.707107
+	// -0.707107 0.707107
+	//
+	// Singular value decomposition:
+	// S                           : 2 vector
+	// 3 1
+	//
+	// U                           : 2 x 2 matrix
+	// 0.707107  0.707107
+	// 0.707107 -0.707107
+	//
+	// V                           : 2 x 2 matrix
+	// 0.707107  0.707107
+	// 0.707107 -0.707107
+	//
+	// pseudoInverse(A)            : 2 x 2 matrix
+	//  0.666667 -0.333333
+	// -0.333333  0.666667
 }
 
-func ExampleVerboseString() {
-	var A *Matrix
-	var k, uk, lk int
+func ExampleDenseEigenvalueDecomposition() {
+	A := tfloat64.NewMatrix(8, 8)
+	A.AssignArray([][]float64{
+		{611, 196, -192, 407, -8, -52, -49, 29},
+		{196, 899, 113, -192, -71, -43, -8, -44},
+		{-192, 113, 899, 196, 61, 49, 8, 52},
+		{407, -192, 196, 611, 8, 44, 59, -23},
+		{-8, -71, 61, 8, 411, -599, 208, 208},
+		{-52, -43, 49, 44, -599, 411, 208, 208},
+		{-49, -8, 8, 59, 208, 208, 99, -911},
+		{29, -44, 52, -23, 208, 208, -911, 99},
+	})
+	eig, _ := tfloat64.NewDenseEigenvalueDecomposition(A)
 
-	values1 := [][]float64{
-		[]float64{ 0, 1, 0, 0 },
-		[]float64{ 3, 0, 2, 0 },
-		[]float64{ 0, 2, 0, 3 },
-		[]float64{ 0, 0, 1, 0 },
-	}
-	A = MakeMatrixValues(values1)
-	
-	fmt.Println("\n\n" + linalg.VerboseString(A))
-	
-	values2 := [][]float64{
-		[]float64{ 1.0000000000000167, -0.3623577544766736, -0.3623577544766736 },
-		[]float64{ 0, 0.9320390859672374, -0.3377315902755755 },
-		[]float64{ 0, 0, 0.8686968577706282 },
-		[]float64{ 0, 0, 0 },
-		[]float64{ 0, 0, 0 },
-	}
-	
-	A = MakeMatrixValues(values2)
-	
-	fmt.Println("\n\n" + linalg.VerboseString(A))
-	
-	values3 := [][]float64{
-		[]float64{ 611, 196, -192, 407, -8, -52, -49, 29 },
-		[]float64{ 196, 899, 113, -192, -71, -43, -8, -44 },
-		[]float64{ -192, 113, 899, 196, 61, 49, 8, 52 },
-		[]float64{ 407, -192, 196, 611, 8, 44, 59, -23 },
-		[]float64{ -8, -71, 61, 8, 411, -599, 208, 208 },
-		[]float64{ -52, -43, 49, 44, -599, 411, 208, 208 },
-		[]float64{ -49, -8, 8, 59, 208, 208, 99, -911 },
-		[]float64{ 29, -44, 52, -23, 208, 208, -911, 99 },
-	}
-	A = MakeMatrixValues(values3)
-	
-	fmt.Println("\n\n" + linalg.VerboseString(A))
-	
-	// Exact eigenvalues from Westlake (1968), p.150 (ei'vectors given too):
+	// Exact eigenvalues from Westlake (1968), p.150.
 	a := math.Sqrt(10405)
 	b := math.Sqrt(26)
-	e := []float64{ -10 * a, 0, 510 - 100 * b, 1000, 1000, 510 + 100 * b, 1020, 10 * a }
-	fmt.Println(MakeVectorValues(e))
+	e := []float64{-10 * a, 0, 510 - 100*b, 1000, 1000, 510 + 100*b, 1020, 10 * a}
+	for i, d := range eig.RealEigenvalues().ToArray() {
+		fmt.Printf("%10.4f %t\n", e[i], math.Abs(d-e[i]) < 1e-9)
+	}
+	// Output:
+	// -1020.0490 true
+	//     0.0000 true
+	//     0.0980 true
+	//  1000.0000 true
+	//  1000.0000 true
+	//  1019.9020 true
+	//  1020.0000 true
+	//  1020.0490 true
 }
 
 func ExampleFormatter() {
-	values1 := [][]float64{
-		[]float64{ 1 / 3, 2 / 3, math.PI, 0 },
-		[]float64{ 3, 9, 0, 0 },
-		[]float64{ 0, 2, 7, 0 },
-		[]float64{ 0, 0, 3, 9 },
-	}
-	A := MakeMatrixValues(values1)
-	fmt.Println(A);
-	fmt.Println(NewFormatter().StringMatrix(A))
+	A := tfloat64.NewMatrix(4, 4)
+	A.AssignArray([][]float64{
+		{1.0 / 3, 2.0 / 3, math.Pi, 0},
+		{3, 9, 0, 0},
+		{0, 2, 7, 0},
+		{0, 0, 3, 9},
+	})
+	fmt.Println(A)
+	fmt.Println(tfloat64.NewFormatterFormat("%.4G").MatrixToString(A))
+	// Output:
+	// 4 x 4 matrix
+	// 0.3333333333333333 0.6666666666666666 3.141592653589793 0
+	// 3                  9                  0                 0
+	// 0                  2                  7                 0
+	// 0                  0                  3                 9
+	// 4 x 4 matrix
+	// 0.3333 0.6667 3.142 0
+	// 3      9      0     0
+	// 0      2      7     0
+	// 0      0      3     9
 }
 
-func ExampleDiagonallyDominant() {
-	values1 := [][]float64{
-		[]float64{ 1 / 3, 2 / 3, math.PI, 0 },
-		[]float64{ 3, 9, 0, 0 },
-		[]float64{ 0, 2, 7, 0 },
-		[]float64{ 0, 0, 3, 9 },
-	}
-	A := MakeMatrixValues(values1)
-	fmt.Println(A)
+func ExampleProperty_IsDiagonallyDominantByRow() {
+	A := tfloat64.NewMatrix(4, 4)
+	A.AssignArray([][]float64{
+		{1.0 / 3, 2.0 / 3, math.Pi, 0},
+		{3, 9, 0, 0},
+		{0, 2, 7, 0},
+		{0, 0, 3, 9},
+	})
 	fmt.Println(prop.IsDiagonallyDominantByRow(A))
 	fmt.Println(prop.IsDiagonallyDominantByColumn(A))
 	prop.GenerateNonSingular(A)
-	fmt.Println(A)
 	fmt.Println(prop.IsDiagonallyDominantByRow(A))
 	fmt.Println(prop.IsDiagonallyDominantByColumn(A))
+	// Output:
+	// false
+	// false
+	// true
+	// true
 }
 
-func ExampleLUDecomposition() {
-	var runs, size int
-	var nonZeroFraction float64
-	var dense bool
-
-	// Initialize
-	var A, LU, I, Inv *Matrix
-	var b, solved *Vector
-
-	mean := 5.0
-	stdDev := 3.0
-	random := random.NewNormal(mean, stdDev, random.NewMersenneTwister())
-
-	// Sample
-	value := 2.0
-	if dense {
-		A = SampleDenseMatrix(size, size, value, nonZeroFraction)
-	} else {
-		A = SampleSparseMatrix(size, size, value, nonZeroFraction)
-	}
-	b = A.Like1D(size).Assign(1)
-
-	// A.Assign(random)
-	// A.AssignFunc(Rint) // Round off.
-	// Generate invertible matrix.
-	prop.GenerateNonSingular(A)
-
-	// I = Identity(size)
-
-	LU = A.Like()
-	solved = b.Like()
-	// Inv = MakeMatrix(size, size)
-
-	lu := NewDenseLUDecompositionQuick()
-
-	// Benchmarking assignment.
-	now := time.Now()
-	LU.Assign(A)
-	solved.Assign(b)
-	time.now() - now
+func ExampleDenseLUDecompositionQuick() {
+	A := tfloat64.NewMatrix(3, 3)
+	A.AssignArray([][]float64{
+		{4, 1, 0},
+		{1, 4, 1},
+		{0, 1, 4},
+	})
+	b := tfloat64.NewVectorArray([]float64{5, 6, 5})
 
-	LU.Assign(A)
+	lu := tfloat64.NewDenseLUDecompositionQuick()
+	LU := A.Copy()
 	lu.Decompose(LU)
-
-	// Benchmarking LU.
-	now = time.Now()
-	for i = 0; i < runs; i++ {
-		solved.Assign(b)
-		// Inv.Assign(I)
-		// lu.Decompose(LU)
-		lu.Solve(solved)
-		// lu.Solve(Inv)
-	}
-	time.Now() - now
-
-	// fmt.Println("A="+A)
-	// fmt.Println("LU="+LU)
-	// fmt.Println("U=" +lu.U())
-	// fmt.Println("L="+lu.L())
+	lu.Solve(b)
+	fmt.Println(b.ToArray())
+	// Output:
+	// [1 1 1]
 }
 
-func ExampleStencil() {
-	var runs, size int
-	var dense bool
-	var A *Matrix
+func Example_stencil() {
+	size := 4
 
 	// Initialize.
 	value := 2.0
 	omega := 1.25
 	alpha := omega * 0.25
 	beta := 1 - omega
-	if dense {
-		A = MakeMatrix(size, size, value)
-	} else {
-		A = MakeSparseMatrix(size, size, value)
-	}
-
-	f := func(_, a01, _, a10, a11, a12, _, a21, _ float64) float64 {
-		return alpha * a11 + beta * (a01 + a10 + a12 + a21)
-	}
-	now = time.Now()
-
-	// Benchmark stencil.
-	for i := 0; i < runs; i++ {
-		A.ZAssign8Neighbors(A, f)
+	A := tfloat64.NewMatrix(size, size)
+	A.Assign(value)
+
+	// Apply a 5 point stencil to the inner cells.
+	B := A.Copy()
+	for r := 1; r < size-1; r++ {
+		for c := 1; c < size-1; c++ {
+			B.SetQuick(r, c, alpha*A.GetQuick(r, c)+beta*(A.GetQuick(r-1, c)+
+				A.GetQuick(r, c-1)+A.GetQuick(r, c+1)+A.GetQuick(r+1, c)))
+		}
 	}
-	// A.ZSum4Neighbors(A, alpha, beta, runs)
-	time.Now() - now
-	// fmt.Println("A="+A)
+	fmt.Println(B.ToArray())
+	// Output:
+	// [[2 2 2 2] [2 -1.375 -1.375 2] [2 -1.375 -1.375 2] [2 2 2 2]]
 }
 
-func ExampleInverse() {
-	var size int
-
-	// Initialize.
-	dense := true
-	var A *Matrix
-
-	value := 0.5
-	if dense {
-		A = MakeDenseMatrix(size, size, value)
-	} else {
-		A = MakeSparseMatrix(size, size, value)
-	}
+func ExampleAlgebra_Inverse_nonSingular() {
+	A := tfloat64.NewMatrix(3, 3)
+	A.Assign(0.5)
 	prop.GenerateNonSingular(A)
-	now = time.Now()
 
-	fmt.Println(A)
-	fmt.Println(alg.Inverse(A))
-	time.Now() - now
+	inv, _ := alg.Inverse(A)
+	I, _ := alg.Mult(A, inv)
+	fmt.Println(prop.IsIdentity(I))
+	// Output:
+	// true
 }
 
-func ExamplePattern() {
-	rows := 51
-	columns := 10
-	trainingSet := make([][]float64, columns, rows)
-	for i = 0; i < columns; i++ {
-		trainingSet[i][i] = 2.0
-	}
-
-	patternIndex := 0
-	unitIndex := 0
-
-	var patternMatrix *Matrix
-	var transposeMatrix *Matrix
-	var QMatrix *Matrix
-	var inverseQMatrix *Matrix
-	var pseudoInverseMatrix *Matrix
-	var weightMatrix *Matrix
+func Example_pseudoInverse() {
+	rows, columns := 5, 3
 
 	// Form a matrix with the columns as training vectors.
-	patternMatrix := NewMatrix(rows, columns)
-
-	// Copy the patterns into the matrix.
-	for patternIndex := 0; patternIndex < columns; patternIndex++ {
-		for unitIndex = 0; unitIndex < rows; unitIndex++ {
-			patternMatrix.SetQuick(unitIndex, patternIndex, trainingSet[patternIndex][unitIndex])
-		}
+	patternMatrix := tfloat64.NewMatrix(rows, columns)
+	for i := 0; i < columns; i++ {
+		patternMatrix.SetQuick(i, i, 2)
 	}
 
-	transposeMatrix = alg.Transpose(patternMatrix)
-	QMatrix = alg.Mult(transposeMatrix, patternMatrix)
-	inverseQMatrix = alg.Inverse(QMatrix)
-	pseudoInverseMatrix = alg.Mult(inverseQMatrix, transposeMatrix)
-	weightMatrix = alg.Mult(patternMatrix, pseudoInverseMatrix)
+	transposeMatrix := alg.Transpose(patternMatrix)
+	QMatrix, _ := alg.Mult(transposeMatrix, patternMatrix)
+	inverseQMatrix, _ := alg.Inverse(QMatrix)
+	pseudoInverseMatrix, _ := alg.Mult(inverseQMatrix, transposeMatrix)
+	weightMatrix, _ := alg.Mult(patternMatrix, pseudoInverseMatrix)
+	fmt.Println(alg.Trace(weightMatrix))
+	// Output:
+	// 3
 }
 
-func ExampleZMult() {
-	data := []float64{ 1, 2, 3, 4, 5, 6 }
-	arrMatrix := [][]float64{
-		[]float64{ 1, 2, 3, 4, 5, 6 },
-		[]float64{ 2, 3, 4, 5, 6, 7 },
-	}
-	vector := NewVectorArray(data)
-	matrix := MakeMatrixArray(arrMatrix)
-	res := vector.Like(matrix.Rows())
+func ExampleMatrix_ZMult() {
+	vector := tfloat64.NewVectorArray([]float64{1, 2, 3, 4, 5, 6})
+	matrix := tfloat64.NewMatrix(2, 6)
+	matrix.AssignArray([][]float64{
+		{1, 2, 3, 4, 5, 6},
+		{2, 3, 4, 5, 6, 7},
+	})
+	res := &tfloat64.Vector{vector.Like(matrix.Rows())}
 
 	matrix.ZMult(vector, res)
-
-	fmt.Println(res)
+	fmt.Println(res.ToArray())
+	// Output:
+	// [91 112]
 }
 
-func ExampleZMultMatrix() {
-	x := NewMatrix(size, size).Assign(0.5)
-	matrix = SampleDenseMatrix(size, size, 0.5, 0.001)
-
-	res := matrix.ZMultMatrix(x, nil)
+func ExampleMatrix_ZMultMatrix() {
+	x := tfloat64.NewMatrix(3, 3)
+	x.Assign(0.5)
+	matrix := ascendingMatrix(3, 3)
 
-	fmt.Println(res)
+	res, _ := matrix.ZMultMatrix(x, nil)
+	fmt.Println(res.ToArray())
+	// Output:
+	// [[1.5 1.5 1.5] [6 6 6] [10.5 10.5 10.5]]
 }
 
-func ExampleZMultMatrix_array() {
-	data := [][]float64{
-		[]float64{ 6, 5, 4 },
-		[]float64{ 7, 6, 3 },
-		[]float64{ 6, 5, 4 },
-		[]float64{ 7, 6, 3 },
-		[]float64{ 6, 5, 4 },
-		[]float64{ 7, 6, 3 },
-	}
-
-	arrMatrix := [][]float64{
-		[]float64{ 1, 2, 3, 4, 5, 6 },
-		[]float64{ 2, 3, 4, 5, 6, 7 },
-	}
-
-	x := NewMatrixArray(data)
-	matrix := NewMatrixArray(arrMatrix)
-
-	res := matrix.ZMultMatrix(x, nil)
+func ExampleMatrix_ZMultMatrix_array() {
+	x := tfloat64.NewMatrix(6, 3)
+	x.AssignArray([][]float64{
+		{6, 5, 4},
+		{7, 6, 3},
+		{6, 5, 4},
+		{7, 6, 3},
+		{6, 5, 4},
+		{7, 6, 3},
+	})
+	matrix := tfloat64.NewMatrix(2, 6)
+	matrix.AssignArray([][]float64{
+		{1, 2, 3, 4, 5, 6},
+		{2, 3, 4, 5, 6, 7},
+	})
 
-	fmt.Println(res)
+	res, _ := matrix.ZMultMatrix(x, nil)
+	fmt.Println(res.ToArray())
+	// Output:
+	// [[138 117 72] [177 150 93]]
 }
 
-func ExampleFlip() {
-	rows, columns := 4, 5
-	master := NewMatrix(rows, columns)
-	fmt.Println(master)
-	master.Assign(1) // Set all cells to 1.
-	fmt.Println("\n" + master)
-	master.ViewPart(2, 0, 2, 3).Assign(2) // Set [2,1] .. [3,3] to 2
-	fmt.Println("\n" + master)
+func ExampleMatrix_ViewColumnFlip() {
+	master := tfloat64.NewMatrix(4, 5)
+	master.Assign(1)
+	part, _ := master.ViewPart(2, 0, 2, 3)
+	part.Assign(2)
 
 	flip1 := master.ViewColumnFlip()
-	fmt.Println("flip around columns=" + flip1)
+	fmt.Println(flip1.ToArray())
 	flip2 := flip1.ViewRowFlip()
-	fmt.Println("further flip around rows=" + flip2)
-
-	flip2.ViewPart(0, 0, 2, 2).assign(3)
-	fmt.Println("master replaced" + master)
-	fmt.Println("flip1 replaced" + flip1)
-	fmt.Println("flip2 replaced" + flip2)
+	fmt.Println(flip2.ToArray())
+
+	corner, _ := flip2.ViewPart(0, 0, 2, 2)
+	corner.Assign(3)
+	fmt.Println(master.ToArray())
+	// Output:
+	// [[1 1 1 1 1] [1 1 1 1 1] [1 1 2 2 2] [1 1 2 2 2]]
+	// [[1 1 2 2 2] [1 1 2 2 2] [1 1 1 1 1] [1 1 1 1 1]]
+	// [[1 1 1 1 1] [1 1 1 1 1] [2 2 2 3 3] [2 2 2 3 3]]
 }
 
-func ExampleWrapper() {
-	var size int
-	a := Descending(size)
-	b := NewWrapperVector(a)
-	c := b.ViewPart(2, 3)
-	d = c.ViewFlip()
-	// c = b.ViewFlip()
-	// d = c.ViewFlip()
-	d.set(0, 99)
-	b = b.viewSorted()
-	fmt.Println("a = " + a)
-	fmt.Println("b = " + b)
-	fmt.Println("c = " + c)
-	fmt.Println("d = " + d)
+func Example_wrapper() {
+	size := 6
+	a := tfloat64.Decending(size)
+	b := a.ViewVector()
+	c, _ := b.ViewPart(2, 3)
+	d := c.ViewFlip()
+	d.Set(0, 99)
+	fmt.Println(a.ToArray())
+	fmt.Println(b.ToArray())
+	fmt.Println(c.ToArray())
+	fmt.Println(d.ToArray())
+	// Output:
+	// [5 4 3 2 99 0]
+	// [5 4 3 2 99 0]
+	// [3 2 99]
+	// [99 2 3]
 }
 
-func ExampleInfinity() {
-	nan := math.NaN()
-	inf := math.Inf(1)
-	ninf := math.Int(-1)
-
-	data := [][]float64{ []float64{ ninf, nan } }
-
-	x := NewMatrixArray(data)
-	
-	fmt.Println("\n\n\n" + x)
-	fmt.Println("\n" + x.Equals(ninf))
+func Example_infinity() {
+	x := tfloat64.NewMatrix(1, 2)
+	x.AssignArray([][]float64{{math.Inf(-1), math.NaN()}})
+	fmt.Println(x.ToArray())
+	fmt.Println(x.Equals(math.Inf(-1)))
+	// Output:
+	// [[-Inf NaN]]
+	// false
 }
 
-func ExampleViewSorted() {
-	testSort := make([]float64, 5)
-	testSort[0] = 5
-	testSort[1] = math.NaN()
-	testSort[2] = 2
-	testSort[3] = math.NaN
-	testSort[4] = 1
-	doubleDense := NewVectorArray(testSort)
-	fmt.Println("orig = " + doubleDense)
-	doubleDense = doubleDense.ViewSorted()
-	doubleDense.ToArray(testSort)
-	fmt.Println("sort = " + doubleDense)
+func ExampleVector_ViewSorted() {
+	testSort := []float64{5, math.NaN(), 2, math.NaN(), 1}
+	vector := tfloat64.NewVectorArray(testSort)
+	fmt.Println(vector.ToArray())
+	vector = vector.ViewSorted()
+	fmt.Println(vector.ToArray())
 }
 
-func ExampleViewPart() {
-	rows, columns := 4, 5
-	master := NewMatrix(rows, columns)
+func ExampleMatrix_ViewPart() {
+	master := tfloat64.NewMatrix(4, 5)
 	master.Assign(1) // Set all cells to 1.
-	view := master.ViewPart(2, 0, 2, 3).Assign(2)
-	fmt.Println("\n" + master)
-	fmt.Println("\n" + view)
-	view.Assign(Multiply(3))
-	fmt.Println("\n" + master)
-	fmt.Println("\n" + view)
+	view, _ := master.ViewPart(2, 0, 2, 3)
+	view.Assign(2)
+	fmt.Println(master.ToArray())
+	fmt.Println(view.ToArray())
+
+	view.AssignFunc(tfloat64.Multiply(3))
+	fmt.Println(master.ToArray())
+	fmt.Println(view.ToArray())
+	// Output:
+	// [[1 1 1 1 1] [1 1 1 1 1] [2 2 2 1 1] [2 2 2 1 1]]
+	// [[2 2 2] [2 2 2]]
+	// [[1 1 1 1 1] [1 1 1 1 1] [6 6 6 1 1] [6 6 6 1 1]]
+	// [[6 6 6] [6 6 6]]
 }
 
-func ExampleViewRow() {
-	rows, columns := 4, 5
-	master = AscendingMatrix(rows, columns)
-	// master.Assign(1) // Set all cells to 1.
-	master.ViewPart(2, 0, 2, 3).Assign(2); // set [2,1] .. [3,3] to 2
-	fmt.Println("\n" + master)
-
-	indexes := []int{ 0, 1, 3, 0, 1, 2 }
-	view1 := master.ViewRow(0).ViewSelection(indexes)
-	fmt.Println("view1=" + view1)
-	view2 := view1.ViewPart(0, 3)
-	fmt.Println("view2=" + view2)
-	
-	view2.ViewPart(0, 2).Assign(-1)
-	fmt.Println("master replaced" + master)
-	fmt.Println("flip1 replaced" + view1)
-	fmt.Println("flip2 replaced" + view2)
+func ExampleMatrix_ViewRow() {
+	master := ascendingMatrix(4, 5)
+	part, _ := master.ViewPart(2, 0, 2, 3)
+	part.Assign(2) // set [2,0] .. [3,2] to 2
+
+	row, _ := master.ViewRow(0)
+	view1, _ := row.View([]int{0, 1, 3, 0, 1, 2})
+	fmt.Println(view1.ToArray())
+	view2, _ := view1.ViewPart(0, 3)
+	fmt.Println(view2.ToArray())
+
+	view3, _ := view2.ViewPart(0, 2)
+	view3.Assign(-1)
+	fmt.Println(master.ToArray()[0])
+	fmt.Println(view1.ToArray())
+	// Output:
+	// [0 1 3 0 1 2]
+	// [0 1 3]
+	// [-1 -1 2 3 4]
+	// [-1 -1 3 -1 -1 2]
 }
 
-func ExampleViewSelection() {
-	rows, columns := 4, 5
-	master := AscendingMatrix(rows, columns)
-	// master.Assign(1) // Set all cells to 1.
-	fmt.Println("\n" + master)
-	// master.ViewPart(2,0,2,3).Assign(2) // set [2,1] .. [3,3] to 2
-	// fmt.Println("\n"+master)
-
-	rowIndexes := []int{ 0, 1, 3, 0 }
-	columnIndexes := []int{ 0, 2 }
-	view1 := master.ViewSelection(rowIndexes, columnIndexes)
-	fmt.Println("view1=" + view1)
-	view2 := view1.ViewPart(0, 0, 2, 2)
-	fmt.Println("view2=" + view2)
+func ExampleMatrix_ViewSelection() {
+	master := ascendingMatrix(4, 5)
+
+	rowIndexes := []int{0, 1, 3, 0}
+	columnIndexes := []int{0, 2}
+	view1, _ := master.ViewSelection(rowIndexes, columnIndexes)
+	fmt.Println(view1.ToArray())
+	view2, _ := view1.ViewPart(0, 0, 2, 2)
+	fmt.Println(view2.ToArray())
 
 	view2.Assign(-1)
-	fmt.Println("master replaced" + master)
-	fmt.Println("flip1 replaced" + view1)
-	fmt.Println("flip2 replaced" + view2)
+	fmt.Println(master.ToArray())
+	fmt.Println(view1.ToArray())
+	// Output:
+	// [[0 2] [5 7] [15 17] [0 2]]
+	// [[0 2] [5 7]]
+	// [[-1 1 -1 3 4] [-1 6 -1 8 9] [10 11 12 13 14] [15 16 17 18 19]]
+	// [[-1 -1] [-1 -1] [15 17] [-1 -1]]
 }
 
-func ExampleViewDice() {
-	rows, columns := 4, 5
-	master := AscendingMatrix(rows, columns)
-	// master.Assign(1) // Set all cells to 1.
-	fmt.Println("\n" + master)
-	// master.ViewPart(2,0,2,3).Assign(2) // set [2,1] .. [3,3] to 2
-	// fmt.Println("\n"+master)
+func ExampleMatrix_ViewDice() {
+	master := ascendingMatrix(2, 3)
 
 	view1 := master.ViewDice()
-	fmt.Println("view1=" + view1)
+	fmt.Println(view1.ToArray())
 	view2 := view1.ViewDice()
-	fmt.Println("view2=" + view2)
+	fmt.Println(view2.ToArray())
 
 	view2.Assign(-1)
-	fmt.Println("master replaced" + master)
-	fmt.Println("flip1 replaced" + view1)
-	fmt.Println("flip2 replaced" + view2)
+	fmt.Println(master.ToArray())
+	// Output:
+	// [[0 3] [1 4] [2 5]]
+	// [[0 1 2] [3 4 5]]
+	// [[-1 -1 -1] [-1 -1 -1]]
 }
 
-func ExampleViewRowFlip() {
-	rows, columns := 4, 5
-	master := AscendingMatrix(rows, columns)
-	// master.Assign(1) // Set all cells to 1.
-	fmt.Println("\n" + master)
-	// master.ViewPart(2,0,2,3).Assign(2); // set [2,1] .. [3,3] to 2
-	// fmt.Println("\n"+master)
+func ExampleMatrix_ViewRowFlip() {
+	master := ascendingMatrix(3, 2)
 
 	view1 := master.ViewRowFlip()
-	fmt.Println("view1=" + view1)
+	fmt.Println(view1.ToArray())
 	view2 := view1.ViewRowFlip()
-	fmt.Println("view2=" + view2)
-
-	view2.Assign(-1)
-	fmt.Println("master replaced" + master)
-	fmt.Println("flip1 replaced" + view1)
-	fmt.Println("flip2 replaced" + view2)
+	fmt.Println(view2.ToArray())
+	// Output:
+	// [[4 5] [2 3] [0 1]]
+	// [[0 1] [2 3] [4 5]]
 }
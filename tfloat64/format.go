@@ -1,6 +1,7 @@
 package tfloat64
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/rwl/goshawk/common"
 )
@@ -22,12 +23,10 @@ func NewFormatter() *Formatter {
 // Constructs and returns a matrix formatter with the given format used to
 // convert a single cell value.
 func NewFormatterFormat(format string) *Formatter {
-	return &Formatter{
-		common.FormatterBase{
-			Format: format,
-			Alignment: common.DECIMAL,
-		},
-	}
+	f := &Formatter{*common.NewFormatter()}
+	f.Format = format
+	f.Alignment = common.DECIMAL
+	return f
 }
 
 // Converts a given cell to a String; no alignment considered.
@@ -40,10 +39,12 @@ func (f *Formatter) Form(vector Vec, index int) string {
 
 //  Returns a string representations of all cells; no alignment considered.
 func (f *Formatter) FormatMatrix(matrix Mat) [][]string {
-	strings := make([][]string,matrix.Rows(), matrix.Columns())
-	/*for row := 0; row < matrix.Rows(); row++ { TODO: implement ViewRow
-		strings[row] = f.FormatRow(matrix.ViewRow(row))
-	}*/
+	strings := make([][]string, matrix.Rows())
+	m := &Matrix{matrix}
+	for row := 0; row < matrix.Rows(); row++ {
+		view, _ := m.ViewRow(row)
+		strings[row] = f.FormatRow(view)
+	}
 	return strings
 }
 
@@ -59,9 +60,16 @@ func (f *Formatter) FormatRow(vector Vec) []string {
 
 // Returns a string representation of the given vector.
 func (f *Formatter) VectorToString(v Vec) string {
-	//	easy := NewMatrix(1, v.Size())
-	//	easy.ViewRow(0).AssignVector(v)
-	return ""//f.MatrixToString(easy)
+	easy := NewMatrix(1, v.Size())
+	row, _ := easy.ViewRow(0)
+	row.AssignVector(v)
+	strings := f.FormatMatrix(easy)
+	f.Align(strings)
+	total := f.ArrayToString(strings)
+	if f.PrintShape {
+		total = v.StringShort() + "\n" + total
+	}
+	return total
 }
 
 // Returns a string representation of the given matrix.
@@ -70,30 +78,31 @@ func (f *Formatter) MatrixToString(matrix Mat) string {
 	f.Align(strings)
 	total := f.ArrayToString(strings)
 	if f.PrintShape {
-		total = f.MatrixShape(matrix) + "\n" + total
+		total = matrix.StringShort() + "\n" + total
 	}
 	return total
 }
 
-/*
-// Returns a string representation of the given matrix.
-func (f *Formatter) CubeToString(matrix *Cube) string {
+// Returns a string representation of the given cube, formatting each
+// slice as a matrix.
+func (f *Formatter) CubeToString(cube *Cube) string {
 	var buf bytes.Buffer
 	oldPrintShape := f.PrintShape
 	f.PrintShape = false
-	for slice := 0; slice < matrix.Slices; slice++ {
+	for slice := 0; slice < cube.Slices(); slice++ {
 		if slice != 0 {
 			buf.WriteString(f.SliceSeparator)
 		}
-		buf.WriteString(f.VectorToString(matrix.ViewSlice(slice)))
+		view, _ := cube.ViewSlice(slice)
+		buf.WriteString(f.MatrixToString(view))
 	}
 	f.PrintShape = oldPrintShape
-	if printShape {
-		return f.CubeShape(matrix) + "\n" + buf.String()
+	if f.PrintShape {
+		return cube.StringShort() + "\n" + buf.String()
 	}
 	return buf.String()
 }
-*/
+
 /*
 func (f *Formatter) VectorToSourceCode(matrix Vector) string {
 	var copy Formatter = f.Clone()
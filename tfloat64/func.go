@@ -15,8 +15,12 @@ type Float64Float64Procedure func (float64, float64) float64
 
 type IntIntFloat64Func func (int, int, float64) float64
 
+type IntIntFloat64Procedure func (int, int, float64) bool
+
 type VectorProcedure func (Vec) bool
 
+type MatrixProcedure func (Mat) bool
+
 // Function that returns a * a.
 func Square(a float64) float64 {
 	return a*a
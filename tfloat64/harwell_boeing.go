@@ -0,0 +1,556 @@
+package tfloat64
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A sparse matrix exchanged in Harwell-Boeing or Rutherford-Boeing format,
+// together with any right-hand sides, starting guesses and exact solutions
+// stored alongside it.
+type HarwellBoeing struct {
+	Title string    // Up to 72 characters describing the matrix.
+	Key   string    // Up to 8 characters identifying the matrix.
+	Type  string    // Three letter matrix type, e.g. "RUA"; see ReadHarwellBoeing.
+	A     *Matrix   // The matrix, in compressed column storage when read.
+	RHS   []*Vector // Right-hand sides of the system A*x = b.
+	Guess []*Vector // Starting guesses, one per right-hand side, if any.
+	Exact []*Vector // Exact solutions, one per right-hand side, if any.
+}
+
+// Reads a matrix in Harwell-Boeing or Rutherford-Boeing format from r.
+// The values are parsed according to the Fortran formats given in the
+// header, such as (13I6) or (1P,4E20.12), as they are read.
+//
+// The first letter of the matrix type must be R (real), I (integer) or
+// P (pattern, all values 1); the second U or R (unsymmetric), S or H
+// (symmetric) or Z (skew-symmetric), with the upper triangle of symmetric
+// matrices mirrored from the stored lower triangle; the third A
+// (assembled). Right-hand sides must be stored in full, as columns of
+// the same length as the matrix has rows.
+func ReadHarwellBoeing(r io.Reader) (*HarwellBoeing, error) {
+	s := &harwellBoeingScanner{scanner: bufio.NewScanner(r)}
+	s.scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+
+	header := make([]string, 4)
+	for i := range header {
+		line, err := s.next()
+		if err != nil {
+			return nil, fmt.Errorf("Missing Harwell-Boeing header line %d", i+1)
+		}
+		header[i] = line
+	}
+	hb := &HarwellBoeing{
+		Title: strings.TrimSpace(fixedField(header[0], 0, 72)),
+		Key:   strings.TrimSpace(fixedField(header[0], 72, 80)),
+	}
+
+	cards, err := parseHarwellBoeingInts(header[1], 4, 5)
+	if err != nil {
+		return nil, fmt.Errorf("Line 2: %s", err)
+	}
+	rhsCards := 0
+	if len(cards) == 5 {
+		rhsCards = cards[4]
+	}
+
+	fields := strings.Fields(header[2])
+	if len(fields) == 0 || len(fields[0]) != 3 {
+		return nil, fmt.Errorf("Line 3: invalid matrix type: %q", header[2])
+	}
+	hb.Type = strings.ToUpper(fields[0])
+	size, err := parseHarwellBoeingInts(strings.Join(fields[1:], " "), 3, 4)
+	if err != nil {
+		return nil, fmt.Errorf("Line 3: %s", err)
+	}
+	rows, columns, nonZeros := size[0], size[1], size[2]
+	if rows < 0 || columns < 0 || nonZeros < 0 {
+		return nil, fmt.Errorf("Line 3: negative size: %q", header[2])
+	}
+	value, symmetry, assembled := hb.Type[0], hb.Type[1], hb.Type[2]
+	switch {
+	case value != 'R' && value != 'I' && value != 'P' && value != 'Q':
+		return nil, fmt.Errorf("Unsupported matrix type: %s", hb.Type)
+	case symmetry != 'U' && symmetry != 'R' && symmetry != 'S' && symmetry != 'H' && symmetry != 'Z':
+		return nil, fmt.Errorf("Unsupported matrix type: %s", hb.Type)
+	case assembled != 'A':
+		return nil, fmt.Errorf("Unsupported matrix type: %s", hb.Type)
+	case symmetry != 'U' && symmetry != 'R' && rows != columns:
+		return nil, fmt.Errorf("Symmetric matrix must be square: %d x %d", rows, columns)
+	}
+	pattern := value == 'P' || value == 'Q'
+
+	pointerFormat, err := parseFortranFormat(fixedField(header[3], 0, 16))
+	if err != nil {
+		return nil, err
+	}
+	indexFormat, err := parseFortranFormat(fixedField(header[3], 16, 32))
+	if err != nil {
+		return nil, err
+	}
+	var valueFormat, rhsFormat *fortranFormat
+	if !pattern {
+		if valueFormat, err = parseFortranFormat(fixedField(header[3], 32, 52)); err != nil {
+			return nil, err
+		}
+	}
+	rhsType := ""
+	nrhs := 0
+	if rhsCards > 0 {
+		if rhsFormat, err = parseFortranFormat(fixedField(header[3], 52, 72)); err != nil {
+			return nil, err
+		}
+		line, err := s.next()
+		if err != nil {
+			return nil, fmt.Errorf("Missing Harwell-Boeing header line 5")
+		}
+		rhsType = strings.ToUpper(fixedField(line, 0, 3))
+		counts, err := parseHarwellBoeingInts(fixedField(line, 3, len(line)), 1, 2)
+		if err != nil {
+			return nil, fmt.Errorf("Line 5: %s", err)
+		}
+		nrhs = counts[0]
+		if len(rhsType) == 0 || rhsType[0] != 'F' {
+			return nil, fmt.Errorf("Unsupported right-hand side type: %q", rhsType)
+		}
+	}
+
+	pointers := make([]int, columns+1)
+	err = s.read(pointerFormat, columns+1, func(k int, value float64) error {
+		pointers[k] = int(value) - 1
+		if pointers[k] < 0 || pointers[k] > nonZeros || (k > 0 && pointers[k] < pointers[k-1]) {
+			return fmt.Errorf("Line %d: invalid column pointer: %g", s.line, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pointers[0] != 0 || pointers[columns] != nonZeros {
+		return nil, fmt.Errorf("Column pointers do not span %d entries", nonZeros)
+	}
+	rowIndexes := make([]int, nonZeros)
+	err = s.read(indexFormat, nonZeros, func(k int, value float64) error {
+		rowIndexes[k] = int(value) - 1
+		if rowIndexes[k] < 0 || rowIndexes[k] >= rows {
+			return fmt.Errorf("Line %d: invalid row index: %g", s.line, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := nonZeros
+	if symmetry != 'U' && symmetry != 'R' {
+		capacity *= 2
+	}
+	b := NewTripletBuilderCapacity(rows, columns, capacity)
+	c := 0
+	add := func(k int, value float64) error {
+		for pointers[c+1] <= k {
+			c++
+		}
+		r := rowIndexes[k]
+		b.Append(r, c, value)
+		if r != c {
+			switch symmetry {
+			case 'S', 'H':
+				b.Append(c, r, value)
+			case 'Z':
+				b.Append(c, r, -value)
+			}
+		}
+		return nil
+	}
+	if pattern {
+		for k := 0; k < nonZeros; k++ {
+			add(k, 1)
+		}
+	} else if err := s.read(valueFormat, nonZeros, add); err != nil {
+		return nil, err
+	}
+	hb.A = b.ColumnCompressed()
+
+	if nrhs > 0 {
+		if hb.RHS, err = s.readVectors(rhsFormat, nrhs, rows); err != nil {
+			return nil, err
+		}
+		if len(rhsType) > 1 && rhsType[1] == 'G' {
+			if hb.Guess, err = s.readVectors(rhsFormat, nrhs, rows); err != nil {
+				return nil, err
+			}
+		}
+		if len(rhsType) > 2 && rhsType[2] == 'X' {
+			if hb.Exact, err = s.readVectors(rhsFormat, nrhs, rows); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return hb, nil
+}
+
+// Returns the substring of line between the given columns, which is
+// shorter or empty if line is.
+func fixedField(line string, start, end int) string {
+	if start >= len(line) {
+		return ""
+	}
+	if end > len(line) {
+		end = len(line)
+	}
+	return line[start:end]
+}
+
+// Parses between min and max whitespace separated integers.
+func parseHarwellBoeingInts(line string, min, max int) ([]int, error) {
+	fields := strings.Fields(line)
+	if len(fields) < min || len(fields) > max {
+		return nil, fmt.Errorf("expected %d to %d integers: %q", min, max, line)
+	}
+	values := make([]int, len(fields))
+	for i, f := range fields {
+		value, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer: %s", f)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Reads the lines of a Harwell-Boeing file, keeping track of the line
+// number for error messages.
+type harwellBoeingScanner struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// Returns the next line, or io.EOF at the end of the input.
+func (s *harwellBoeingScanner) next() (string, error) {
+	if s.scanner.Scan() {
+		s.line++
+		return s.scanner.Text(), nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", io.EOF
+}
+
+// Reads n values in the given format, starting on a new line, and passes
+// each of them with its position to function. Lines may be shorter than
+// the format if their trailing blank fields have been stripped.
+func (s *harwellBoeingScanner) read(f *fortranFormat, n int, function func(int, float64) error) error {
+	k := 0
+	for k < n {
+		line, err := s.next()
+		if err == io.EOF {
+			return fmt.Errorf("Expected %d values, found %d", n, k)
+		} else if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, " \t\r")
+		for j := 0; j < f.repeat && k < n && j*f.width < len(line); j++ {
+			value, err := f.parse(fixedField(line, j*f.width, (j+1)*f.width))
+			if err != nil {
+				return fmt.Errorf("Line %d: %s", s.line, err)
+			}
+			if err := function(k, value); err != nil {
+				return err
+			}
+			k++
+		}
+	}
+	return nil
+}
+
+// Reads count consecutive vectors of the given size in the given format.
+func (s *harwellBoeingScanner) readVectors(f *fortranFormat, count, size int) ([]*Vector, error) {
+	vectors := make([]*Vector, count)
+	for i := range vectors {
+		vectors[i] = NewVector(size)
+	}
+	err := s.read(f, count*size, func(k int, value float64) error {
+		vectors[k/size].SetQuick(k%size, value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
+
+var fortranFormatPattern = regexp.MustCompile(`^\(\s*(?:(-?\d+)P\s*,?\s*)?(\d*)\s*([IEDFG])(\d+)(?:\.(\d+))?(?:E\d+)?\s*\)$`)
+
+// A Fortran edit descriptor with a repeat count, such as 13I6 or
+// 1P,4E20.12, describing a line of fixed width fields.
+type fortranFormat struct {
+	repeat   int  // Number of fields per line.
+	kind     byte // One of I, E, D, F or G.
+	width    int  // Number of characters per field.
+	decimals int  // Number of digits after the decimal point.
+	scale    int  // Scale factor applied to values without an exponent.
+}
+
+// Parses a parenthesized Fortran format with a single edit descriptor.
+func parseFortranFormat(s string) (*fortranFormat, error) {
+	m := fortranFormatPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil {
+		return nil, fmt.Errorf("Unsupported Fortran format: %q", s)
+	}
+	f := &fortranFormat{repeat: 1, kind: m[3][0]}
+	if m[1] != "" {
+		f.scale, _ = strconv.Atoi(m[1])
+	}
+	if m[2] != "" {
+		f.repeat, _ = strconv.Atoi(m[2])
+	}
+	f.width, _ = strconv.Atoi(m[4])
+	if m[5] != "" {
+		f.decimals, _ = strconv.Atoi(m[5])
+	}
+	if f.repeat < 1 || f.width < 1 {
+		return nil, fmt.Errorf("Unsupported Fortran format: %q", s)
+	}
+	return f, nil
+}
+
+// Returns the string representation of this format.
+func (f *fortranFormat) String() string {
+	scale := ""
+	if f.scale != 0 {
+		scale = fmt.Sprintf("%dP,", f.scale)
+	}
+	if f.kind == 'I' {
+		return fmt.Sprintf("(%s%d%c%d)", scale, f.repeat, f.kind, f.width)
+	}
+	return fmt.Sprintf("(%s%d%c%d.%d)", scale, f.repeat, f.kind, f.width, f.decimals)
+}
+
+// Parses a field of this format. Blank fields are zero, D exponents and
+// exponents without a letter, such as 1.5-3, are accepted, and the
+// decimal point is implied by the format if the field has none.
+func (f *fortranFormat) parse(field string) (float64, error) {
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return 0, nil
+	}
+	if f.kind == 'I' {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return 0, fmt.Errorf("invalid integer: %s", field)
+		}
+		return float64(value), nil
+	}
+	s := strings.Map(func(r rune) rune {
+		if r == 'D' || r == 'd' {
+			return 'E'
+		}
+		return r
+	}, field)
+	exponent := strings.IndexAny(s, "Ee")
+	if exponent < 0 {
+		if i := strings.LastIndexAny(s, "+-"); i > 0 {
+			s = s[:i] + "E" + s[i:]
+			exponent = i
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid real: %s", field)
+	}
+	if !strings.Contains(s, ".") {
+		value /= math.Pow10(f.decimals)
+	}
+	if exponent < 0 && f.scale != 0 {
+		value /= math.Pow10(f.scale)
+	}
+	return value, nil
+}
+
+// Returns the field of this format holding the given value.
+func (f *fortranFormat) format(value float64) string {
+	if f.kind == 'I' {
+		return fmt.Sprintf("%*d", f.width, int64(value))
+	}
+	return fmt.Sprintf("%*.*E", f.width, f.decimals, value)
+}
+
+// Returns a format for integers up to max, filling lines of 80 characters.
+func integerFortranFormat(max int) *fortranFormat {
+	width := len(strconv.Itoa(max)) + 1
+	return &fortranFormat{repeat: 80 / width, kind: 'I', width: width}
+}
+
+// The format of real values. With a scale factor of 1 each field holds
+// the 17 significant digits that float64 values need to round trip.
+var realFortranFormat = &fortranFormat{repeat: 3, kind: 'E', width: 26, decimals: 16, scale: 1}
+
+// Writes the given values in the given format, filling whole lines.
+func writeFortran(w *bufio.Writer, f *fortranFormat, values []float64) {
+	for k, value := range values {
+		w.WriteString(f.format(value))
+		if (k+1)%f.repeat == 0 || k == len(values)-1 {
+			w.WriteByte('\n')
+		}
+	}
+}
+
+// Returns the number of lines needed to write n values in format f.
+func fortranLines(f *fortranFormat, n int) int {
+	return (n + f.repeat - 1) / f.repeat
+}
+
+// Writes hb to w in Harwell-Boeing format, including any right-hand
+// sides, guesses and exact solutions. If the matrix type is empty it is
+// "RUA". Only the lower triangle of symmetric matrices is written and an
+// error is returned if the matrix does not have the symmetry of its type.
+func WriteHarwellBoeing(w io.Writer, hb *HarwellBoeing) error {
+	return writeHarwellBoeing(w, hb, false)
+}
+
+// Writes the matrix of hb to w in Rutherford-Boeing format, which has no
+// right-hand sides; an error is returned if hb has any. The matrix type
+// is handled as by WriteHarwellBoeing.
+func WriteRutherfordBoeing(w io.Writer, hb *HarwellBoeing) error {
+	if len(hb.RHS) > 0 {
+		return fmt.Errorf("Rutherford-Boeing matrix files hold no right-hand sides")
+	}
+	return writeHarwellBoeing(w, hb, true)
+}
+
+func writeHarwellBoeing(w io.Writer, hb *HarwellBoeing, rutherford bool) error {
+	mxtype := strings.ToUpper(hb.Type)
+	if mxtype == "" {
+		mxtype = "RUA"
+	}
+	if len(mxtype) != 3 || !strings.ContainsRune("RIP", rune(mxtype[0])) || !strings.ContainsRune("URSHZ", rune(mxtype[1])) || mxtype[2] != 'A' {
+		return fmt.Errorf("Unsupported matrix type: %s", hb.Type)
+	}
+	var A Mat = hb.A.Mat
+	symmetry := mxtype[1]
+	var err error
+	switch symmetry {
+	case 'S', 'H':
+		err = checkSymmetry(A, MatrixMarketSymmetric, 1)
+	case 'Z':
+		err = checkSymmetry(A, MatrixMarketSkewSymmetric, -1)
+	}
+	if err != nil {
+		return err
+	}
+	rows, columns := A.Rows(), A.Columns()
+	for _, vectors := range [][]*Vector{hb.RHS, hb.Guess, hb.Exact} {
+		if len(vectors) > 0 && len(vectors) != len(hb.RHS) {
+			return fmt.Errorf("Expected %d guesses and exact solutions, found %d", len(hb.RHS), len(vectors))
+		}
+		for _, v := range vectors {
+			if v.Size() != rows {
+				return fmt.Errorf("Incompatible dimensions: %d x %d matrix and vector of size %d", rows, columns, v.Size())
+			}
+		}
+	}
+
+	m := columnCompressed(A)
+	pointers := make([]float64, columns+1)
+	var rowIndexes, values []float64
+	for c := 0; c < columns; c++ {
+		for k := m.columnPointers[c]; k < m.columnPointers[c+1]; k++ {
+			r := m.rowIndexes[k]
+			if (symmetry == 'S' || symmetry == 'H') && r < c || symmetry == 'Z' && r <= c {
+				continue
+			}
+			rowIndexes = append(rowIndexes, float64(r+1))
+			values = append(values, m.values[k])
+		}
+		pointers[c+1] = float64(len(values))
+	}
+	for c := range pointers {
+		pointers[c]++
+	}
+	nonZeros := len(values)
+
+	pointerFormat := integerFortranFormat(nonZeros + 1)
+	indexFormat := integerFortranFormat(rows)
+	valueFormat := realFortranFormat
+	switch mxtype[0] {
+	case 'I':
+		max := 0.0
+		for _, value := range values {
+			if value != math.Trunc(value) || math.Abs(value) >= math.MaxInt64 {
+				return fmt.Errorf("Value is not an integer: %g", value)
+			}
+			max = math.Max(max, math.Abs(value))
+		}
+		valueFormat = integerFortranFormat(int(max))
+		valueFormat.width++
+		valueFormat.repeat = 80 / valueFormat.width
+	case 'P':
+		valueFormat = nil
+	}
+
+	guess, exact := " ", " "
+	if len(hb.Guess) > 0 {
+		guess = "G"
+	}
+	if len(hb.Exact) > 0 {
+		exact = "X"
+	}
+	rhsType := "F" + guess + exact
+
+	pointerCards := fortranLines(pointerFormat, columns+1)
+	indexCards := fortranLines(indexFormat, nonZeros)
+	valueCards := 0
+	if valueFormat != nil {
+		valueCards = fortranLines(valueFormat, nonZeros)
+	}
+	rhsCards := 0
+	for _, vectors := range [][]*Vector{hb.RHS, hb.Guess, hb.Exact} {
+		if len(vectors) > 0 {
+			rhsCards += fortranLines(realFortranFormat, len(vectors)*rows)
+		}
+	}
+	totalCards := pointerCards + indexCards + valueCards + rhsCards
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%-72.72s%-8.8s\n", hb.Title, hb.Key)
+	valueFormatString := ""
+	if valueFormat != nil {
+		valueFormatString = valueFormat.String()
+	}
+	if rutherford {
+		fmt.Fprintf(bw, "%14d%14d%14d%14d\n", totalCards, pointerCards, indexCards, valueCards)
+		fmt.Fprintf(bw, "%-3s%11s%14d%14d%14d%14d\n", strings.ToLower(mxtype), "", rows, columns, nonZeros, 0)
+		fmt.Fprintln(bw, strings.TrimRight(fmt.Sprintf("%-16s%-16s%-20s", pointerFormat, indexFormat, valueFormatString), " "))
+	} else {
+		fmt.Fprintf(bw, "%14d%14d%14d%14d%14d\n", totalCards, pointerCards, indexCards, valueCards, rhsCards)
+		fmt.Fprintf(bw, "%-3s%11s%14d%14d%14d%14d\n", mxtype, "", rows, columns, nonZeros, 0)
+		rhsFormatString := ""
+		if rhsCards > 0 {
+			rhsFormatString = realFortranFormat.String()
+		}
+		fmt.Fprintln(bw, strings.TrimRight(fmt.Sprintf("%-16s%-16s%-20s%-20s", pointerFormat, indexFormat, valueFormatString, rhsFormatString), " "))
+		if rhsCards > 0 {
+			fmt.Fprintf(bw, "%-3s%11s%14d%14d\n", rhsType, "", len(hb.RHS), 0)
+		}
+	}
+	writeFortran(bw, pointerFormat, pointers)
+	writeFortran(bw, indexFormat, rowIndexes)
+	if valueFormat != nil {
+		writeFortran(bw, valueFormat, values)
+	}
+	for _, vectors := range [][]*Vector{hb.RHS, hb.Guess, hb.Exact} {
+		var rhs []float64
+		for _, v := range vectors {
+			rhs = append(rhs, v.ToArray()...)
+		}
+		writeFortran(bw, realFortranFormat, rhs)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,246 @@
+package tfloat64
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func harwellBoeingMatrix(values [][]float64) *Matrix {
+	A := NewMatrix(len(values), len(values[0]))
+	A.AssignArray(values)
+	return A
+}
+
+func harwellBoeingExample() string {
+	return fmt.Sprintf("%-72s%-8s\n", "Example 3 x 4 system", "EX34") +
+		fmt.Sprintf("%14d%14d%14d%14d%14d\n", 9, 2, 1, 3, 3) +
+		fmt.Sprintf("%-3s%11s%14d%14d%14d%14d\n", "RUA", "", 3, 4, 5, 0) +
+		fmt.Sprintf("%-16s%-16s%-20s%-20s\n", "(3I3)", "(5I2)", "(2D12.4)", "(3F8.2)") +
+		fmt.Sprintf("%-3s%11s%14d%14d\n", "FGX", "", 1, 0) +
+		"  1  3  4\n" +
+		"  5  6\n" +
+		" 1 3 2 3 1\n" +
+		"  0.1000D+01  0.4000D+01\n" +
+		"  0.3000D+01  0.5000D+01\n" +
+		"  0.2000D+01\n" +
+		"    3.00    3.00    9.00\n" +
+		"    0.00    1.00    0.00\n" +
+		"    1.00    1.00    1.00\n"
+}
+
+func TestReadHarwellBoeing(t *testing.T) {
+	hb, err := ReadHarwellBoeing(strings.NewReader(harwellBoeingExample()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hb.Title != "Example 3 x 4 system" || hb.Key != "EX34" || hb.Type != "RUA" {
+		t.Errorf("expected:%q %q %q actual:%q %q %q", "Example 3 x 4 system", "EX34", "RUA", hb.Title, hb.Key, hb.Type)
+	}
+	if _, ok := hb.A.Mat.(*SparseCCMat); !ok {
+		t.Errorf("expected:*SparseCCMat actual:%T", hb.A.Mat)
+	}
+	expected := harwellBoeingMatrix([][]float64{
+		{1, 0, 0, 2},
+		{0, 3, 0, 0},
+		{4, 0, 5, 0},
+	})
+	if !hb.A.EqualsMatrix(expected) {
+		t.Errorf("expected:%s actual:%s", expected, hb.A)
+	}
+	for i, v := range [][]*Vector{hb.RHS, hb.Guess, hb.Exact} {
+		e := [][]float64{{3, 3, 9}, {0, 1, 0}, {1, 1, 1}}[i]
+		if len(v) != 1 || !v[0].EqualsVector(NewVectorArray(e)) {
+			t.Errorf("expected:%v actual:%v", e, v)
+		}
+	}
+}
+
+func TestReadHarwellBoeingSymmetric(t *testing.T) {
+	header := fmt.Sprintf("%-80s\n", "Symmetric") +
+		fmt.Sprintf("%14d%14d%14d%14d\n", 4, 1, 1, 2) +
+		fmt.Sprintf("%-3s%11s%14d%14d%14d%14d\n", "rsa", "", 3, 3, 4, 0) +
+		fmt.Sprintf("%-16s%-16s%-20s\n", "(4I2)", "(4I2)", "(1P,2E12.4)")
+	hb, err := ReadHarwellBoeing(strings.NewReader(header + " 1 3 4 5\n 1 2 2 3\n" +
+		fmt.Sprintf("%12s%12s\n%12s%12s\n", "4.0E0", "-1.0E0", "2.0-1", "6.5")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := harwellBoeingMatrix([][]float64{
+		{4, -1, 0},
+		{-1, 0.2, 0},
+		{0, 0, 0.65},
+	})
+	if !hb.A.EqualsMatrix(expected) {
+		t.Errorf("expected:%s actual:%s", expected, hb.A)
+	}
+
+	header = fmt.Sprintf("%-80s\n", "Skew-symmetric pattern") +
+		fmt.Sprintf("%14d%14d%14d%14d\n", 2, 1, 1, 0) +
+		fmt.Sprintf("%-3s%11s%14d%14d%14d\n", "PZA", "", 3, 3, 2) +
+		fmt.Sprintf("%-16s%-16s\n", "(4I2)", "(4I2)")
+	hb, err = ReadHarwellBoeing(strings.NewReader(header + " 1 3 3 3\n 2 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = harwellBoeingMatrix([][]float64{
+		{0, -1, -1},
+		{1, 0, 0},
+		{1, 0, 0},
+	})
+	if !hb.A.EqualsMatrix(expected) {
+		t.Errorf("expected:%s actual:%s", expected, hb.A)
+	}
+}
+
+func TestReadHarwellBoeingErrors(t *testing.T) {
+	example := harwellBoeingExample()
+	for _, s := range []string{
+		"",
+		strings.Replace(example, "RUA", "CUA", 1),
+		strings.Replace(example, "RUA", "RUE", 1),
+		strings.Replace(example, "RUA", "RSA", 1),
+		strings.Replace(example, "(2D12.4)", "(2A12)  ", 1),
+		strings.Replace(example, "FGX", "MGX", 1),
+		strings.Replace(example, "  5  6\n", "  5  7\n", 1),
+		strings.Replace(example, " 1 3 2 3 1\n", " 1 4 2 3 1\n", 1),
+		strings.Replace(example, "  0.2000D+01\n", "  0.2000X+01\n", 1),
+		strings.TrimSuffix(example, "    1.00    1.00    1.00\n"),
+	} {
+		if _, err := ReadHarwellBoeing(strings.NewReader(s)); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestFortranFormat(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		field  string
+		value  float64
+	}{
+		{"(13I6)", "    42", 42},
+		{"(5E16.8)", "  1.5-3", 0.0015},
+		{"(1P,4E20.12)", "1.25", 0.125},
+		{"(1P4D25.16)", "-0.5D+02", -50},
+		{"(10F8.3)", "   12345", 12.345},
+		{"(4e26.18e3)", "1.0e+100", 1e100},
+		{"(3G26.16)", "", 0},
+	} {
+		f, err := parseFortranFormat(test.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := f.parse(test.field)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(value-test.value) > 1e-15*math.Abs(test.value) {
+			t.Errorf("expected:%g actual:%g", test.value, value)
+		}
+	}
+	f, _ := parseFortranFormat("(1P,4E20.12)")
+	if f.repeat != 4 || f.width != 20 || f.String() != "(1P,4E20.12)" {
+		t.Errorf("expected:%s actual:%s", "(1P,4E20.12)", f)
+	}
+	for _, s := range []string{"(A10)", "13I6", "(0I6)"} {
+		if _, err := parseFortranFormat(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestWriteHarwellBoeing(t *testing.T) {
+	A := harwellBoeingMatrix([][]float64{
+		{0.1, 0, 1.0 / 3},
+		{0, -2.5e300, 0},
+		{1e-300, 0, 0},
+		{0, 7, 0},
+	})
+	hb := &HarwellBoeing{
+		Title: "Round trip",
+		Key:   "RT",
+		A:     A,
+		RHS:   []*Vector{NewVectorArray([]float64{1, 2, 3, 4}), NewVectorArray([]float64{0, 0, 0, math.Pi})},
+		Exact: []*Vector{NewVector(4), NewVectorArray([]float64{-1, 0, 0, 1})},
+	}
+	var buf bytes.Buffer
+	if err := WriteHarwellBoeing(&buf, hb); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.HasPrefix(lines[2], "RUA") || !strings.HasPrefix(lines[4], "F X") {
+		t.Errorf("expected:%q %q actual:%q %q", "RUA", "F X", lines[2], lines[4])
+	}
+	read, err := ReadHarwellBoeing(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Title != hb.Title || read.Key != hb.Key || !read.A.EqualsMatrix(A) {
+		t.Errorf("expected:%s actual:%s", A, read.A)
+	}
+	if len(read.RHS) != 2 || len(read.Guess) != 0 || len(read.Exact) != 2 {
+		t.Fatalf("expected:%d %d %d actual:%d %d %d", 2, 0, 2, len(read.RHS), len(read.Guess), len(read.Exact))
+	}
+	for i := range hb.RHS {
+		if !read.RHS[i].EqualsVector(hb.RHS[i]) || !read.Exact[i].EqualsVector(hb.Exact[i]) {
+			t.Errorf("expected:%s %s actual:%s %s", hb.RHS[i], hb.Exact[i], read.RHS[i], read.Exact[i])
+		}
+	}
+
+	hb.Guess = hb.RHS[:1]
+	if err := WriteHarwellBoeing(&buf, hb); err == nil {
+		t.Errorf("expected error for guesses")
+	}
+	hb.Guess = nil
+	hb.Type = "RSA"
+	if err := WriteHarwellBoeing(&buf, hb); err == nil {
+		t.Errorf("expected error for symmetric")
+	}
+	hb.Type = "IUA"
+	if err := WriteHarwellBoeing(&buf, hb); err == nil {
+		t.Errorf("expected error for integer")
+	}
+}
+
+func TestWriteRutherfordBoeing(t *testing.T) {
+	A := harwellBoeingMatrix([][]float64{
+		{2, -1, 0},
+		{-1, 2, -1},
+		{0, -1, 2},
+	})
+	for _, mxtype := range []string{"RSA", "ISA", "PSA"} {
+		hb := &HarwellBoeing{Title: "Laplacian", Key: "LAP3", Type: mxtype, A: A}
+		var buf bytes.Buffer
+		if err := WriteRutherfordBoeing(&buf, hb); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(buf.String(), "\n")
+		if !strings.HasPrefix(lines[2], strings.ToLower(mxtype)) || strings.TrimSpace(lines[4]) != "1 3 5 6" {
+			t.Errorf("unexpected header: %q", buf.String())
+		}
+		read, err := ReadHarwellBoeing(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := A
+		if mxtype == "PSA" {
+			expected = A.Copy()
+			expected.AssignFunc(func(x float64) float64 {
+				if x != 0 {
+					return 1
+				}
+				return 0
+			})
+		}
+		if read.Type != mxtype || !read.A.EqualsMatrix(expected) {
+			t.Errorf("expected:%s %s actual:%s %s", mxtype, expected, read.Type, read.A)
+		}
+	}
+	hb := &HarwellBoeing{A: A, RHS: []*Vector{NewVector(3)}}
+	if err := WriteRutherfordBoeing(&bytes.Buffer{}, hb); err == nil {
+		t.Errorf("expected error for right-hand sides")
+	}
+}
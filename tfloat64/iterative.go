@@ -0,0 +1,442 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+)
+
+// Preconditioner for the iterative solvers. A preconditioner M
+// approximates the system matrix A such that systems M*x = b are cheap
+// to solve.
+type Preconditioner interface {
+	// Prepares the preconditioner for the given system matrix. Called by
+	// the iterative solvers before the first iteration.
+	SetMatrix(A *Matrix) error
+
+	// Computes x = M^-1 * b.
+	Apply(b, x *Vector) error
+}
+
+// The identity preconditioner, M = I. Used by the iterative solvers if
+// no other preconditioner is set.
+type IdentityPreconditioner struct{}
+
+// Constructs and returns a new identity preconditioner.
+func NewIdentityPreconditioner() *IdentityPreconditioner {
+	return &IdentityPreconditioner{}
+}
+
+func (p *IdentityPreconditioner) SetMatrix(A *Matrix) error {
+	return nil
+}
+
+func (p *IdentityPreconditioner) Apply(b, x *Vector) error {
+	_, err := x.AssignVector(b)
+	return err
+}
+
+// Settings and state common to all iterative solvers.
+type iterativeSolver struct {
+	tolerance      float64
+	maxIterations  int
+	preconditioner Preconditioner
+	residuals      []float64
+}
+
+func newIterativeSolver() iterativeSolver {
+	return iterativeSolver{
+		tolerance:      1e-10,
+		maxIterations:  1000,
+		preconditioner: NewIdentityPreconditioner(),
+	}
+}
+
+// Returns the relative residual tolerance. The iteration has converged
+// when norm2(b - A*x) <= tolerance * norm2(b).
+func (s *iterativeSolver) Tolerance() float64 {
+	return s.tolerance
+}
+
+// Sets the relative residual tolerance to math.Abs(tolerance).
+func (s *iterativeSolver) SetTolerance(tolerance float64) {
+	s.tolerance = math.Abs(tolerance)
+}
+
+// Returns the maximum number of iterations.
+func (s *iterativeSolver) MaxIterations() int {
+	return s.maxIterations
+}
+
+// Sets the maximum number of iterations.
+func (s *iterativeSolver) SetMaxIterations(maxIterations int) {
+	s.maxIterations = maxIterations
+}
+
+// Returns the preconditioner.
+func (s *iterativeSolver) Preconditioner() Preconditioner {
+	return s.preconditioner
+}
+
+// Sets the preconditioner. A nil preconditioner is replaced by the
+// identity preconditioner.
+func (s *iterativeSolver) SetPreconditioner(preconditioner Preconditioner) {
+	if preconditioner == nil {
+		preconditioner = NewIdentityPreconditioner()
+	}
+	s.preconditioner = preconditioner
+}
+
+// Returns the residual norm norm2(b - A*x) of the initial guess followed
+// by that of each iteration of the last call to Solve.
+func (s *iterativeSolver) ResidualHistory() []float64 {
+	return s.residuals
+}
+
+// Returns the number of iterations performed by the last call to Solve.
+func (s *iterativeSolver) Iterations() int {
+	if len(s.residuals) == 0 {
+		return 0
+	}
+	return len(s.residuals) - 1
+}
+
+// Checks the arguments to Solve, prepares the preconditioner and returns
+// the initial guess x (zero if nil), the residual r = b - A*x and
+// norm2(b).
+func (s *iterativeSolver) setup(A *Matrix, b, x *Vector) (*Vector, *Vector, float64, error) {
+	n := A.Rows()
+	if A.Columns() != n {
+		return nil, nil, 0, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	if b.Size() != n {
+		return nil, nil, 0, fmt.Errorf("Incompatible args: %s, %s", A.StringShort(), b.StringShort())
+	}
+	if x == nil {
+		x = NewVector(n)
+	} else if x.Size() != n {
+		return nil, nil, 0, fmt.Errorf("Incompatible args: %s, %s", A.StringShort(), x.StringShort())
+	}
+	if err := s.preconditioner.SetMatrix(A); err != nil {
+		return nil, nil, 0, err
+	}
+	r := NewVector(n)
+	r.AssignVector(b)
+	if _, err := A.ZMultConst(x, r, -1, 1, false); err != nil {
+		return nil, nil, 0, err
+	}
+	s.residuals = []float64{norm2(r)}
+	return x, r, norm2(b), nil
+}
+
+// Records the given residual norm and returns whether the iteration has
+// converged.
+func (s *iterativeSolver) converged(residual, bnorm float64) bool {
+	s.residuals = append(s.residuals, residual)
+	return residual <= s.tolerance*bnorm
+}
+
+func (s *iterativeSolver) errNotConverged() error {
+	return fmt.Errorf("no convergence after %d iterations, residual: %g", s.Iterations(), s.residuals[len(s.residuals)-1])
+}
+
+// Returns Sqrt(Sum(x[i]^2)).
+func norm2(x *Vector) float64 {
+	return math.Sqrt(x.ZDotProduct(x))
+}
+
+// Computes y = alpha*x + y.
+func axpy(alpha float64, x, y *Vector) {
+	for i := 0; i < y.Size(); i++ {
+		y.SetQuick(i, alpha*x.GetQuick(i)+y.GetQuick(i))
+	}
+}
+
+// Preconditioned conjugate gradient method for symmetric positive definite
+// systems. The preconditioner must also be symmetric positive definite.
+type ConjugateGradient struct {
+	iterativeSolver
+}
+
+// Constructs and returns a new conjugate gradient solver with tolerance
+// 1e-10, at most 1000 iterations and no preconditioning.
+func NewConjugateGradient() *ConjugateGradient {
+	return &ConjugateGradient{newIterativeSolver()}
+}
+
+// Solves A*x = b. The given x is used as the initial guess, or zero if
+// nil, and is overwritten with the solution. If the iteration does not
+// converge within the maximum number of iterations the last iterate is
+// returned together with an error.
+func (s *ConjugateGradient) Solve(A *Matrix, b, x *Vector) (*Vector, error) {
+	x, r, bnorm, err := s.setup(A, b, x)
+	if err != nil {
+		return nil, err
+	}
+	if s.residuals[0] <= s.tolerance*bnorm {
+		return x, nil
+	}
+	n := A.Rows()
+	z := NewVector(n)
+	q := NewVector(n)
+	if err = s.preconditioner.Apply(r, z); err != nil {
+		return x, err
+	}
+	p := z.Copy()
+	rz := r.ZDotProduct(z)
+
+	for iter := 0; iter < s.maxIterations; iter++ {
+		if _, err = A.ZMult(p, q); err != nil {
+			return x, err
+		}
+		pq := p.ZDotProduct(q)
+		if pq == 0 {
+			return x, fmt.Errorf("breakdown: p'*A*p == 0 at iteration %d", iter)
+		}
+		alpha := rz / pq
+		axpy(alpha, p, x)
+		axpy(-alpha, q, r)
+		if s.converged(norm2(r), bnorm) {
+			return x, nil
+		}
+		if err = s.preconditioner.Apply(r, z); err != nil {
+			return x, err
+		}
+		rzOld := rz
+		rz = r.ZDotProduct(z)
+		beta := rz / rzOld
+		for i := 0; i < n; i++ {
+			p.SetQuick(i, z.GetQuick(i)+beta*p.GetQuick(i))
+		}
+	}
+	return x, s.errNotConverged()
+}
+
+// Biconjugate gradient stabilized method for general nonsymmetric
+// systems, with right preconditioning.
+type BiCGStab struct {
+	iterativeSolver
+}
+
+// Constructs and returns a new BiCGStab solver with tolerance 1e-10, at
+// most 1000 iterations and no preconditioning.
+func NewBiCGStab() *BiCGStab {
+	return &BiCGStab{newIterativeSolver()}
+}
+
+// Solves A*x = b. The given x is used as the initial guess, or zero if
+// nil, and is overwritten with the solution. If the iteration does not
+// converge within the maximum number of iterations, or breaks down, the
+// last iterate is returned together with an error.
+func (s *BiCGStab) Solve(A *Matrix, b, x *Vector) (*Vector, error) {
+	x, r, bnorm, err := s.setup(A, b, x)
+	if err != nil {
+		return nil, err
+	}
+	if s.residuals[0] <= s.tolerance*bnorm {
+		return x, nil
+	}
+	n := A.Rows()
+	rhat := r.Copy()
+	p := NewVector(n)
+	v := NewVector(n)
+	phat := NewVector(n)
+	shat := NewVector(n)
+	t := NewVector(n)
+	rho, alpha, omega := 1.0, 1.0, 1.0
+	eps := math.Pow(2, -52)
+
+	for iter := 0; iter < s.maxIterations; iter++ {
+		rhoOld := rho
+		rho = rhat.ZDotProduct(r)
+		if rho == 0 {
+			return x, fmt.Errorf("breakdown: rho == 0 at iteration %d", iter)
+		}
+		beta := (rho / rhoOld) * (alpha / omega)
+		for i := 0; i < n; i++ {
+			p.SetQuick(i, r.GetQuick(i)+beta*(p.GetQuick(i)-omega*v.GetQuick(i)))
+		}
+		if err = s.preconditioner.Apply(p, phat); err != nil {
+			return x, err
+		}
+		if _, err = A.ZMult(phat, v); err != nil {
+			return x, err
+		}
+		rv := rhat.ZDotProduct(v)
+		if math.Abs(rv) <= eps*norm2(rhat)*norm2(v) {
+			return x, fmt.Errorf("breakdown: rhat'*v == 0 at iteration %d", iter)
+		}
+		alpha = rho / rv
+
+		// s = r - alpha*v, held in r.
+		axpy(-alpha, v, r)
+		axpy(alpha, phat, x)
+		snorm := norm2(r)
+		if snorm <= s.tolerance*bnorm {
+			s.converged(snorm, bnorm)
+			return x, nil
+		}
+
+		if err = s.preconditioner.Apply(r, shat); err != nil {
+			return x, err
+		}
+		if _, err = A.ZMult(shat, t); err != nil {
+			return x, err
+		}
+		tt := t.ZDotProduct(t)
+		if tt == 0 {
+			return x, fmt.Errorf("breakdown: t == 0 at iteration %d", iter)
+		}
+		omega = t.ZDotProduct(r) / tt
+		axpy(omega, shat, x)
+		axpy(-omega, t, r)
+		if s.converged(norm2(r), bnorm) {
+			return x, nil
+		}
+		if omega == 0 {
+			return x, fmt.Errorf("breakdown: omega == 0 at iteration %d", iter)
+		}
+	}
+	return x, s.errNotConverged()
+}
+
+// Restarted generalized minimal residual method, GMRES(m), for general
+// nonsymmetric systems, with right preconditioning. The Krylov basis is
+// orthogonalized using modified Gram-Schmidt and the least squares
+// problem is updated using Givens rotations.
+type GMRES struct {
+	iterativeSolver
+	restart int
+}
+
+// Constructs and returns a new GMRES solver that restarts after the given
+// number of iterations, with tolerance 1e-10, at most 1000 iterations and
+// no preconditioning.
+func NewGMRES(restart int) *GMRES {
+	if restart < 1 {
+		restart = 1
+	}
+	return &GMRES{newIterativeSolver(), restart}
+}
+
+// Returns the number of iterations after which the method restarts.
+func (s *GMRES) Restart() int {
+	return s.restart
+}
+
+// Solves A*x = b. The given x is used as the initial guess, or zero if
+// nil, and is overwritten with the solution. If the iteration does not
+// converge within the maximum number of iterations the last iterate is
+// returned together with an error.
+func (s *GMRES) Solve(A *Matrix, b, x *Vector) (*Vector, error) {
+	x, r, bnorm, err := s.setup(A, b, x)
+	if err != nil {
+		return nil, err
+	}
+	if s.residuals[0] <= s.tolerance*bnorm {
+		return x, nil
+	}
+	n := A.Rows()
+	m := s.restart
+	if m > n {
+		m = n
+	}
+	V := make([]*Vector, m+1)
+	for i := range V {
+		V[i] = NewVector(n)
+	}
+	H := make([][]float64, m+1)
+	for i := range H {
+		H[i] = make([]float64, m)
+	}
+	cs := make([]float64, m)
+	sn := make([]float64, m)
+	g := make([]float64, m+1)
+	y := make([]float64, m)
+	z := NewVector(n)
+	w := NewVector(n)
+
+	iter := 0
+	for {
+		beta := norm2(r)
+		for i := 0; i < n; i++ {
+			V[0].SetQuick(i, r.GetQuick(i)/beta)
+		}
+		for i := range g {
+			g[i] = 0
+		}
+		g[0] = beta
+
+		k := 0
+		done := false
+		for k < m && iter < s.maxIterations {
+			iter++
+			if err = s.preconditioner.Apply(V[k], z); err != nil {
+				return x, err
+			}
+			if _, err = A.ZMult(z, w); err != nil {
+				return x, err
+			}
+			for i := 0; i <= k; i++ {
+				H[i][k] = w.ZDotProduct(V[i])
+				axpy(-H[i][k], V[i], w)
+			}
+			H[k+1][k] = norm2(w)
+			if H[k+1][k] != 0 {
+				for i := 0; i < n; i++ {
+					V[k+1].SetQuick(i, w.GetQuick(i)/H[k+1][k])
+				}
+			}
+
+			for i := 0; i < k; i++ {
+				h := cs[i]*H[i][k] + sn[i]*H[i+1][k]
+				H[i+1][k] = -sn[i]*H[i][k] + cs[i]*H[i+1][k]
+				H[i][k] = h
+			}
+			d := math.Hypot(H[k][k], H[k+1][k])
+			if d == 0 {
+				return x, fmt.Errorf("breakdown: singular Hessenberg matrix at iteration %d", iter)
+			}
+			cs[k] = H[k][k] / d
+			sn[k] = H[k+1][k] / d
+			H[k][k] = d
+			H[k+1][k] = 0
+			g[k+1] = -sn[k] * g[k]
+			g[k] = cs[k] * g[k]
+			k++
+
+			if s.converged(math.Abs(g[k]), bnorm) {
+				done = true
+				break
+			}
+		}
+
+		// Solve the upper triangular system H*y = g and update
+		// x = x + M^-1 * V*y.
+		for i := k - 1; i >= 0; i-- {
+			y[i] = g[i]
+			for j := i + 1; j < k; j++ {
+				y[i] -= H[i][j] * y[j]
+			}
+			y[i] /= H[i][i]
+		}
+		w.Assign(0)
+		for i := 0; i < k; i++ {
+			axpy(y[i], V[i], w)
+		}
+		if err = s.preconditioner.Apply(w, z); err != nil {
+			return x, err
+		}
+		axpy(1, z, x)
+
+		if done {
+			return x, nil
+		}
+		if iter >= s.maxIterations {
+			return x, s.errNotConverged()
+		}
+		r.AssignVector(b)
+		if _, err = A.ZMultConst(x, r, -1, 1, false); err != nil {
+			return x, err
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns the n x n matrix of the one dimensional Poisson equation, which
+// is symmetric positive definite and tridiagonal.
+func makePoissonMatrix(A *Matrix) *Matrix {
+	n := A.Rows()
+	for i := 0; i < n; i++ {
+		A.SetQuick(i, i, 2)
+		if i > 0 {
+			A.SetQuick(i, i-1, -1)
+		}
+		if i < n-1 {
+			A.SetQuick(i, i+1, -1)
+		}
+	}
+	return A
+}
+
+type iterativeSolverTest interface {
+	Solve(A *Matrix, b, x *Vector) (*Vector, error)
+	ResidualHistory() []float64
+	Iterations() int
+	Tolerance() float64
+}
+
+func testIterativeSolver(t *testing.T, s iterativeSolverTest, A *Matrix) {
+	n := A.Rows()
+	expected := NewVector(n)
+	for i := 0; i < n; i++ {
+		expected.SetQuick(i, rand.Float64())
+	}
+	b, _ := A.ZMult(expected, nil)
+	x, err := s.Solve(A, b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		if math.Abs(expected.GetQuick(i)-x.GetQuick(i)) > 1e-6 {
+			t.Errorf("expected:%g actual:%g", expected.GetQuick(i), x.GetQuick(i))
+		}
+	}
+
+	history := s.ResidualHistory()
+	if len(history) != s.Iterations()+1 {
+		t.Errorf("expected:%d actual:%d", s.Iterations()+1, len(history))
+	}
+	if history[len(history)-1] > s.Tolerance()*norm2(b) {
+		t.Errorf("expected residual <= %g actual:%g", s.Tolerance()*norm2(b), history[len(history)-1])
+	}
+
+	// The exact solution as initial guess converges immediately.
+	_, err = s.Solve(A, b, expected.Copy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Iterations() != 0 {
+		t.Errorf("expected:%d actual:%d", 0, s.Iterations())
+	}
+}
+
+func TestConjugateGradient(t *testing.T) {
+	testIterativeSolver(t, NewConjugateGradient(), makePoissonMatrix(NewSparseMatrix(50, 50)))
+	testIterativeSolver(t, NewConjugateGradient(), makeSPDMatrix(NewMatrix(nsquare, nsquare)))
+}
+
+func TestConjugateGradientMaxIterations(t *testing.T) {
+	s := NewConjugateGradient()
+	s.SetMaxIterations(3)
+	A := makePoissonMatrix(NewSparseMatrix(50, 50))
+	b := NewVector(50)
+	b.Assign(1)
+	_, err := s.Solve(A, b, nil)
+	if err == nil {
+		t.Errorf("expected error for too few iterations")
+	}
+	if s.Iterations() != 3 {
+		t.Errorf("expected:%d actual:%d", 3, s.Iterations())
+	}
+}
+
+func TestBiCGStab(t *testing.T) {
+	testIterativeSolver(t, NewBiCGStab(), makeSquareMatrix(NewSparseMatrix(nsquare, nsquare)))
+	testIterativeSolver(t, NewBiCGStab(), makePoissonMatrix(NewSparseRCMatrix(50, 50)))
+}
+
+func TestBiCGStabBreakdown(t *testing.T) {
+	// For a skew-symmetric A, rhat'*A*rhat == 0 in the first iteration.
+	A := NewMatrix(2, 2)
+	A.AssignArray([][]float64{
+		{0, 1},
+		{-1, 0},
+	})
+	b := NewVectorArray([]float64{1, 0})
+	x, err := NewBiCGStab().Solve(A, b, nil)
+	if err == nil {
+		t.Errorf("expected breakdown error")
+	}
+	for i := 0; i < x.Size(); i++ {
+		if math.IsNaN(x.GetQuick(i)) || math.IsInf(x.GetQuick(i), 0) {
+			t.Errorf("expected finite iterate, actual:%g", x.GetQuick(i))
+		}
+	}
+}
+
+func TestGMRES(t *testing.T) {
+	testIterativeSolver(t, NewGMRES(5), makeSquareMatrix(NewMatrix(nsquare, nsquare)))
+	testIterativeSolver(t, NewGMRES(20), makePoissonMatrix(NewSparseRCMatrix(50, 50)))
+}
+
+func TestIterativeSolverIncompatible(t *testing.T) {
+	A := NewMatrix(3, 4)
+	if _, err := NewGMRES(2).Solve(A, NewVector(3), nil); err == nil {
+		t.Errorf("expected error for non-square matrix")
+	}
+	A = NewMatrix(3, 3)
+	if _, err := NewBiCGStab().Solve(A, NewVector(4), nil); err == nil {
+		t.Errorf("expected error for incompatible vector")
+	}
+}
@@ -0,0 +1,77 @@
+package tfloat64
+
+// LU decomposition of a matrix. The decomposed matrix is copied, so the
+// argument passed to NewDenseLUDecomposition is left unchanged and the
+// solve methods return new results rather than overriding their
+// arguments. See DenseLUDecompositionQuick for the in-place variant.
+type DenseLUDecomposition struct {
+	quick *DenseLUDecompositionQuick
+}
+
+// Constructs and returns a new LU decomposition of a copy of the given
+// matrix.
+func NewDenseLUDecomposition(A *Matrix) *DenseLUDecomposition {
+	quick := NewDenseLUDecompositionQuick()
+	quick.Decompose(A.Copy())
+	return &DenseLUDecomposition{quick}
+}
+
+// Returns the determinant, det(A).
+func (d *DenseLUDecomposition) Det() (float64, error) {
+	return d.quick.Det()
+}
+
+// Returns whether the decomposed matrix is non-singular.
+func (d *DenseLUDecomposition) IsNonSingular() bool {
+	return d.quick.IsNonSingular()
+}
+
+// Returns the lower triangular factor, L.
+func (d *DenseLUDecomposition) L() *Matrix {
+	return d.quick.L()
+}
+
+// Returns the upper triangular factor, U.
+func (d *DenseLUDecomposition) U() *Matrix {
+	return d.quick.U()
+}
+
+// Returns a copy of the pivot permutation vector.
+func (d *DenseLUDecomposition) Pivot() []int {
+	return d.quick.Pivot()
+}
+
+// Solves A*x = b and returns x. The vector b is left unchanged.
+func (d *DenseLUDecomposition) Solve(b *Vector) (*Vector, error) {
+	x := b.Copy()
+	err := d.quick.Solve(x)
+	if err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// Solves A*X = B and returns X. The matrix B is left unchanged.
+func (d *DenseLUDecomposition) SolveMatrix(B *Matrix) (*Matrix, error) {
+	X := B.Copy()
+	err := d.quick.SolveMatrix(X)
+	if err != nil {
+		return nil, err
+	}
+	return X, nil
+}
+
+// Returns the inverse of the decomposed matrix, i.e. the solution X of
+// A*X = I.
+func (d *DenseLUDecomposition) Inverse() (*Matrix, error) {
+	n := d.quick.lu.Rows()
+	I := &Matrix{d.quick.lu.Like(n, n)}
+	for i := 0; i < n; i++ {
+		I.SetQuick(i, i, 1)
+	}
+	err := d.quick.SolveMatrix(I)
+	if err != nil {
+		return nil, err
+	}
+	return I, nil
+}
@@ -0,0 +1,371 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+)
+
+// A low level version of DenseLUDecomposition, avoiding unnecessary memory
+// allocation and copying. The input to Decompose methods is overridden
+// with the result (LU). The input to Solve methods is overridden with the
+// result (X).
+//
+// For an m x n matrix A with m >= n, the LU decomposition is an m x n
+// unit lower triangular matrix L, an n x n upper triangular matrix U, and a
+// permutation vector piv of length m so that A(piv,:) = L*U. Partial
+// pivoting by rows is used.
+//
+// The LU decomposition with pivoting always exists, even if the matrix is
+// singular, so Decompose will never fail. The primary use of the LU
+// decomposition is in the solution of square systems of simultaneous
+// linear equations. Solving will fail if IsNonSingular() returns false.
+//
+// All access to the matrix is via GetQuick/SetQuick so any backend (dense,
+// sparse or a view) may be decomposed.
+type DenseLUDecompositionQuick struct {
+	lu            *Matrix   // Array for internal storage of decomposition.
+	piv           []int     // Internal storage of pivot vector.
+	pivsign       int       // Sign of the pivot permutation.
+	isNonSingular bool      // Whether the decomposed matrix is non-singular.
+	tolerance     float64   // Relative threshold below which a pivot is treated as zero.
+	work          []float64 // Work array holding a single column.
+}
+
+// Constructs and returns a new LU decomposition object with the default
+// tolerance of the package Property; call Decompose to compute the
+// decomposition of a matrix.
+func NewDenseLUDecompositionQuick() *DenseLUDecompositionQuick {
+	return NewDenseLUDecompositionQuickTolerance(prop.tolerance)
+}
+
+// Constructs and returns a new LU decomposition object which uses the given
+// tolerance to determine whether a pivot element is zero.
+func NewDenseLUDecompositionQuickTolerance(tolerance float64) *DenseLUDecompositionQuick {
+	return &DenseLUDecompositionQuick{tolerance: tolerance}
+}
+
+// Decomposes matrix A into L and U (in-place). Upon return A is overridden
+// with the result LU, such that L*U = A(piv,:). Uses a "left-looking",
+// dot-product, Crout/Doolittle algorithm.
+func (d *DenseLUDecompositionQuick) Decompose(A *Matrix) {
+	m := A.Rows()
+	n := A.Columns()
+
+	// setup
+	d.lu = A
+	if len(d.piv) != m {
+		d.piv = make([]int, m)
+	}
+	for i := 0; i < m; i++ {
+		d.piv[i] = i
+	}
+	d.pivsign = 1
+	if len(d.work) < m {
+		d.work = make([]float64, m)
+	}
+	LUcolj := d.work[:m]
+
+	// Outer loop.
+	for j := 0; j < n; j++ {
+		// Make a copy of the j-th column to localize references.
+		for i := 0; i < m; i++ {
+			LUcolj[i] = A.GetQuick(i, j)
+		}
+
+		// Apply previous transformations.
+		for i := 0; i < m; i++ {
+			// Most of the time is spent in the following dot product.
+			kmax := i
+			if j < kmax {
+				kmax = j
+			}
+			s := 0.0
+			for k := 0; k < kmax; k++ {
+				s += A.GetQuick(i, k) * LUcolj[k]
+			}
+			LUcolj[i] -= s
+			A.SetQuick(i, j, LUcolj[i])
+		}
+
+		// Find pivot and exchange if necessary.
+		p := j
+		if p < m {
+			max := math.Abs(LUcolj[p])
+			for i := j + 1; i < m; i++ {
+				v := math.Abs(LUcolj[i])
+				if v > max {
+					p = i
+					max = v
+				}
+			}
+		}
+		if p != j {
+			for k := 0; k < n; k++ {
+				tmp := A.GetQuick(p, k)
+				A.SetQuick(p, k, A.GetQuick(j, k))
+				A.SetQuick(j, k, tmp)
+			}
+			d.piv[p], d.piv[j] = d.piv[j], d.piv[p]
+			d.pivsign = -d.pivsign
+		}
+
+		// Compute multipliers.
+		if j < m {
+			jj := A.GetQuick(j, j)
+			if jj != 0 {
+				for i := j + 1; i < m; i++ {
+					A.SetQuick(i, j, A.GetQuick(i, j)/jj)
+				}
+			}
+		}
+	}
+	d.setNonSingular()
+}
+
+// Sets the internal state from a matrix that already holds a combined LU
+// decomposition, e.g. one computed by a previous call to Decompose, and
+// the pivot vector that belongs to it. A nil piv stands for a
+// decomposition without row exchanges. Returns an error if piv is not a
+// permutation of the rows of LU.
+func (d *DenseLUDecompositionQuick) SetLU(LU *Matrix, piv []int) error {
+	m := LU.Rows()
+	if piv == nil {
+		piv = make([]int, m)
+		for i := range piv {
+			piv[i] = i
+		}
+	}
+	if len(piv) != m {
+		return fmt.Errorf("Pivot vector must have one entry per row: %d, %s", len(piv), LU.StringShort())
+	}
+	seen := make([]bool, m)
+	for _, p := range piv {
+		if p < 0 || p >= m || seen[p] {
+			return fmt.Errorf("Pivot vector is not a permutation: %v", piv)
+		}
+		seen[p] = true
+	}
+
+	// The sign of a permutation is (-1)^(m - number of cycles).
+	pivsign := 1
+	for i := range seen {
+		seen[i] = false
+	}
+	for i := 0; i < m; i++ {
+		if seen[i] {
+			continue
+		}
+		for j := i; !seen[j]; j = piv[j] {
+			seen[j] = true
+			if piv[j] != i {
+				pivsign = -pivsign
+			}
+		}
+	}
+
+	d.lu = LU
+	d.piv = make([]int, m)
+	copy(d.piv, piv)
+	d.pivsign = pivsign
+	d.setNonSingular()
+	return nil
+}
+
+// A pivot is treated as zero if it is no larger in magnitude than the
+// tolerance times the largest magnitude in U, so that the test does not
+// depend on the scale of the matrix.
+func (d *DenseLUDecompositionQuick) setNonSingular() {
+	d.isNonSingular = true
+	n := d.lu.Rows()
+	if d.lu.Columns() < n {
+		n = d.lu.Columns()
+	}
+	norm := 0.0
+	for i := 0; i < n; i++ {
+		for j := i; j < d.lu.Columns(); j++ {
+			norm = math.Max(norm, math.Abs(d.lu.GetQuick(i, j)))
+		}
+	}
+	for j := 0; j < n; j++ {
+		if math.Abs(d.lu.GetQuick(j, j)) <= d.tolerance*norm {
+			d.isNonSingular = false
+			return
+		}
+	}
+}
+
+// Returns the determinant, det(A). Returns an error if the decomposed
+// matrix is not square.
+func (d *DenseLUDecompositionQuick) Det() (float64, error) {
+	m := d.lu.Rows()
+	n := d.lu.Columns()
+	if m != n {
+		return math.NaN(), fmt.Errorf("Matrix must be square: %s", d.lu.StringShort())
+	}
+	if !d.isNonSingular {
+		return 0, nil // avoid rounding errors
+	}
+	det := float64(d.pivsign)
+	for j := 0; j < n; j++ {
+		det *= d.lu.GetQuick(j, j)
+	}
+	return det, nil
+}
+
+// Returns whether the decomposed matrix is non-singular, i.e. whether no
+// diagonal element of U is smaller in magnitude than the tolerance times
+// the largest magnitude in U.
+func (d *DenseLUDecompositionQuick) IsNonSingular() bool {
+	return d.isNonSingular
+}
+
+// Returns the lower triangular factor, L. The returned matrix has
+// Rows() x min(Rows(), Columns()) cells and a unit diagonal.
+func (d *DenseLUDecompositionQuick) L() *Matrix {
+	m := d.lu.Rows()
+	n := d.lu.Columns()
+	if m < n {
+		n = m
+	}
+	L := &Matrix{d.lu.Like(m, n)}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if i > j {
+				L.SetQuick(i, j, d.lu.GetQuick(i, j))
+			} else if i == j {
+				L.SetQuick(i, j, 1)
+			}
+		}
+	}
+	return L
+}
+
+// Returns the upper triangular factor, U. The returned matrix has
+// min(Rows(), Columns()) x Columns() cells.
+func (d *DenseLUDecompositionQuick) U() *Matrix {
+	m := d.lu.Rows()
+	n := d.lu.Columns()
+	if n < m {
+		m = n
+	}
+	U := &Matrix{d.lu.Like(m, n)}
+	for i := 0; i < m; i++ {
+		for j := i; j < n; j++ {
+			U.SetQuick(i, j, d.lu.GetQuick(i, j))
+		}
+	}
+	return U
+}
+
+// Returns the combined lower and upper triangular factors. This is the
+// matrix passed to Decompose and is not a copy.
+func (d *DenseLUDecompositionQuick) LU() *Matrix {
+	return d.lu
+}
+
+// Returns a copy of the pivot permutation vector.
+func (d *DenseLUDecompositionQuick) Pivot() []int {
+	piv := make([]int, len(d.piv))
+	copy(piv, d.piv)
+	return piv
+}
+
+func (d *DenseLUDecompositionQuick) checkSolve(rows int) error {
+	if d.lu.Rows() != d.lu.Columns() {
+		return fmt.Errorf("Matrix must be square: %s", d.lu.StringShort())
+	}
+	if rows != d.lu.Rows() {
+		return fmt.Errorf("Matrix dimensions must agree: %s, %d", d.lu.StringShort(), rows)
+	}
+	if !d.isNonSingular {
+		return fmt.Errorf("Matrix is singular")
+	}
+	return nil
+}
+
+// Solves the system of equations A*x = b (in-place). Upon return b is
+// overridden with the result x, such that L*U*x = b(piv).
+func (d *DenseLUDecompositionQuick) Solve(b *Vector) error {
+	err := d.checkSolve(b.Size())
+	if err != nil {
+		return err
+	}
+	n := d.lu.Columns()
+
+	// b = b(piv)
+	if len(d.work) < n {
+		d.work = make([]float64, n)
+	}
+	work := d.work[:n]
+	for i := 0; i < n; i++ {
+		work[i] = b.GetQuick(d.piv[i])
+	}
+
+	// Solve L*Y = b(piv)
+	for k := 0; k < n; k++ {
+		f := work[k]
+		if f != 0 {
+			for i := k + 1; i < n; i++ {
+				work[i] -= f * d.lu.GetQuick(i, k)
+			}
+		}
+	}
+
+	// Solve U*b = Y
+	for k := n - 1; k >= 0; k-- {
+		work[k] /= d.lu.GetQuick(k, k)
+		f := work[k]
+		if f != 0 {
+			for i := 0; i < k; i++ {
+				work[i] -= f * d.lu.GetQuick(i, k)
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		b.SetQuick(i, work[i])
+	}
+	return nil
+}
+
+// Solves the system of equations A*X = B (in-place). Upon return B is
+// overridden with the result X, such that L*U*X = B(piv,:).
+func (d *DenseLUDecompositionQuick) SolveMatrix(B *Matrix) error {
+	err := d.checkSolve(B.Rows())
+	if err != nil {
+		return err
+	}
+	n := d.lu.Columns()
+	if len(d.work) < n {
+		d.work = make([]float64, n)
+	}
+	work := d.work[:n]
+
+	// Each column of B is an independent right hand side.
+	for c := 0; c < B.Columns(); c++ {
+		for i := 0; i < n; i++ {
+			work[i] = B.GetQuick(d.piv[i], c)
+		}
+		for k := 0; k < n; k++ {
+			f := work[k]
+			if f != 0 {
+				for i := k + 1; i < n; i++ {
+					work[i] -= f * d.lu.GetQuick(i, k)
+				}
+			}
+		}
+		for k := n - 1; k >= 0; k-- {
+			work[k] /= d.lu.GetQuick(k, k)
+			f := work[k]
+			if f != 0 {
+				for i := 0; i < k; i++ {
+					work[i] -= f * d.lu.GetQuick(i, k)
+				}
+			}
+		}
+		for i := 0; i < n; i++ {
+			B.SetQuick(i, c, work[i])
+		}
+	}
+	return nil
+}
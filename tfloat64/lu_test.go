@@ -0,0 +1,189 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const nsquare = 11
+
+// Fills A with random values and strengthens its diagonal so that the
+// result is well conditioned.
+func makeSquareMatrix(A *Matrix) *Matrix {
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			A.SetQuick(r, c, rand.Float64())
+		}
+		A.SetQuick(r, r, A.GetQuick(r, r)+float64(A.Rows()))
+	}
+	return A
+}
+
+func testLUDecomposition(t *testing.T, A *Matrix) {
+	lu := NewDenseLUDecomposition(A)
+	if !lu.IsNonSingular() {
+		t.Fatal("expected non-singular")
+	}
+
+	// A(piv,:) == L*U
+	LU, _ := lu.L().ZMultMatrix(lu.U(), nil)
+	piv := lu.Pivot()
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := A.GetQuick(piv[r], c)
+			actual := LU.GetQuick(r, c)
+			if math.Abs(expected-actual) > tol {
+				t.Errorf("expected:%g actual:%g", expected, actual)
+			}
+		}
+	}
+
+	b := NewVector(A.Rows())
+	for i := 0; i < b.Size(); i++ {
+		b.SetQuick(i, rand.Float64())
+	}
+	x, err := lu.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Ax, _ := A.ZMult(x, nil)
+	for i := 0; i < b.Size(); i++ {
+		if math.Abs(b.GetQuick(i)-Ax.GetQuick(i)) > tol {
+			t.Errorf("expected:%g actual:%g", b.GetQuick(i), Ax.GetQuick(i))
+		}
+	}
+
+	inv, err := lu.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	I, _ := A.ZMultMatrix(inv, nil)
+	for r := 0; r < I.Rows(); r++ {
+		for c := 0; c < I.Columns(); c++ {
+			expected := 0.0
+			if r == c {
+				expected = 1
+			}
+			if math.Abs(expected-I.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", expected, I.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func testLUDecompositionDet(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{2, 0, 1},
+		{1, 3, 2},
+		{1, 1, 2},
+	})
+	det, err := NewDenseLUDecomposition(A).Det()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(6-det) > tol {
+		t.Errorf("expected:%g actual:%g", 6.0, det)
+	}
+
+	A.AssignArray([][]float64{
+		{1, 2, 3},
+		{2, 4, 6},
+		{1, 1, 1},
+	})
+	lu := NewDenseLUDecomposition(A)
+	if lu.IsNonSingular() {
+		t.Errorf("expected singular")
+	}
+	if _, err := lu.Solve(NewVector(3)); err == nil {
+		t.Errorf("expected error solving singular system")
+	}
+}
+
+func TestDenseLUDecomposition(t *testing.T) {
+	A := makeSquareMatrix(NewMatrix(nsquare, nsquare))
+	testLUDecomposition(t, A)
+}
+
+func TestDenseLUDecompositionDet(t *testing.T) {
+	testLUDecompositionDet(t, NewMatrix(3, 3))
+}
+
+func TestSparseLUDecomposition(t *testing.T) {
+	A := makeSquareMatrix(NewSparseMatrix(nsquare, nsquare))
+	testLUDecomposition(t, A)
+}
+
+func TestSparseLUDecompositionDet(t *testing.T) {
+	testLUDecompositionDet(t, NewSparseMatrix(3, 3))
+}
+
+func TestDenseLUDecompositionView(t *testing.T) {
+	A := makeSquareMatrix(NewMatrix(nsquare, nsquare)).ViewDice()
+	testLUDecomposition(t, A)
+}
+
+func testLUDecompositionScaled(t *testing.T, A *Matrix) {
+	for i := 0; i < A.Rows(); i++ {
+		A.SetQuick(i, i, 1e-10)
+	}
+	lu := NewDenseLUDecompositionQuick()
+	lu.Decompose(A)
+	if !lu.IsNonSingular() {
+		t.Errorf("expected non-singular")
+	}
+	det, _ := lu.Det()
+	expected := math.Pow(1e-10, float64(A.Rows()))
+	if math.Abs(expected-det) > expected*tol {
+		t.Errorf("expected:%g actual:%g", expected, det)
+	}
+}
+
+func TestDenseLUDecompositionScaled(t *testing.T) {
+	testLUDecompositionScaled(t, NewMatrix(3, 3))
+}
+
+func TestSparseLUDecompositionScaled(t *testing.T) {
+	testLUDecompositionScaled(t, NewSparseMatrix(3, 3))
+}
+
+func TestDenseLUDecompositionQuickSetLU(t *testing.T) {
+	// Reversing the rows of a diagonally dominant matrix forces row exchanges.
+	A := makeSquareMatrix(NewMatrix(nsquare, nsquare)).ViewRowFlip().Copy()
+	lu := NewDenseLUDecompositionQuick()
+	LU := A.Copy()
+	lu.Decompose(LU)
+	expected, _ := lu.Det()
+	piv := lu.Pivot()
+
+	// A fresh decomposition object must use the given pivots, not the
+	// identity or any left over from an earlier decomposition.
+	other := NewDenseLUDecompositionQuick()
+	other.Decompose(makeSquareMatrix(NewMatrix(nsquare, nsquare)))
+	if err := other.SetLU(LU, piv); err != nil {
+		t.Fatal(err)
+	}
+	det, _ := other.Det()
+	if math.Abs(expected-det) > math.Abs(expected)*tol {
+		t.Errorf("expected:%g actual:%g", expected, det)
+	}
+	b := NewRandomVector(nsquare)
+	x := b.Copy()
+	if err := other.Solve(x); err != nil {
+		t.Fatal(err)
+	}
+	Ax, _ := A.ZMult(x, nil)
+	for i := 0; i < b.Size(); i++ {
+		if math.Abs(b.GetQuick(i)-Ax.GetQuick(i)) > tol {
+			t.Errorf("expected:%g actual:%g", b.GetQuick(i), Ax.GetQuick(i))
+		}
+	}
+
+	if err := other.SetLU(LU, []int{0, 0}); err == nil {
+		t.Errorf("expected error for a short pivot vector")
+	}
+	piv[0] = piv[1]
+	if err := other.SetLU(LU, piv); err == nil {
+		t.Errorf("expected error for a pivot vector that is not a permutation")
+	}
+}
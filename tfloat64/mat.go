@@ -10,4 +10,13 @@ type Mat interface {
 
 	Like(int, int) Mat
 	LikeVector(size int) Vec
+
+	// Returns a vector view of size cells, the first at index zero of
+	// the elements and the others stride apart, sharing the elements of
+	// the receiver.
+	Like1D(size, zero, stride int) Vec
+
+	// Returns a new backend sharing the elements of the receiver whose
+	// shape may be modified independently.
+	View() Mat
 }
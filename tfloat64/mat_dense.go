@@ -25,3 +25,28 @@ func (m *DenseMat) Like(rows, columns int) Mat {
 		make([]float64, rows*columns),
 	}
 }
+
+func (m *DenseMat) LikeVector(size int) Vec {
+	return NewVector(size).Vec
+}
+
+func (m *DenseMat) Like1D(size, zero, stride int) Vec {
+	return &DenseVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.elements,
+	}
+}
+
+func (m *DenseMat) View() Mat {
+	return &DenseMat{m.CoreMat.View(), m.elements}
+}
+
+func (m *DenseMat) ViewSelectionLike(rowOffsets, columnOffsets []int) Mat {
+	return &SelectedDenseMat{
+		&DenseMat{
+			common.NewCoreMat(true, len(rowOffsets), len(columnOffsets), 1, 1, 0, 0),
+			m.elements,
+		},
+		rowOffsets, columnOffsets, 0,
+	}
+}
@@ -30,3 +30,28 @@ func (m *SparseMat) Like(rows, columns int) Mat {
 		make(map[int]float64),
 	}
 }
+
+func (m *SparseMat) LikeVector(size int) Vec {
+	return NewSparseVector(size).Vec
+}
+
+func (m *SparseMat) Like1D(size, zero, stride int) Vec {
+	return &SparseVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.elements,
+	}
+}
+
+func (m *SparseMat) View() Mat {
+	return &SparseMat{m.CoreMat.View(), m.elements}
+}
+
+func (m *SparseMat) ViewSelectionLike(rowOffsets, columnOffsets []int) Mat {
+	return &SelectedSparseMat{
+		&SparseMat{
+			common.NewCoreMat(true, len(rowOffsets), len(columnOffsets), 1, 1, 0, 0),
+			m.elements,
+		},
+		rowOffsets, columnOffsets, 0,
+	}
+}
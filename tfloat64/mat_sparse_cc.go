@@ -0,0 +1,305 @@
+package tfloat64
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rwl/goshawk/common"
+)
+
+// Sparse column-compressed matrix backend. The non-zero cells are held in
+// compressed column storage (CCS, also known as CSC): the row indexes and
+// values of column c are rowIndexes[columnPointers[c]:columnPointers[c+1]]
+// and values[columnPointers[c]:columnPointers[c+1]], with the row indexes
+// of each column kept in ascending order.
+//
+// Column access and transpose multiplication visit only the non-zero
+// cells of the columns involved. Setting a previously zero cell shifts all
+// subsequent cells, so a matrix should be assembled column by column in
+// ascending row order or built from another backend using
+// NewSparseCCMatrixMat.
+type SparseCCMat struct {
+	*common.CoreMat
+	columnPointers []int     // Offsets of the first cell of each column; len == columns+1.
+	rowIndexes     []int     // Row index of each non-zero cell.
+	values         []float64 // Value of each non-zero cell.
+}
+
+func newSparseCCMat(rows, columns int) *SparseCCMat {
+	return &SparseCCMat{
+		common.NewCoreMat(false, rows, columns, columns, 1, 0, 0),
+		make([]int, columns+1),
+		make([]int, 0),
+		make([]float64, 0),
+	}
+}
+
+// Returns the position of the given cell within rowIndexes and values and
+// whether the cell is non-zero. If the cell is zero the position at which
+// it would be inserted is returned.
+func (m *SparseCCMat) search(row, column int) (int, bool) {
+	low := m.columnPointers[column]
+	high := m.columnPointers[column+1]
+	k := low + sort.SearchInts(m.rowIndexes[low:high], row)
+	return k, k < high && m.rowIndexes[k] == row
+}
+
+func (m *SparseCCMat) GetQuick(row, column int) float64 {
+	k, found := m.search(row, column)
+	if !found {
+		return 0
+	}
+	return m.values[k]
+}
+
+func (m *SparseCCMat) SetQuick(row, column int, value float64) {
+	k, found := m.search(row, column)
+	if found {
+		if value == 0 {
+			m.remove(column, k)
+		} else {
+			m.values[k] = value
+		}
+		return
+	}
+	if value == 0 {
+		return
+	}
+	m.rowIndexes = append(m.rowIndexes, 0)
+	copy(m.rowIndexes[k+1:], m.rowIndexes[k:])
+	m.rowIndexes[k] = row
+
+	m.values = append(m.values, 0)
+	copy(m.values[k+1:], m.values[k:])
+	m.values[k] = value
+
+	for c := column + 1; c < len(m.columnPointers); c++ {
+		m.columnPointers[c]++
+	}
+}
+
+func (m *SparseCCMat) remove(column, k int) {
+	m.rowIndexes = append(m.rowIndexes[:k], m.rowIndexes[k+1:]...)
+	m.values = append(m.values[:k], m.values[k+1:]...)
+	for c := column + 1; c < len(m.columnPointers); c++ {
+		m.columnPointers[c]--
+	}
+}
+
+// Returns the values of the non-zero cells.
+func (m *SparseCCMat) Elements() interface{} {
+	return m.values
+}
+
+func (m *SparseCCMat) Like(rows, columns int) Mat {
+	return newSparseCCMat(rows, columns)
+}
+
+func (m *SparseCCMat) LikeVector(size int) Vec {
+	return &SparseVec{
+		common.NewCoreVec(false, size, 0, 1),
+		make(map[int]float64),
+	}
+}
+
+func (m *SparseCCMat) Like1D(size, zero, stride int) Vec {
+	return &MatrixVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m, nil,
+	}
+}
+
+// Returns a wrapper of the receiver whose shape may be modified
+// independently. The cells of the view are mapped through Index onto
+// the receiver, so flipped, diced, strided and partial views read and
+// write the right cells of the compressed storage.
+func (m *SparseCCMat) View() Mat {
+	return &WrapperMat{m.CoreMat.View(), m}
+}
+
+// Returns the column pointers of the compressed column storage. The slice
+// is backed by this matrix and must not be modified.
+func (m *SparseCCMat) ColumnPointers() []int {
+	return m.columnPointers
+}
+
+// Returns the row indexes of the compressed column storage. The slice is
+// backed by this matrix and must not be modified.
+func (m *SparseCCMat) RowIndexes() []int {
+	return m.rowIndexes
+}
+
+// Returns the values of the compressed column storage. The slice is
+// backed by this matrix; values may be modified in place.
+func (m *SparseCCMat) Values() []float64 {
+	return m.values
+}
+
+// Returns the number of non-zero cells.
+func (m *SparseCCMat) NonZeroCount() int {
+	return m.columnPointers[m.Columns()]
+}
+
+// Returns the row indexes and values of the non-zero cells of the given
+// column, in ascending row order. The slices are backed by this matrix.
+func (m *SparseCCMat) ColumnNonZeros(column int) ([]int, []float64) {
+	low := m.columnPointers[column]
+	high := m.columnPointers[column+1]
+	return m.rowIndexes[low:high], m.values[low:high]
+}
+
+// Applies the given function to each non-zero cell in column major order
+// and replaces the cell value with the result. Cells for which the
+// function returns zero are removed from the storage.
+func (m *SparseCCMat) ForEachNonZero(function IntIntFloat64Func) {
+	k := 0
+	low := 0
+	for c := 0; c < m.Columns(); c++ {
+		high := m.columnPointers[c+1]
+		for ; low < high; low++ {
+			value := function(m.rowIndexes[low], c, m.values[low])
+			if value != 0 {
+				m.rowIndexes[k] = m.rowIndexes[low]
+				m.values[k] = value
+				k++
+			}
+		}
+		m.columnPointers[c+1] = k
+	}
+	m.rowIndexes = m.rowIndexes[:k]
+	m.values = m.values[:k]
+}
+
+// Applies the given procedure to each non-zero cell in column major order,
+// without modifying the storage. Returns false if the procedure returned
+// false for some cell, in which case the iteration stopped at that cell.
+func (m *SparseCCMat) ForEachNonZeroProcedure(procedure IntIntFloat64Procedure) bool {
+	for c := 0; c < m.Columns(); c++ {
+		for k := m.columnPointers[c]; k < m.columnPointers[c+1]; k++ {
+			if !procedure(m.rowIndexes[k], c, m.values[k]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Computes z = alpha * A * y + beta * z, or z = alpha * A' * y + beta * z
+// if transposeA is true, visiting only the non-zero cells of A.
+func (m *SparseCCMat) ZMultConst(y, z *Vector, alpha, beta float64, transposeA bool) (*Vector, error) {
+	rows := m.Rows()
+	columns := m.Columns()
+	if transposeA {
+		rows, columns = columns, rows
+	}
+	if z == nil {
+		z = &Vector{y.Like(rows)}
+	}
+	if columns != y.Size() || rows > z.Size() {
+		return z, fmt.Errorf("Incompatible args: %s, %s, %s", m.StringShort(), y.StringShort(), z.StringShort())
+	}
+
+	if transposeA {
+		for c := 0; c < rows; c++ {
+			s := 0.0
+			for k := m.columnPointers[c]; k < m.columnPointers[c+1]; k++ {
+				s += m.values[k] * y.GetQuick(m.rowIndexes[k])
+			}
+			z.SetQuick(c, alpha*s+beta*z.GetQuick(c))
+		}
+		return z, nil
+	}
+
+	for i := 0; i < rows; i++ {
+		z.SetQuick(i, beta*z.GetQuick(i))
+	}
+	for c := 0; c < m.Columns(); c++ {
+		yc := alpha * y.GetQuick(c)
+		if yc == 0 {
+			continue
+		}
+		for k := m.columnPointers[c]; k < m.columnPointers[c+1]; k++ {
+			r := m.rowIndexes[k]
+			z.SetQuick(r, z.GetQuick(r)+m.values[k]*yc)
+		}
+	}
+	return z, nil
+}
+
+// Returns a new dense matrix with the same cell values as this matrix.
+func (m *SparseCCMat) Dense() *Matrix {
+	D := NewMatrix(m.Rows(), m.Columns())
+	m.copyTo(D)
+	return D
+}
+
+// Returns a new hash based sparse matrix with the same cell values as
+// this matrix.
+func (m *SparseCCMat) Sparse() *Matrix {
+	S := NewSparseMatrix(m.Rows(), m.Columns())
+	m.copyTo(S)
+	return S
+}
+
+// Returns a new matrix in compressed row storage with the same cell
+// values as this matrix.
+func (m *SparseCCMat) RowCompressed() *Matrix {
+	rc := newSparseRCMat(m.Rows(), m.Columns())
+	rc.rowPointers, rc.columnIndexes, rc.values = transposeCompressed(m.Rows(), m.columnPointers, m.rowIndexes, m.values)
+	return &Matrix{rc}
+}
+
+func (m *SparseCCMat) copyTo(A *Matrix) {
+	for c := 0; c < m.Columns(); c++ {
+		for k := m.columnPointers[c]; k < m.columnPointers[c+1]; k++ {
+			A.SetQuick(m.rowIndexes[k], c, m.values[k])
+		}
+	}
+}
+
+// Returns a new matrix in compressed column storage with the same cell
+// values as this matrix.
+func (m *SparseRCMat) ColumnCompressed() *Matrix {
+	cc := newSparseCCMat(m.Rows(), m.Columns())
+	cc.columnPointers, cc.rowIndexes, cc.values = transposeCompressed(m.Columns(), m.rowPointers, m.columnIndexes, m.values)
+	return &Matrix{cc}
+}
+
+// Converts between compressed row and compressed column storage. Given
+// the pointers, indexes and values of a matrix compressed along one
+// dimension, with n the size of the other dimension, returns the same
+// matrix compressed along the other dimension. Indexes in the result are
+// in ascending order.
+func transposeCompressed(n int, pointers, indexes []int, values []float64) ([]int, []int, []float64) {
+	nnz := len(values)
+	tpointers := make([]int, n+1)
+	tindexes := make([]int, nnz)
+	tvalues := make([]float64, nnz)
+	for k := 0; k < nnz; k++ {
+		tpointers[indexes[k]+1]++
+	}
+	for i := 0; i < n; i++ {
+		tpointers[i+1] += tpointers[i]
+	}
+	next := make([]int, n)
+	copy(next, tpointers[:n])
+	for j := 0; j+1 < len(pointers); j++ {
+		for k := pointers[j]; k < pointers[j+1]; k++ {
+			i := indexes[k]
+			tindexes[next[i]] = j
+			tvalues[next[i]] = values[k]
+			next[i]++
+		}
+	}
+	return tpointers, tindexes, tvalues
+}
+
+// Returns the cells of A in compressed column storage. A is returned as is
+// if it already is a compressed column matrix; the cells of the other
+// sparse backends are converted without visiting zero cells.
+func columnCompressed(A Mat) *SparseCCMat {
+	if m, ok := A.(*SparseCCMat); ok {
+		return m
+	}
+	return rowCompressed(A).ColumnCompressed().Mat.(*SparseCCMat)
+}
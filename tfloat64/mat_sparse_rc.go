@@ -0,0 +1,273 @@
+package tfloat64
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rwl/goshawk/common"
+)
+
+// Sparse row-compressed matrix backend. The non-zero cells are held in
+// compressed row storage (CRS, also known as CSR): the column indexes and
+// values of row r are columnIndexes[rowPointers[r]:rowPointers[r+1]] and
+// values[rowPointers[r]:rowPointers[r+1]], with the column indexes of each
+// row kept in ascending order.
+//
+// Iterating over the non-zeros of a row is a slice traversal and
+// GetQuick is a binary search within the row. Setting a previously zero
+// cell shifts all subsequent cells, so a matrix should be assembled
+// row by row in ascending column order or built from another backend
+// using NewSparseRCMatrixMat.
+type SparseRCMat struct {
+	*common.CoreMat
+	rowPointers   []int     // Offsets of the first cell of each row; len == rows+1.
+	columnIndexes []int     // Column index of each non-zero cell.
+	values        []float64 // Value of each non-zero cell.
+}
+
+func newSparseRCMat(rows, columns int) *SparseRCMat {
+	return &SparseRCMat{
+		common.NewCoreMat(false, rows, columns, columns, 1, 0, 0),
+		make([]int, rows+1),
+		make([]int, 0),
+		make([]float64, 0),
+	}
+}
+
+// Returns the position of the given cell within columnIndexes and values
+// and whether the cell is non-zero. If the cell is zero the position
+// at which it would be inserted is returned.
+func (m *SparseRCMat) search(row, column int) (int, bool) {
+	low := m.rowPointers[row]
+	high := m.rowPointers[row+1]
+	k := low + sort.SearchInts(m.columnIndexes[low:high], column)
+	return k, k < high && m.columnIndexes[k] == column
+}
+
+func (m *SparseRCMat) GetQuick(row, column int) float64 {
+	k, found := m.search(row, column)
+	if !found {
+		return 0
+	}
+	return m.values[k]
+}
+
+func (m *SparseRCMat) SetQuick(row, column int, value float64) {
+	k, found := m.search(row, column)
+	if found {
+		if value == 0 {
+			m.remove(row, k)
+		} else {
+			m.values[k] = value
+		}
+		return
+	}
+	if value == 0 {
+		return
+	}
+	m.columnIndexes = append(m.columnIndexes, 0)
+	copy(m.columnIndexes[k+1:], m.columnIndexes[k:])
+	m.columnIndexes[k] = column
+
+	m.values = append(m.values, 0)
+	copy(m.values[k+1:], m.values[k:])
+	m.values[k] = value
+
+	for r := row + 1; r < len(m.rowPointers); r++ {
+		m.rowPointers[r]++
+	}
+}
+
+func (m *SparseRCMat) remove(row, k int) {
+	m.columnIndexes = append(m.columnIndexes[:k], m.columnIndexes[k+1:]...)
+	m.values = append(m.values[:k], m.values[k+1:]...)
+	for r := row + 1; r < len(m.rowPointers); r++ {
+		m.rowPointers[r]--
+	}
+}
+
+// Returns the values of the non-zero cells.
+func (m *SparseRCMat) Elements() interface{} {
+	return m.values
+}
+
+func (m *SparseRCMat) Like(rows, columns int) Mat {
+	return newSparseRCMat(rows, columns)
+}
+
+func (m *SparseRCMat) LikeVector(size int) Vec {
+	return &SparseVec{
+		common.NewCoreVec(false, size, 0, 1),
+		make(map[int]float64),
+	}
+}
+
+func (m *SparseRCMat) Like1D(size, zero, stride int) Vec {
+	return &MatrixVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m, nil,
+	}
+}
+
+// Returns a wrapper of the receiver whose shape may be modified
+// independently. The cells of the view are mapped through Index onto
+// the receiver, so flipped, diced, strided and partial views read and
+// write the right cells of the compressed storage.
+func (m *SparseRCMat) View() Mat {
+	return &WrapperMat{m.CoreMat.View(), m}
+}
+
+// Returns the row pointers of the compressed row storage. The slice is
+// backed by this matrix and must not be modified.
+func (m *SparseRCMat) RowPointers() []int {
+	return m.rowPointers
+}
+
+// Returns the column indexes of the compressed row storage. The slice is
+// backed by this matrix and must not be modified.
+func (m *SparseRCMat) ColumnIndexes() []int {
+	return m.columnIndexes
+}
+
+// Returns the values of the compressed row storage. The slice is backed
+// by this matrix; values may be modified in place.
+func (m *SparseRCMat) Values() []float64 {
+	return m.values
+}
+
+// Returns the number of non-zero cells.
+func (m *SparseRCMat) NonZeroCount() int {
+	return m.rowPointers[m.Rows()]
+}
+
+// Returns the column indexes and values of the non-zero cells of the
+// given row, in ascending column order. The slices are backed by this
+// matrix.
+func (m *SparseRCMat) RowNonZeros(row int) ([]int, []float64) {
+	low := m.rowPointers[row]
+	high := m.rowPointers[row+1]
+	return m.columnIndexes[low:high], m.values[low:high]
+}
+
+// Applies the given function to each non-zero cell in row major order and
+// replaces the cell value with the result. Cells for which the function
+// returns zero are removed from the storage.
+func (m *SparseRCMat) ForEachNonZero(function IntIntFloat64Func) {
+	k := 0
+	low := 0
+	for r := 0; r < m.Rows(); r++ {
+		high := m.rowPointers[r+1]
+		for ; low < high; low++ {
+			value := function(r, m.columnIndexes[low], m.values[low])
+			if value != 0 {
+				m.columnIndexes[k] = m.columnIndexes[low]
+				m.values[k] = value
+				k++
+			}
+		}
+		m.rowPointers[r+1] = k
+	}
+	m.columnIndexes = m.columnIndexes[:k]
+	m.values = m.values[:k]
+}
+
+// Applies the given procedure to each non-zero cell in row major order,
+// without modifying the storage. Returns false if the procedure returned
+// false for some cell, in which case the iteration stopped at that cell.
+func (m *SparseRCMat) ForEachNonZeroProcedure(procedure IntIntFloat64Procedure) bool {
+	for r := 0; r < m.Rows(); r++ {
+		for k := m.rowPointers[r]; k < m.rowPointers[r+1]; k++ {
+			if !procedure(r, m.columnIndexes[k], m.values[k]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Computes z = alpha * A * y + beta * z, or z = alpha * A' * y + beta * z
+// if transposeA is true, visiting only the non-zero cells of A.
+func (m *SparseRCMat) ZMultConst(y, z *Vector, alpha, beta float64, transposeA bool) (*Vector, error) {
+	rows := m.Rows()
+	columns := m.Columns()
+	if transposeA {
+		rows, columns = columns, rows
+	}
+	if z == nil {
+		z = &Vector{y.Like(rows)}
+	}
+	if columns != y.Size() || rows > z.Size() {
+		return z, fmt.Errorf("Incompatible args: %s, %s, %s", m.StringShort(), y.StringShort(), z.StringShort())
+	}
+
+	if transposeA {
+		for i := 0; i < rows; i++ {
+			z.SetQuick(i, beta*z.GetQuick(i))
+		}
+		for r := 0; r < m.Rows(); r++ {
+			yr := alpha * y.GetQuick(r)
+			if yr == 0 {
+				continue
+			}
+			for k := m.rowPointers[r]; k < m.rowPointers[r+1]; k++ {
+				c := m.columnIndexes[k]
+				z.SetQuick(c, z.GetQuick(c)+m.values[k]*yr)
+			}
+		}
+		return z, nil
+	}
+
+	for r := 0; r < rows; r++ {
+		s := 0.0
+		for k := m.rowPointers[r]; k < m.rowPointers[r+1]; k++ {
+			s += m.values[k] * y.GetQuick(m.columnIndexes[k])
+		}
+		z.SetQuick(r, alpha*s+beta*z.GetQuick(r))
+	}
+	return z, nil
+}
+
+// Returns a new dense matrix with the same cell values as this matrix.
+func (m *SparseRCMat) Dense() *Matrix {
+	D := NewMatrix(m.Rows(), m.Columns())
+	m.copyTo(D)
+	return D
+}
+
+// Returns a new hash based sparse matrix with the same cell values as
+// this matrix.
+func (m *SparseRCMat) Sparse() *Matrix {
+	S := NewSparseMatrix(m.Rows(), m.Columns())
+	m.copyTo(S)
+	return S
+}
+
+func (m *SparseRCMat) copyTo(A *Matrix) {
+	for r := 0; r < m.Rows(); r++ {
+		for k := m.rowPointers[r]; k < m.rowPointers[r+1]; k++ {
+			A.SetQuick(r, m.columnIndexes[k], m.values[k])
+		}
+	}
+}
+
+// Returns the cells of A in compressed row storage. A is returned as is if
+// it already is a compressed row matrix; the cells of the other sparse
+// backends are converted without visiting zero cells.
+func rowCompressed(A Mat) *SparseRCMat {
+	switch m := A.(type) {
+	case *SparseRCMat:
+		return m
+	case *SparseCCMat:
+		return m.RowCompressed().Mat.(*SparseRCMat)
+	case *SparseMat:
+		if !m.IsView() {
+			columns := m.Columns()
+			b := NewTripletBuilderCapacity(m.Rows(), columns, len(m.elements))
+			for index, value := range m.elements {
+				b.Append(index/columns, index%columns, value)
+			}
+			return b.compress()
+		}
+	}
+	return NewSparseRCMatrixMat(A).Mat.(*SparseRCMat)
+}
@@ -0,0 +1,69 @@
+package tfloat64
+
+import "github.com/rwl/goshawk/common"
+
+// Selection view on sparse 2-d matrices holding float64 elements, as
+// returned by the slice, row and column views of a selected cube.
+//
+// The row and column zeros and strides index into the offset arrays
+// rather than into the elements. Cell addressing overhead is 2
+// additional array index accesses per get/set.
+type SelectedSparseMat struct {
+	*SparseMat
+	rowOffsets    []int // The offsets of the visible rows of this matrix.
+	columnOffsets []int // The offsets of the visible columns of this matrix.
+	offset        int   // The offset.
+}
+
+func (m *SelectedSparseMat) GetQuick(row, column int) float64 {
+	return m.elements[m.Index(row, column)]
+}
+
+func (m *SelectedSparseMat) SetQuick(row, column int, value float64) {
+	index := m.Index(row, column)
+	if value == 0 {
+		delete(m.elements, index)
+	} else {
+		m.elements[index] = value
+	}
+}
+
+func (m *SelectedSparseMat) Index(row, column int) int {
+	return m.offset + m.rowOffsets[m.RowZero()+row*m.RowStride()] +
+		m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()]
+}
+
+func (m *SelectedSparseMat) View() Mat {
+	return &SelectedSparseMat{
+		&SparseMat{m.CoreMat.View(), m.elements},
+		m.rowOffsets, m.columnOffsets, m.offset,
+	}
+}
+
+// Transposes the axes and their offsets.
+func (m *SelectedSparseMat) VDice() {
+	m.CoreMat.VDice()
+	m.rowOffsets, m.columnOffsets = m.columnOffsets, m.rowOffsets
+}
+
+// Constructs and returns a new selection view of the given row.
+func (m *SelectedSparseMat) ViewRow(row int) Vec {
+	return &SelectedSparseVec{
+		&SparseVec{
+			common.NewCoreVec(true, m.Columns(), m.ColumnZero(), m.ColumnStride()),
+			m.elements,
+		},
+		m.columnOffsets, m.offset + m.rowOffsets[m.RowZero()+row*m.RowStride()],
+	}
+}
+
+// Constructs and returns a new selection view of the given column.
+func (m *SelectedSparseMat) ViewColumn(column int) Vec {
+	return &SelectedSparseVec{
+		&SparseVec{
+			common.NewCoreVec(true, m.Rows(), m.RowZero(), m.RowStride()),
+			m.elements,
+		},
+		m.rowOffsets, m.offset + m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()],
+	}
+}
@@ -3,18 +3,25 @@ package tfloat64
 
 import "github.com/rwl/goshawk/common"
 
-
+// Matrix backend wrapping another matrix. The index of a cell is its
+// position in the row major layout of the wrapped matrix, so views of
+// the wrapper read and write the cells of the wrapped matrix through
+// GetQuick and SetQuick.
 type WrapperMat struct {
 	*common.CoreMat
 	content Mat // The elements of the matrix.
 }
 
 func (m *WrapperMat) GetQuick(row, column int) float64 {
-	return m.content.GetQuick(row, column)
+	i := m.Index(row, column)
+	columns := m.content.Columns()
+	return m.content.GetQuick(i/columns, i%columns)
 }
 
 func (m *WrapperMat) SetQuick(row, column int, value float64) {
-	m.content.SetQuick(row, column)
+	i := m.Index(row, column)
+	columns := m.content.Columns()
+	m.content.SetQuick(i/columns, i%columns, value)
 }
 
 func (m *WrapperMat) Elements() interface{} {
@@ -28,3 +35,88 @@ func (m *WrapperMat) Like(rows, columns int) Mat {
 func (m *WrapperMat) LikeVector(size int) Vec {
 	return m.content.LikeVector(size)
 }
+
+func (m *WrapperMat) Like1D(size, zero, stride int) Vec {
+	return &MatrixVec{
+		common.NewCoreVec(true, size, zero, stride),
+		m.content, nil,
+	}
+}
+
+func (m *WrapperMat) View() Mat {
+	return &WrapperMat{m.CoreMat.View(), m.content}
+}
+
+func (m *WrapperMat) ViewSelectionLike(rowOffsets, columnOffsets []int) Mat {
+	return &SelectedWrapperMat{
+		&WrapperMat{
+			common.NewCoreMat(true, len(rowOffsets), len(columnOffsets), 1, 1, 0, 0),
+			m.content,
+		},
+		rowOffsets, columnOffsets,
+	}
+}
+
+// Selection view on a wrapped matrix. The row and column zeros and
+// strides index into the offset arrays, and the sum of the offsets is
+// the position of the cell in the row major layout of the wrapped
+// matrix.
+type SelectedWrapperMat struct {
+	*WrapperMat
+	rowOffsets    []int // The offsets of the visible rows of this matrix.
+	columnOffsets []int // The offsets of the visible columns of this matrix.
+}
+
+func (m *SelectedWrapperMat) GetQuick(row, column int) float64 {
+	i := m.Index(row, column)
+	columns := m.content.Columns()
+	return m.content.GetQuick(i/columns, i%columns)
+}
+
+func (m *SelectedWrapperMat) SetQuick(row, column int, value float64) {
+	i := m.Index(row, column)
+	columns := m.content.Columns()
+	m.content.SetQuick(i/columns, i%columns, value)
+}
+
+func (m *SelectedWrapperMat) Index(row, column int) int {
+	return m.rowOffsets[m.RowZero()+row*m.RowStride()] +
+		m.columnOffsets[m.ColumnZero()+column*m.ColumnStride()]
+}
+
+func (m *SelectedWrapperMat) View() Mat {
+	return &SelectedWrapperMat{
+		&WrapperMat{m.CoreMat.View(), m.content},
+		m.rowOffsets, m.columnOffsets,
+	}
+}
+
+// Transposes the axes and their offsets.
+func (m *SelectedWrapperMat) VDice() {
+	m.CoreMat.VDice()
+	m.rowOffsets, m.columnOffsets = m.columnOffsets, m.rowOffsets
+}
+
+// Constructs and returns a new selection view of the given row.
+func (m *SelectedWrapperMat) ViewRow(row int) Vec {
+	offsets := make([]int, m.Columns())
+	for c := range offsets {
+		offsets[c] = m.Index(row, c)
+	}
+	return &MatrixVec{
+		common.NewCoreVec(true, len(offsets), 0, 1),
+		m.content, offsets,
+	}
+}
+
+// Constructs and returns a new selection view of the given column.
+func (m *SelectedWrapperMat) ViewColumn(column int) Vec {
+	offsets := make([]int, m.Rows())
+	for r := range offsets {
+		offsets[r] = m.Index(r, column)
+	}
+	return &MatrixVec{
+		common.NewCoreVec(true, len(offsets), 0, 1),
+		m.content, offsets,
+	}
+}
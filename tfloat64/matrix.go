@@ -4,12 +4,49 @@ import (
 	"fmt"
 	"math"
 	"errors"
+
+	"github.com/rwl/goshawk/common"
 )
 
 type Matrix struct {
 	Mat
 }
 
+// Implemented by backends that can visit their non-zero cells without
+// scanning every coordinate.
+type nonZeroMat interface {
+	ForEachNonZero(IntIntFloat64Func)
+}
+
+// Implemented by backends that can visit their non-zero cells, without
+// scanning every coordinate and without modifying the storage.
+type nonZeroProcedureMat interface {
+	ForEachNonZeroProcedure(IntIntFloat64Procedure) bool
+}
+
+// Implemented by backends whose rows and columns cannot be viewed with
+// Like1D, such as selection views.
+type lineViewMat interface {
+	ViewRow(int) Vec
+	ViewColumn(int) Vec
+}
+
+// Implemented by backends that can construct selection views from
+// element offsets.
+type selectionMat interface {
+	Index(int, int) int
+
+	// Returns a selection view sharing the elements of the receiver,
+	// where cell [r,c] of the view is the element at
+	// rowOffsets[r]+columnOffsets[c].
+	ViewSelectionLike(rowOffsets, columnOffsets []int) Mat
+}
+
+// Implemented by backends with a specialised matrix-vector product.
+type zMultMat interface {
+	ZMultConst(y, z *Vector, alpha, beta float64, transposeA bool) (*Vector, error)
+}
+
 // Returns a string representation using default formatting.
 func (m *Matrix) String() string {
 	return fmtr.MatrixToString(m)
@@ -69,6 +106,10 @@ func (m *Matrix) ToArray() [][]float64 {
 }
 
 func (m *Matrix) ForEachNonZero(function IntIntFloat64Func) *Matrix {
+	if nz, ok := m.Mat.(nonZeroMat); ok {
+		nz.ForEachNonZero(function)
+		return m
+	}
 	for r := 0; r < m.Rows(); r++ {
 		for c := 0; c < m.Columns(); c++ {
 			value := m.GetQuick(r, c)
@@ -187,27 +228,28 @@ func (m *Matrix) Normalize() *Matrix {
 	return m
 }
 
-func (m *Matrix) ViewColumn(column int) (*Matrix, error) {
-	err := m.CheckColumn(column)
-	if err != nil {
-		return nil , err
+// Constructs and returns a new view of the given column. The view shares
+// the cells of the receiver.
+func (m *Matrix) ViewColumn(column int) (*Vector, error) {
+	if err := m.checkColumn(column); err != nil {
+		return nil, err
 	}
-	viewSize := m.Rows()
-	viewZero := m.Index(0, column)
-	viewStride := m.RowStride()
-	return m.Like1D(viewSize, viewZero, viewStride)
+	if lv, ok := m.Mat.(lineViewMat); ok {
+		return &Vector{lv.ViewColumn(column)}, nil
+	}
+	return &Vector{m.Like1D(m.Rows(), m.Index(0, column), m.RowStride())}, nil
 }
 
 func (m *Matrix) ViewColumnFlip() *Matrix {
 	v := m.View()
 	v.VColumnFlip()
-	return v
+	return &Matrix{v}
 }
 
 func (m *Matrix) ViewDice() *Matrix {
 	v := m.View()
 	v.VDice()
-	return v
+	return &Matrix{v}
 }
 
 func (m *Matrix) ViewPart(row, column, height, width int) (*Matrix, error) {
@@ -216,69 +258,96 @@ func (m *Matrix) ViewPart(row, column, height, width int) (*Matrix, error) {
 	if err != nil {
 		return m, err
 	}
-	return v, nil
+	return &Matrix{v}, nil
 }
 
+// Constructs and returns a new view of the given row. The view shares
+// the cells of the receiver.
 func (m *Matrix) ViewRow(row int) (*Vector, error) {
-	err := m.CheckRow(row)
-	if err != nil {
+	if err := m.checkRow(row); err != nil {
 		return nil, err
 	}
-	viewSize := m.Columns()
-	viewZero := m.Index(row, 0)
-	viewStride := m.ColumnStride()
-	r := m.Like1D(viewSize, viewZero, viewStride)
-	return r, nil
+	if lv, ok := m.Mat.(lineViewMat); ok {
+		return &Vector{lv.ViewRow(row)}, nil
+	}
+	return &Vector{m.Like1D(m.Columns(), m.Index(row, 0), m.ColumnStride())}, nil
 }
 
-func (m *Matrix) ViewRowFlip() (*Matrix) {
+func (m *Matrix) ViewRowFlip() *Matrix {
 	v := m.View()
-	v.vRowFlip()
-	return v
+	v.VRowFlip()
+	return &Matrix{v}
 }
 
+// Returns a selection view holding the rows for which condition yields
+// true when applied to the row view, together with all columns.
 func (m *Matrix) ViewSelectionProcedure(condition VectorProcedure) *Matrix {
 	matches := make([]int, 0)
 	for i := 0; i < m.Rows(); i++ {
-		if condition(m.ViewRow(i)) {
+		row, _ := m.ViewRow(i)
+		if condition(row.Vec) {
 			matches = append(matches, i)
 		}
 	}
-	return m.ViewSelection(matches, nil) // take all columns
+	view, _ := m.ViewSelection(matches, nil) // take all columns
+	return view
 }
 
+// Returns a selection view holding the indicated rows and columns, with
+// view.Get(r,c) == m.Get(rowIndexes[r], columnIndexes[c]). Indexes can
+// occur multiple times and can be in arbitrary order. A nil list selects
+// all indexes of that axis. The view shares the cells of the matrix;
+// modifying the index lists after the call has no effect on the view.
 func (m *Matrix) ViewSelection(rowIndexes, columnIndexes []int) (*Matrix, error) {
-	// check for "all"
-	if rowIndexes == nil {
-		rowIndexes = make([]int, m.Rows())
-		for i := 0; i < m.Rows(); i++ {
-			rowIndexes[i] = i
-		}
-	}
-	if columnIndexes == nil {
-		columnIndexes = make([]int, m.Columns())
-		for i := 0; i < m.Columns(); i++ {
-			columnIndexes[i] = i
-		}
-	}
-
-	err := m.CheckRowIndexes(rowIndexes)
+	rowIndexes, err := selectionIndexes(rowIndexes, m.Rows(), m.checkRow)
 	if err != nil {
 		return nil, err
 	}
-	err = m.CheckColumnIndexes(columnIndexes)
+	columnIndexes, err = selectionIndexes(columnIndexes, m.Columns(), m.checkColumn)
 	if err != nil {
 		return nil, err
 	}
+	sm, ok := m.Mat.(selectionMat)
+	if !ok {
+		wm := &WrapperMat{
+			common.NewCoreMat(false, m.Rows(), m.Columns(), m.Columns(), 1, 0, 0),
+			m.Mat,
+		}
+		sm = wm
+	}
 	rowOffsets := make([]int, len(rowIndexes))
 	columnOffsets := make([]int, len(columnIndexes))
-	for i := 0; i < len(rowIndexes); i++ {
-		rowOffsets[i] = _rowOffset(_rowRank(rowIndexes[i]))
+	if len(rowIndexes) > 0 && len(columnIndexes) > 0 {
+		base := sm.Index(0, 0)
+		for i, r := range rowIndexes {
+			rowOffsets[i] = sm.Index(r, 0)
+		}
+		for i, c := range columnIndexes {
+			columnOffsets[i] = sm.Index(0, c) - base
+		}
 	}
-	for i := 0; i < len(columnIndexes); i++ {
-		columnOffsets[i] = _columnOffset(_columnRank(columnIndexes[i]))
+	return &Matrix{sm.ViewSelectionLike(rowOffsets, columnOffsets)}, nil
+}
+
+// Returns a symmetric permuted view B of the square matrix, with
+// B[i,j] == A[p[i],p[j]], e.g. for an ordering returned by RCMOrder or
+// AMDOrder. Returns an error if p is not a permutation of the rows.
+func (m *Matrix) ViewPermuted(p []int) (*Matrix, error) {
+	n := m.Rows()
+	if m.Columns() != n {
+		return nil, fmt.Errorf("Matrix must be square: %s", m.StringShort())
+	}
+	if len(p) != n {
+		return nil, fmt.Errorf("Invalid permutation length: %d, %s", len(p), m.StringShort())
+	}
+	seen := make([]bool, n)
+	for _, i := range p {
+		if i < 0 || i >= n || seen[i] {
+			return nil, fmt.Errorf("Invalid permutation: %v", p)
+		}
+		seen[i] = true
 	}
-	return m.ViewSelectionLike(rowOffsets, columnOffsets)
+	return m.ViewSelection(p, p)
 }
 
 /*func (m *Matrix) ViewSorted(column int) *Matrix {
@@ -291,7 +360,7 @@ func (m *Matrix) ViewStrides(rowStride, columnStride int) (*Matrix, error) {
 	if err != nil {
 		return m, err
 	}
-	return v, nil
+	return &Matrix{v}, nil
 }
 
 func (m *Matrix) ZMult(y, z *Vector) (*Vector, error) {
@@ -299,17 +368,17 @@ func (m *Matrix) ZMult(y, z *Vector) (*Vector, error) {
 }
 
 func (m *Matrix) ZMultConst(y, z *Vector, alpha, beta float64, transposeA bool) (*Vector, error) {
+	if zm, ok := m.Mat.(zMultMat); ok {
+		return zm.ZMultConst(y, z, alpha, beta, transposeA)
+	}
 	if transposeA {
 		return m.ViewDice().ZMultConst(y, z, alpha, beta, false)
 	}
-	var zz *Vector
 	if z == nil {
-		zz = y.LikeVector(m.Rows())
-	} else {
-		zz = z
+		z = &Vector{y.Like(m.Rows())}
 	}
-	if m.Columns() != y.Size() || m.Rows() > zz.Size() {
-		return zz, fmt.Errorf("Incompatible args: %s, %s, %s", m.StringShort(), y.StringShort(), zz.StringShort())
+	if m.Columns() != y.Size() || m.Rows() > z.Size() {
+		return z, fmt.Errorf("Incompatible args: %s, %s, %s", m.StringShort(), y.StringShort(), z.StringShort())
 	}
 
 	for r := 0; r < m.Rows(); r++ {
@@ -317,9 +386,9 @@ func (m *Matrix) ZMultConst(y, z *Vector, alpha, beta float64, transposeA bool)
 		for c := 0; c < m.Columns(); c++ {
 			s += m.GetQuick(r, c) * y.GetQuick(c)
 		}
-		zz.SetQuick(r, alpha * s + beta * zz.getQuick(r))
+		z.SetQuick(r, alpha*s+beta*z.GetQuick(r))
 	}
-	return zz, nil
+	return z, nil
 }
 
 func (m *Matrix) ZMultMatrix(B, C *Matrix) (*Matrix, error) {
@@ -328,41 +397,38 @@ func (m *Matrix) ZMultMatrix(B, C *Matrix) (*Matrix, error) {
 
 func (m *Matrix) ZMultMatrixConst(B, C *Matrix, alpha, beta float64, transposeA, transposeB bool) (*Matrix, error) {
 	if transposeA {
-		return m.ViewDice().ZMultmatrixConst(B, C, alpha, beta, false, transposeB)
+		return m.ViewDice().ZMultMatrixConst(B, C, alpha, beta, false, transposeB)
 	}
 	if transposeB {
 		return m.ZMultMatrixConst(B.ViewDice(), C, alpha, beta, transposeA, false)
 	}
 
-	m := m.Rows()
+	rows := m.Rows()
 	n := m.Columns()
 	p := B.Columns()
-	var CC *Matrix
 	if C == nil {
-		CC = m.Like(m, p)
-	} else {
-		CC = C
+		C = &Matrix{m.Like(rows, p)}
 	}
-	if B.Rows != n {
-		return CC, fmt.Errorf("Matrix2D inner dimensions must agree: %s, %s", m.StringShort(), B.StringShort())
+	if B.Rows() != n {
+		return C, fmt.Errorf("Matrix2D inner dimensions must agree: %s, %s", m.StringShort(), B.StringShort())
 	}
-	if CC.Rows() != m || CC.Columns() != p {
-		return CC, fmt.Errorf("Incompatibe result matrix: %s, %s, %s", m.StringShort(), B.StringShort(), CC.toStringShort())
+	if C.Rows() != rows || C.Columns() != p {
+		return C, fmt.Errorf("Incompatibe result matrix: %s, %s, %s", m.StringShort(), B.StringShort(), C.StringShort())
 	}
-	if m == CC || B == CC {
-		return CC, errors.New("Matrices must not be identical")
+	if m.Mat == C.Mat || B.Mat == C.Mat {
+		return C, errors.New("Matrices must not be identical")
 	}
 
 	for a := 0; a < p; a++ {
-		for b := 0; b < m; b++ {
+		for b := 0; b < rows; b++ {
 			s := 0.0
 			for c := 0; c < n; c++ {
 				s += m.GetQuick(b, c) * B.GetQuick(c, a)
 			}
-			CC.SetQuick(b, a, alpha * s + beta * CC.GetQuick(b, a))
+			C.SetQuick(b, a, alpha*s+beta*C.GetQuick(b, a))
 		}
 	}
-	return CC, nil
+	return C, nil
 }
 
 func (m *Matrix) ZSum() float64 {
@@ -371,3 +437,24 @@ func (m *Matrix) ZSum() float64 {
 	}
 	return m.Aggregate(Plus, Identity)
 }
+
+func (m *Matrix) checkShape(other Mat) error {
+	if m.Rows() != other.Rows() || m.Columns() != other.Columns() {
+		return fmt.Errorf("Incompatible dimensions: %s and %s", m.StringShort(), other.StringShort())
+	}
+	return nil
+}
+
+func (m *Matrix) checkRow(row int) error {
+	if row < 0 || row >= m.Rows() {
+		return fmt.Errorf("Attempted to access %s at row=%d", m.StringShort(), row)
+	}
+	return nil
+}
+
+func (m *Matrix) checkColumn(column int) error {
+	if column < 0 || column >= m.Columns() {
+		return fmt.Errorf("Attempted to access %s at column=%d", m.StringShort(), column)
+	}
+	return nil
+}
@@ -24,8 +24,8 @@ func (m *Matrix) Aggregate(aggr Float64Float64Func, f Float64Func) float64 {
 			} else {
 				idx1 = idx0 + k
 			}
-			go func() {
-				b := f(m.GetQuick(0, 0))
+			go func(idx0, idx1 int) {
+				b := f(m.GetQuick(idx0, 0))
 				d := 1 // First cell already done.
 				for r := idx0; r < idx1; r++ {
 					for c := d; c < m.Columns(); c++ {
@@ -34,7 +34,7 @@ func (m *Matrix) Aggregate(aggr Float64Float64Func, f Float64Func) float64 {
 					d = 0
 				}
 				ch <- b
-			}()
+			}(idx0, idx1)
 		}
 		a = <-ch
 		for j := 1; j < n; j++ {
@@ -71,9 +71,9 @@ func (m *Matrix) AggregateProcedure(aggr Float64Float64Func, f Float64Func, cond
 			} else {
 				idx1 = idx0 + k
 			}
-			go func() {
+			go func(idx0, idx1 int) {
 				elem := m.GetQuick(idx0, 0)
-				b := 0
+				b := 0.0
 				if cond(elem) {
 					b = aggr(b, f(elem))
 				}
@@ -88,14 +88,14 @@ func (m *Matrix) AggregateProcedure(aggr Float64Float64Func, f Float64Func, cond
 					d = 0;
 				}
 				ch <- b
-			}()
+			}(idx0, idx1)
 		}
 		a = <-ch
 		for j := 1; j < n; j++ {
 			a = aggr(a, <-ch)
 		}
 	} else {
-		a := 0.0
+		a = 0.0
 		elem := m.GetQuick(0, 0)
 		if cond(elem) {
 			a = aggr(a, f(elem))
@@ -133,14 +133,14 @@ func (m *Matrix) AggregateProcedureSelection(aggr Float64Float64Func, f Float64F
 			} else {
 				idx1 = idx0 + k
 			}
-			go func() {
+			go func(idx0, idx1 int) {
 				b := f(m.GetQuick(rowList[idx0], columnList[idx0]))
 				for i := idx0 + 1; i < idx1; i++ {
 					elem := m.GetQuick(rowList[i], columnList[i])
 					b = aggr(b, f(elem))
 				}
 				ch <- b
-			}()
+			}(idx0, idx1)
 		}
 		a = <-ch
 		for j := 1; j < n; j++ {
@@ -178,7 +178,7 @@ func (m *Matrix) AggregateMatrix(other Mat, aggr Float64Float64Func, f Float64Fl
 			} else {
 				idx1 = idx0 + k
 			}
-			go func() {
+			go func(idx0, idx1 int) {
 				a := f(m.GetQuick(idx0, 0), other.GetQuick(idx0, 0))
 				d := 1
 				for r := idx0; r < idx1; r++ {
@@ -187,15 +187,15 @@ func (m *Matrix) AggregateMatrix(other Mat, aggr Float64Float64Func, f Float64Fl
 					}
 					d = 0
 				}
-				ch <- d
-			}()
+				ch <- a
+			}(idx0, idx1)
 		}
 		a = <-ch
 		for j := 1; j < n; j++ {
 			a = aggr(a, <-ch)
 		}
 	} else {
-		a := f(m.GetQuick(0, 0), other.GetQuick(0, 0))
+		a = f(m.GetQuick(0, 0), other.GetQuick(0, 0))
 		d := 1 // First cell already done.
 		for r := 0; r < m.Rows(); r++ {
 			for c := d; c < m.Columns(); c++ {
@@ -40,7 +40,7 @@ func testMatrixAssignArray(t *testing.T, A assignArrayMatrix) {
 	A.AssignArray(expected)
 	for r := 0; r < A.Rows(); r++ {
 		if len(expected[r]) != A.Columns() {
-			t.Errorf("expected:%g actual:%g", len(expected[r]), A.Columns())
+			t.Errorf("expected:%d actual:%d", len(expected[r]), A.Columns())
 		}
 		for c := 0; c < A.Columns(); c++ {
 			if math.Abs(expected[r][c] - A.GetQuick(r, c)) > tol {
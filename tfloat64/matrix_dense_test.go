@@ -88,3 +88,118 @@ func TestDenseMatrixAssignProcedureFunc(t *testing.T) {
 	A := makeDenseMatrix()
 	testMatrixAssignProcedureFunc(t, A)
 }
+
+// Matrix tests.
+
+func TestDenseMatrixCardinality(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixCardinality(t, A)
+}
+
+func TestDenseMatrixEquals(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixEquals(t, A)
+}
+
+func TestDenseMatrixEqualsMatrix(t *testing.T) {
+	A := makeDenseMatrix()
+	B := makeDenseMatrix()
+	testMatrixEqualsMatrix(t, A, B)
+}
+
+func TestDenseMatrixForEachNonZero(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixForEachNonZero(t, A)
+}
+
+func TestDenseMatrixMaxLocation(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixMaxLocation(t, A)
+}
+
+func TestDenseMatrixMinLocation(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixMinLocation(t, A)
+}
+
+func TestDenseMatrixNegativeValues(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixNegativeValues(t, A)
+}
+
+func TestDenseMatrixNonZeros(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixNonZeros(t, A)
+}
+
+func TestDenseMatrixPositiveValues(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixPositiveValues(t, A)
+}
+
+func TestDenseMatrixToArray(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixToArray(t, A)
+}
+
+func TestDenseMatrixZMult(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixZMult(t, A)
+}
+
+func TestDenseMatrixZMultMatrix(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixZMultMatrix(t, A)
+}
+
+func TestDenseMatrixZSum(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixZSum(t, A)
+}
+
+// View tests.
+
+func TestDenseMatrixViewColumn(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewColumn(t, A)
+}
+
+func TestDenseMatrixViewColumnFlip(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewColumnFlip(t, A)
+}
+
+func TestDenseMatrixViewDice(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewDice(t, A)
+}
+
+func TestDenseMatrixViewPart(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewPart(t, A)
+}
+
+func TestDenseMatrixViewRow(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewRow(t, A)
+}
+
+func TestDenseMatrixViewRowFlip(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewRowFlip(t, A)
+}
+
+func TestDenseMatrixViewSelectionProcedure(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewSelectionVectorProcedure(t, A)
+}
+
+func TestDenseMatrixViewSelection(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewSelection(t, A)
+}
+
+func TestDenseMatrixViewStrides(t *testing.T) {
+	A := makeDenseMatrix()
+	testMatrixViewStrides(t, A)
+}
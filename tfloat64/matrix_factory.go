@@ -22,3 +22,49 @@ func NewSparseMatrix(rows, columns int) *Matrix {
 		},
 	}
 }
+
+// Returns a new sparse matrix with the given number of rows and columns,
+// held in compressed row storage.
+func NewSparseRCMatrix(rows, columns int) *Matrix {
+	return &Matrix{newSparseRCMat(rows, columns)}
+}
+
+// Returns a new sparse matrix, held in compressed row storage, with the
+// same cell values as the given matrix.
+func NewSparseRCMatrixMat(A Mat) *Matrix {
+	m := newSparseRCMat(A.Rows(), A.Columns())
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			value := A.GetQuick(r, c)
+			if value != 0 {
+				m.columnIndexes = append(m.columnIndexes, c)
+				m.values = append(m.values, value)
+			}
+		}
+		m.rowPointers[r+1] = len(m.values)
+	}
+	return &Matrix{m}
+}
+
+// Returns a new sparse matrix with the given number of rows and columns,
+// held in compressed column storage.
+func NewSparseCCMatrix(rows, columns int) *Matrix {
+	return &Matrix{newSparseCCMat(rows, columns)}
+}
+
+// Returns a new sparse matrix, held in compressed column storage, with
+// the same cell values as the given matrix.
+func NewSparseCCMatrixMat(A Mat) *Matrix {
+	m := newSparseCCMat(A.Rows(), A.Columns())
+	for c := 0; c < A.Columns(); c++ {
+		for r := 0; r < A.Rows(); r++ {
+			value := A.GetQuick(r, c)
+			if value != 0 {
+				m.rowIndexes = append(m.rowIndexes, r)
+				m.values = append(m.values, value)
+			}
+		}
+		m.columnPointers[c+1] = len(m.values)
+	}
+	return &Matrix{m}
+}
@@ -0,0 +1,425 @@
+package tfloat64
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Matrix Market object formats, fields and symmetries.
+const (
+	MatrixMarketCoordinate    = "coordinate"     // Sparse format listing the non-zero cells as (row, column, value).
+	MatrixMarketArray         = "array"          // Dense format listing all cells in column-major order.
+	MatrixMarketReal          = "real"           // Cell values are floating point numbers.
+	MatrixMarketInteger       = "integer"        // Cell values are integers.
+	MatrixMarketPattern       = "pattern"        // Only the coordinates of the non-zero cells are given; their values are 1.
+	MatrixMarketGeneral       = "general"        // All cells are given.
+	MatrixMarketSymmetric     = "symmetric"      // Only the cells on and below the main diagonal are given.
+	MatrixMarketSkewSymmetric = "skew-symmetric" // Only the cells below the main diagonal are given.
+)
+
+const matrixMarketBanner = "%%MatrixMarket"
+
+// The fraction of non-zero cells above which ReadMatrixMarket returns a
+// matrix with a dense backend instead of a compressed column one.
+var MatrixMarketDensity = 0.1
+
+// Reads a matrix in Matrix Market exchange format from r. Files in
+// coordinate and array format with real, integer or pattern fields and
+// general, symmetric or skew-symmetric symmetry are supported. The cells
+// are parsed as they are read, so r is never held in memory as a whole.
+//
+// Returns a dense matrix if the fraction of non-zero cells exceeds
+// MatrixMarketDensity and a sparse matrix in compressed column storage
+// otherwise.
+func ReadMatrixMarket(r io.Reader) (*Matrix, error) {
+	s := &matrixMarketScanner{scanner: bufio.NewScanner(r)}
+	s.scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+
+	if !s.scanner.Scan() {
+		if err := s.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Missing Matrix Market header")
+	}
+	s.line++
+	format, field, symmetry, err := parseMatrixMarketBanner(s.scanner.Text())
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := s.next()
+	if err != nil {
+		return nil, err
+	}
+	var rows, columns, entries int
+	if format == MatrixMarketCoordinate {
+		err = s.parseInts(size, &rows, &columns, &entries)
+	} else {
+		err = s.parseInts(size, &rows, &columns)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rows < 0 || columns < 0 || entries < 0 {
+		return nil, fmt.Errorf("Line %d: negative size: %s", s.line, strings.Join(size, " "))
+	}
+	if symmetry != MatrixMarketGeneral && rows != columns {
+		return nil, fmt.Errorf("Line %d: %s matrix must be square: %d x %d", s.line, symmetry, rows, columns)
+	}
+
+	var A *Matrix
+	if format == MatrixMarketCoordinate {
+		A, err = s.readCoordinate(rows, columns, entries, field, symmetry)
+	} else {
+		A, err = s.readArray(rows, columns, field, symmetry)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if fields, err := s.next(); err == nil {
+		return nil, fmt.Errorf("Line %d: unexpected entry: %s", s.line, strings.Join(fields, " "))
+	} else if err != io.EOF {
+		return nil, err
+	}
+	return A, nil
+}
+
+// Returns the format, field and symmetry of the given Matrix Market
+// header line.
+func parseMatrixMarketBanner(line string) (string, string, string, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 5 || fields[0] != matrixMarketBanner || strings.ToLower(fields[1]) != "matrix" {
+		return "", "", "", fmt.Errorf("Invalid Matrix Market header: %q", line)
+	}
+	format := strings.ToLower(fields[2])
+	field := strings.ToLower(fields[3])
+	symmetry := strings.ToLower(fields[4])
+	if format != MatrixMarketCoordinate && format != MatrixMarketArray {
+		return "", "", "", fmt.Errorf("Unsupported Matrix Market format: %s", fields[2])
+	}
+	if field != MatrixMarketReal && field != MatrixMarketInteger && field != MatrixMarketPattern {
+		return "", "", "", fmt.Errorf("Unsupported Matrix Market field: %s", fields[3])
+	}
+	if symmetry != MatrixMarketGeneral && symmetry != MatrixMarketSymmetric && symmetry != MatrixMarketSkewSymmetric {
+		return "", "", "", fmt.Errorf("Unsupported Matrix Market symmetry: %s", fields[4])
+	}
+	if format == MatrixMarketArray && field == MatrixMarketPattern {
+		return "", "", "", fmt.Errorf("Matrix Market pattern field requires coordinate format")
+	}
+	return format, field, symmetry, nil
+}
+
+// Reads the lines of a Matrix Market file, skipping comments and blank
+// lines and keeping track of the line number for error messages.
+type matrixMarketScanner struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// Returns the fields of the next line that is neither blank nor a
+// comment, or io.EOF at the end of the input.
+func (s *matrixMarketScanner) next() ([]string, error) {
+	for s.scanner.Scan() {
+		s.line++
+		text := s.scanner.Text()
+		if strings.HasPrefix(text, "%") {
+			continue
+		}
+		if fields := strings.Fields(text); len(fields) > 0 {
+			return fields, nil
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Parses exactly len(values) integers from the given fields.
+func (s *matrixMarketScanner) parseInts(fields []string, values ...*int) error {
+	if len(fields) != len(values) {
+		return fmt.Errorf("Line %d: expected %d values: %s", s.line, len(values), strings.Join(fields, " "))
+	}
+	for i, f := range fields {
+		value, err := strconv.Atoi(f)
+		if err != nil {
+			return fmt.Errorf("Line %d: invalid integer: %s", s.line, f)
+		}
+		*values[i] = value
+	}
+	return nil
+}
+
+// Parses a cell value of the given field.
+func (s *matrixMarketScanner) parseValue(f, field string) (float64, error) {
+	if field == MatrixMarketInteger {
+		value, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Line %d: invalid integer: %s", s.line, f)
+		}
+		return float64(value), nil
+	}
+	value, err := strconv.ParseFloat(f, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Line %d: invalid real: %s", s.line, f)
+	}
+	return value, nil
+}
+
+// Reads the given number of coordinate entries, mirroring the entries of
+// symmetric and skew-symmetric matrices.
+func (s *matrixMarketScanner) readCoordinate(rows, columns, entries int, field, symmetry string) (*Matrix, error) {
+	width := 3
+	if field == MatrixMarketPattern {
+		width = 2
+	}
+	capacity := entries
+	if symmetry != MatrixMarketGeneral {
+		capacity *= 2
+	}
+	b := NewTripletBuilderCapacity(rows, columns, capacity)
+	for k := 0; k < entries; k++ {
+		fields, err := s.next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("Expected %d entries, found %d", entries, k)
+		} else if err != nil {
+			return nil, err
+		}
+		if len(fields) != width {
+			return nil, fmt.Errorf("Line %d: expected %d values: %s", s.line, width, strings.Join(fields, " "))
+		}
+		var row, column int
+		if err := s.parseInts(fields[:2], &row, &column); err != nil {
+			return nil, err
+		}
+		row--
+		column--
+		if row < 0 || row >= rows || column < 0 || column >= columns {
+			return nil, fmt.Errorf("Line %d: attempted to access %d x %d matrix at row=%d, column=%d", s.line, rows, columns, row+1, column+1)
+		}
+		value := 1.0
+		if field != MatrixMarketPattern {
+			if value, err = s.parseValue(fields[2], field); err != nil {
+				return nil, err
+			}
+		}
+		if symmetry == MatrixMarketSkewSymmetric && row == column {
+			return nil, fmt.Errorf("Line %d: skew-symmetric matrix with diagonal entry", s.line)
+		}
+		b.Append(row, column, value)
+		if row != column {
+			switch symmetry {
+			case MatrixMarketSymmetric:
+				b.Append(column, row, value)
+			case MatrixMarketSkewSymmetric:
+				b.Append(column, row, -value)
+			}
+		}
+	}
+	if float64(b.Len()) > MatrixMarketDensity*float64(rows)*float64(columns) {
+		return b.Dense(), nil
+	}
+	return b.ColumnCompressed(), nil
+}
+
+// Reads the cells of an array format matrix in column-major order. Only
+// the cells on and below (symmetric) or below (skew-symmetric) the main
+// diagonal are read for symmetric matrices.
+func (s *matrixMarketScanner) readArray(rows, columns int, field, symmetry string) (*Matrix, error) {
+	A := NewMatrix(rows, columns)
+	nonZeros := 0
+	for c := 0; c < columns; c++ {
+		r := 0
+		switch symmetry {
+		case MatrixMarketSymmetric:
+			r = c
+		case MatrixMarketSkewSymmetric:
+			r = c + 1
+		}
+		for ; r < rows; r++ {
+			fields, err := s.next()
+			if err == io.EOF {
+				return nil, fmt.Errorf("Missing entry at row=%d, column=%d", r+1, c+1)
+			} else if err != nil {
+				return nil, err
+			}
+			if len(fields) != 1 {
+				return nil, fmt.Errorf("Line %d: expected 1 value: %s", s.line, strings.Join(fields, " "))
+			}
+			value, err := s.parseValue(fields[0], field)
+			if err != nil {
+				return nil, err
+			}
+			if value == 0 {
+				continue
+			}
+			A.SetQuick(r, c, value)
+			nonZeros++
+			if r != c {
+				switch symmetry {
+				case MatrixMarketSymmetric:
+					A.SetQuick(c, r, value)
+					nonZeros++
+				case MatrixMarketSkewSymmetric:
+					A.SetQuick(c, r, -value)
+					nonZeros++
+				}
+			}
+		}
+	}
+	if float64(nonZeros) > MatrixMarketDensity*float64(rows)*float64(columns) {
+		return A, nil
+	}
+	return NewSparseCCMatrixMat(A), nil
+}
+
+// Writes A to w in Matrix Market exchange format. Matrices with a sparse
+// backend are written in coordinate format and all others in array
+// format, both with real values and general symmetry.
+func WriteMatrixMarket(w io.Writer, A Mat) error {
+	if m, ok := A.(*Matrix); ok {
+		A = m.Mat
+	}
+	format := MatrixMarketArray
+	switch A.(type) {
+	case *SparseMat, *SparseRCMat, *SparseCCMat:
+		format = MatrixMarketCoordinate
+	}
+	return WriteMatrixMarketFormat(w, A, format, MatrixMarketReal, MatrixMarketGeneral)
+}
+
+// Writes A to w in Matrix Market exchange format with the given format,
+// field and symmetry. Returns an error if A has non-integer values and an
+// integer field is requested, or if A does not have the requested
+// symmetry. Only the cells on and below (symmetric) or below
+// (skew-symmetric) the main diagonal are written for symmetric matrices.
+func WriteMatrixMarketFormat(w io.Writer, A Mat, format, field, symmetry string) error {
+	if m, ok := A.(*Matrix); ok {
+		A = m.Mat
+	}
+	header := fmt.Sprintf("%s matrix %s %s %s", matrixMarketBanner, format, field, symmetry)
+	_, _, _, err := parseMatrixMarketBanner(header)
+	if err != nil {
+		return err
+	}
+	switch symmetry {
+	case MatrixMarketSymmetric:
+		err = checkSymmetry(A, symmetry, 1)
+	case MatrixMarketSkewSymmetric:
+		err = checkSymmetry(A, symmetry, -1)
+	}
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, header)
+	if format == MatrixMarketCoordinate {
+		err = writeMatrixMarketCoordinate(bw, A, field, symmetry)
+	} else {
+		err = writeMatrixMarketArray(bw, A, field, symmetry)
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// Returns an error naming the first cell at which the square matrix A
+// differs from sign times its transpose, as A must be exactly symmetric
+// (sign 1) or skew-symmetric (sign -1) for writing only its lower
+// triangle to a file.
+func checkSymmetry(A Mat, symmetry string, sign float64) error {
+	if A.Rows() != A.Columns() {
+		return fmt.Errorf("%s matrix must be square: %d x %d", symmetry, A.Rows(), A.Columns())
+	}
+	row, column := -1, -1
+	forEachNonZero(A, func(r, c int, value float64) {
+		if row < 0 && value != sign*A.GetQuick(c, r) {
+			row, column = r, c
+		}
+	})
+	if row >= 0 {
+		return fmt.Errorf("Matrix is not %s at row=%d, column=%d", symmetry, row, column)
+	}
+	return nil
+}
+
+// Returns whether the cell at the given row and column is written for a
+// matrix with the given symmetry.
+func matrixMarketStored(row, column int, symmetry string) bool {
+	switch symmetry {
+	case MatrixMarketSymmetric:
+		return row >= column
+	case MatrixMarketSkewSymmetric:
+		return row > column
+	}
+	return true
+}
+
+// Returns the string representation of a cell value of the given field.
+func formatMatrixMarketValue(value float64, field string) (string, error) {
+	if field == MatrixMarketInteger {
+		if value != math.Trunc(value) || math.Abs(value) >= math.MaxInt64 {
+			return "", fmt.Errorf("Value is not an integer: %g", value)
+		}
+		return strconv.FormatInt(int64(value), 10), nil
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64), nil
+}
+
+// Writes the non-zero cells of A in column-major order, visiting only the
+// non-zero cells of sparse backends.
+func writeMatrixMarketCoordinate(w *bufio.Writer, A Mat, field, symmetry string) error {
+	m := columnCompressed(A)
+	entries := 0
+	for c := 0; c < m.Columns(); c++ {
+		for k := m.columnPointers[c]; k < m.columnPointers[c+1]; k++ {
+			if matrixMarketStored(m.rowIndexes[k], c, symmetry) {
+				entries++
+			}
+		}
+	}
+	fmt.Fprintf(w, "%d %d %d\n", m.Rows(), m.Columns(), entries)
+	for c := 0; c < m.Columns(); c++ {
+		for k := m.columnPointers[c]; k < m.columnPointers[c+1]; k++ {
+			r := m.rowIndexes[k]
+			if !matrixMarketStored(r, c, symmetry) {
+				continue
+			}
+			if field == MatrixMarketPattern {
+				fmt.Fprintf(w, "%d %d\n", r+1, c+1)
+				continue
+			}
+			value, err := formatMatrixMarketValue(m.values[k], field)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%d %d %s\n", r+1, c+1, value)
+		}
+	}
+	return nil
+}
+
+// Writes the cells of A in column-major order.
+func writeMatrixMarketArray(w *bufio.Writer, A Mat, field, symmetry string) error {
+	fmt.Fprintf(w, "%d %d\n", A.Rows(), A.Columns())
+	for c := 0; c < A.Columns(); c++ {
+		for r := 0; r < A.Rows(); r++ {
+			if !matrixMarketStored(r, c, symmetry) {
+				continue
+			}
+			value, err := formatMatrixMarketValue(A.GetQuick(r, c), field)
+			if err != nil {
+				return err
+			}
+			w.WriteString(value)
+			w.WriteByte('\n')
+		}
+	}
+	return nil
+}
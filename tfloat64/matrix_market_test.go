@@ -0,0 +1,271 @@
+package tfloat64
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func testMatrixMarketCells(t *testing.T, A *Matrix, expected [][]float64) {
+	if A.Rows() != len(expected) || A.Columns() != len(expected[0]) {
+		t.Fatalf("expected:%d x %d actual:%d x %d", len(expected), len(expected[0]), A.Rows(), A.Columns())
+	}
+	for r := range expected {
+		for c := range expected[r] {
+			if expected[r][c] != A.GetQuick(r, c) {
+				t.Errorf("expected:%g actual:%g", expected[r][c], A.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func TestReadMatrixMarketCoordinate(t *testing.T) {
+	s := `%%MatrixMarket matrix coordinate real general
+% An 8 x 5 matrix with 4 non-zero cells.
+
+8 5 4
+1 1 1.5
+3 2 -2e3
+4 5 7
+2 4 0.25
+`
+	A, err := ReadMatrixMarket(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := A.Mat.(*SparseCCMat); !ok {
+		t.Errorf("expected:*SparseCCMat actual:%T", A.Mat)
+	}
+	testMatrixMarketCells(t, A, [][]float64{
+		{1.5, 0, 0, 0, 0},
+		{0, 0, 0, 0.25, 0},
+		{0, -2000, 0, 0, 0},
+		{0, 0, 0, 0, 7},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0},
+	})
+}
+
+func TestReadMatrixMarketSymmetric(t *testing.T) {
+	s := `%%MatrixMarket matrix coordinate integer symmetric
+3 3 3
+1 1 4
+2 1 -1
+3 2 2
+`
+	A, err := ReadMatrixMarket(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := A.Mat.(*DenseMat); !ok {
+		t.Errorf("expected:*DenseMat actual:%T", A.Mat)
+	}
+	testMatrixMarketCells(t, A, [][]float64{
+		{4, -1, 0},
+		{-1, 0, 2},
+		{0, 2, 0},
+	})
+
+	s = `%%MatrixMarket matrix coordinate pattern skew-symmetric
+3 3 2
+2 1
+3 1
+`
+	A, err = ReadMatrixMarket(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMatrixMarketCells(t, A, [][]float64{
+		{0, -1, -1},
+		{1, 0, 0},
+		{1, 0, 0},
+	})
+}
+
+func TestReadMatrixMarketArray(t *testing.T) {
+	s := `%%MatrixMarket matrix array real general
+2 3
+1
+4
+2
+5
+3
+6
+`
+	A, err := ReadMatrixMarket(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMatrixMarketCells(t, A, [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	s = `%%MatrixMarket matrix array real skew-symmetric
+3 3
+-1
+0
+2.5
+`
+	A, err = ReadMatrixMarket(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMatrixMarketCells(t, A, [][]float64{
+		{0, 1, 0},
+		{-1, 0, -2.5},
+		{0, 2.5, 0},
+	})
+}
+
+func TestReadMatrixMarketDensity(t *testing.T) {
+	s := `%%MatrixMarket matrix array real symmetric
+20 20
+` + strings.Repeat("0\n", 209) + "3\n"
+	A, err := ReadMatrixMarket(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := A.Mat.(*SparseCCMat); !ok {
+		t.Errorf("expected:*SparseCCMat actual:%T", A.Mat)
+	}
+	if A.GetQuick(19, 19) != 3 || A.Cardinality() != 1 {
+		t.Errorf("expected:%g actual:%g", 3.0, A.GetQuick(19, 19))
+	}
+}
+
+func TestReadMatrixMarketErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"%%MatrixMarket matrix coordinate complex general\n1 1 1\n1 1 1 0\n",
+		"%%MatrixMarket matrix coordinate real hermitian\n1 1 1\n1 1 1\n",
+		"%%MatrixMarket matrix array pattern general\n1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1 x\n",
+		"%%MatrixMarket matrix coordinate integer general\n2 2 1\n1 1 1.5\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1 1\n2 2 1\n",
+		"%%MatrixMarket matrix coordinate real symmetric\n2 3 1\n1 1 1\n",
+		"%%MatrixMarket matrix coordinate real skew-symmetric\n2 2 1\n1 1 1\n",
+		"%%MatrixMarket matrix array real general\n2 2\n1\n2\n3\n",
+	} {
+		if _, err := ReadMatrixMarket(strings.NewReader(s)); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	values := [][]float64{
+		{1, 0, 0.1},
+		{0, 0, 0},
+		{-3e-200, 5, 0},
+	}
+	for _, A := range []*Matrix{
+		NewMatrix(3, 3),
+		NewSparseMatrix(3, 3),
+		NewSparseRCMatrix(3, 3),
+		NewSparseCCMatrix(3, 3),
+	} {
+		for r := range values {
+			for c := range values[r] {
+				A.SetQuick(r, c, values[r][c])
+			}
+		}
+		var buf bytes.Buffer
+		if err := WriteMatrixMarket(&buf, A); err != nil {
+			t.Fatal(err)
+		}
+		header := "%%MatrixMarket matrix coordinate real general\n3 3 4\n1 1 1\n"
+		if _, ok := A.Mat.(*DenseMat); ok {
+			header = "%%MatrixMarket matrix array real general\n3 3\n1\n0\n"
+		}
+		if !strings.HasPrefix(buf.String(), header) {
+			t.Errorf("expected:%q actual:%q", header, buf.String())
+		}
+		B, err := ReadMatrixMarket(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testMatrixMarketCells(t, B, values)
+	}
+}
+
+func TestWriteMatrixMarketFormat(t *testing.T) {
+	A := NewSparseCCMatrix(3, 3)
+	A.SetQuick(0, 0, 2)
+	A.SetQuick(1, 0, -1)
+	A.SetQuick(0, 1, -1)
+	A.SetQuick(2, 1, 4)
+	A.SetQuick(1, 2, 4)
+
+	var buf bytes.Buffer
+	if err := WriteMatrixMarketFormat(&buf, A, MatrixMarketCoordinate, MatrixMarketInteger, MatrixMarketSymmetric); err != nil {
+		t.Fatal(err)
+	}
+	expected := "%%MatrixMarket matrix coordinate integer symmetric\n3 3 3\n1 1 2\n2 1 -1\n3 2 4\n"
+	if buf.String() != expected {
+		t.Errorf("expected:%q actual:%q", expected, buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteMatrixMarketFormat(&buf, A, MatrixMarketArray, MatrixMarketReal, MatrixMarketSymmetric); err != nil {
+		t.Fatal(err)
+	}
+	expected = "%%MatrixMarket matrix array real symmetric\n3 3\n2\n-1\n0\n0\n4\n0\n"
+	if buf.String() != expected {
+		t.Errorf("expected:%q actual:%q", expected, buf.String())
+	}
+	B, err := ReadMatrixMarket(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !B.EqualsMatrix(A) {
+		t.Errorf("expected:%s actual:%s", A, B)
+	}
+
+	buf.Reset()
+	if err := WriteMatrixMarketFormat(&buf, A, MatrixMarketCoordinate, MatrixMarketPattern, MatrixMarketGeneral); err != nil {
+		t.Fatal(err)
+	}
+	expected = "%%MatrixMarket matrix coordinate pattern general\n3 3 5\n1 1\n2 1\n1 2\n3 2\n2 3\n"
+	if buf.String() != expected {
+		t.Errorf("expected:%q actual:%q", expected, buf.String())
+	}
+
+	if err := WriteMatrixMarketFormat(&buf, A, MatrixMarketCoordinate, MatrixMarketReal, MatrixMarketSkewSymmetric); err == nil {
+		t.Errorf("expected error for skew-symmetric")
+	}
+	if err := WriteMatrixMarketFormat(&buf, A, MatrixMarketArray, MatrixMarketPattern, MatrixMarketGeneral); err == nil {
+		t.Errorf("expected error for array pattern")
+	}
+	A.SetQuick(2, 2, 0.5)
+	if err := WriteMatrixMarketFormat(&buf, A, MatrixMarketCoordinate, MatrixMarketInteger, MatrixMarketGeneral); err == nil {
+		t.Errorf("expected error for integer")
+	}
+	A.SetQuick(2, 0, 1)
+	err = WriteMatrixMarketFormat(&buf, A, MatrixMarketCoordinate, MatrixMarketReal, MatrixMarketSymmetric)
+	if expected := "Matrix is not symmetric at row=2, column=0"; err == nil || err.Error() != expected {
+		t.Errorf("expected:%q actual:%v", expected, err)
+	}
+
+	S := NewMatrix(3, 3)
+	S.SetQuick(1, 0, 2)
+	S.SetQuick(0, 1, -2)
+	buf.Reset()
+	if err := WriteMatrixMarketFormat(&buf, S, MatrixMarketCoordinate, MatrixMarketReal, MatrixMarketSkewSymmetric); err != nil {
+		t.Fatal(err)
+	}
+	B, err = ReadMatrixMarket(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMatrixMarketCells(t, B, [][]float64{
+		{0, -2, 0},
+		{2, 0, 0},
+		{0, 0, 0},
+	})
+}
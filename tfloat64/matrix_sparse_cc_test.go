@@ -0,0 +1,221 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func makeSparseCCMatrix() *Matrix {
+	A := NewSparseCCMatrix(nrows, ncolumns)
+	for c := 0; c < A.Columns(); c++ {
+		for r := 0; r < A.Rows(); r++ {
+			if rand.Float64() < 0.3 {
+				A.SetQuick(r, c, rand.Float64())
+			}
+		}
+	}
+	return A
+}
+
+func TestSparseCCMatrixSetQuick(t *testing.T) {
+	A := NewSparseCCMatrix(4, 3)
+	B := NewMatrix(4, 3)
+	cells := [][3]float64{
+		{3, 1, 5}, {0, 1, 2}, {2, 0, 1}, {1, 2, 7}, {2, 1, 4}, {0, 1, 3}, {2, 0, 0},
+	}
+	for _, cell := range cells {
+		A.SetQuick(int(cell[0]), int(cell[1]), cell[2])
+		B.SetQuick(int(cell[0]), int(cell[1]), cell[2])
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if A.GetQuick(r, c) != B.GetQuick(r, c) {
+				t.Errorf("expected:%g actual:%g", B.GetQuick(r, c), A.GetQuick(r, c))
+			}
+		}
+	}
+	m := A.Mat.(*SparseCCMat)
+	if m.NonZeroCount() != 4 {
+		t.Errorf("expected:%d actual:%d", 4, m.NonZeroCount())
+	}
+	rows, values := m.ColumnNonZeros(1)
+	expectedRows := []int{0, 2, 3}
+	expectedValues := []float64{3, 4, 5}
+	for i := range expectedRows {
+		if rows[i] != expectedRows[i] || values[i] != expectedValues[i] {
+			t.Errorf("expected:(%d,%g) actual:(%d,%g)", expectedRows[i], expectedValues[i], rows[i], values[i])
+		}
+	}
+}
+
+func TestSparseCCMatrixViewColumn(t *testing.T) {
+	A := makeSparseCCMatrix()
+	column := A.Columns() / 2
+	col, err := A.ViewColumn(column)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Size() != A.Rows() {
+		t.Errorf("expected:%d actual:%d", A.Rows(), col.Size())
+	}
+	for r := 0; r < A.Rows(); r++ {
+		if col.GetQuick(r) != A.GetQuick(r, column) {
+			t.Errorf("expected:%g actual:%g", A.GetQuick(r, column), col.GetQuick(r))
+		}
+	}
+	col.SetQuick(1, 42)
+	if A.GetQuick(1, column) != 42 {
+		t.Errorf("expected:%g actual:%g", 42.0, A.GetQuick(1, column))
+	}
+	col.SetQuick(1, 0)
+	if A.GetQuick(1, column) != 0 {
+		t.Errorf("expected:%g actual:%g", 0.0, A.GetQuick(1, column))
+	}
+	if _, err = A.ViewColumn(A.Columns()); err == nil {
+		t.Errorf("expected error for column out of range")
+	}
+}
+
+func TestSparseCCMatrixZMult(t *testing.T) {
+	A := makeSparseCCMatrix()
+	D := A.Mat.(*SparseCCMat).Dense()
+	alpha := 3.0
+	beta := 2.0
+	for _, transposeA := range []bool{false, true} {
+		n, m := A.Columns(), A.Rows()
+		if transposeA {
+			n, m = m, n
+		}
+		y := NewVector(n)
+		for i := 0; i < y.Size(); i++ {
+			y.SetQuick(i, rand.Float64())
+		}
+		z := NewVector(m)
+		z.Assign(1)
+		actual, err := A.ZMultConst(y, z.Copy(), alpha, beta, transposeA)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := D.ZMultConst(y, z.Copy(), alpha, beta, transposeA)
+		for i := 0; i < expected.Size(); i++ {
+			if math.Abs(expected.GetQuick(i)-actual.GetQuick(i)) > tol {
+				t.Errorf("expected:%g actual:%g", expected.GetQuick(i), actual.GetQuick(i))
+			}
+		}
+	}
+}
+
+func TestSparseCCMatrixForEachNonZero(t *testing.T) {
+	A := makeSparseCCMatrix()
+	Acopy := A.Mat.(*SparseCCMat).Dense()
+	A.ForEachNonZero(func(r, c int, value float64) float64 {
+		if r == 0 {
+			return 0
+		}
+		return 2 * value
+	})
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := 2 * Acopy.GetQuick(r, c)
+			if r == 0 {
+				expected = 0
+			}
+			if expected != A.GetQuick(r, c) {
+				t.Errorf("expected:%g actual:%g", expected, A.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func TestSparseCCMatrixConvert(t *testing.T) {
+	S := makeSparseMatrix()
+	A := NewSparseCCMatrixMat(S)
+	if !A.EqualsMatrix(S) {
+		t.Errorf("expected:%s actual:%s", S, A)
+	}
+	rc := A.Mat.(*SparseCCMat).RowCompressed()
+	if !rc.EqualsMatrix(S) {
+		t.Errorf("expected:%s actual:%s", S, rc)
+	}
+	cc := rc.Mat.(*SparseRCMat).ColumnCompressed()
+	if !cc.EqualsMatrix(S) {
+		t.Errorf("expected:%s actual:%s", S, cc)
+	}
+	if !A.Mat.(*SparseCCMat).Sparse().EqualsMatrix(S) {
+		t.Errorf("expected:%s actual:%s", S, A.Mat.(*SparseCCMat).Sparse())
+	}
+}
+
+func TestSparseCCMatrixForEachNonZeroProcedure(t *testing.T) {
+	A := makeSparseCCMatrix()
+	m := A.Mat.(*SparseCCMat)
+	values := m.Values()
+	count := 0
+	completed := m.ForEachNonZeroProcedure(func(r, c int, value float64) bool {
+		if value != A.GetQuick(r, c) {
+			t.Errorf("expected:%g actual:%g", A.GetQuick(r, c), value)
+		}
+		count++
+		return true
+	})
+	if !completed {
+		t.Errorf("expected:%t actual:%t", true, completed)
+	}
+	if count != m.NonZeroCount() {
+		t.Errorf("expected:%d actual:%d", m.NonZeroCount(), count)
+	}
+	if len(values) > 0 && &values[0] != &m.Values()[0] {
+		t.Errorf("storage reallocated by a read-only iteration")
+	}
+	count = 0
+	completed = m.ForEachNonZeroProcedure(func(r, c int, value float64) bool {
+		count++
+		return false
+	})
+	if m.NonZeroCount() > 0 && (completed || count != 1) {
+		t.Errorf("expected:%d actual:%d", 1, count)
+	}
+}
+
+// View tests.
+
+func TestSparseCCMatrixViewColumnFlip(t *testing.T) {
+	A := makeSparseCCMatrix()
+	testMatrixViewColumnFlip(t, A)
+}
+
+func TestSparseCCMatrixViewDice(t *testing.T) {
+	A := makeSparseCCMatrix()
+	testMatrixViewDice(t, A)
+}
+
+func TestSparseCCMatrixViewPart(t *testing.T) {
+	A := makeSparseCCMatrix()
+	testMatrixViewPart(t, A)
+}
+
+func TestSparseCCMatrixViewRow(t *testing.T) {
+	A := makeSparseCCMatrix()
+	testMatrixViewRow(t, A)
+}
+
+func TestSparseCCMatrixViewRowFlip(t *testing.T) {
+	A := makeSparseCCMatrix()
+	testMatrixViewRowFlip(t, A)
+}
+
+func TestSparseCCMatrixViewSelectionProcedure(t *testing.T) {
+	A := makeSparseCCMatrix()
+	testMatrixViewSelectionVectorProcedure(t, A)
+}
+
+func TestSparseCCMatrixViewSelection(t *testing.T) {
+	A := makeSparseCCMatrix()
+	testMatrixViewSelection(t, A)
+}
+
+func TestSparseCCMatrixViewStrides(t *testing.T) {
+	A := makeSparseCCMatrix()
+	testMatrixViewStrides(t, A)
+}
@@ -0,0 +1,216 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func makeSparseRCMatrix() *Matrix {
+	A := NewSparseRCMatrix(nrows, ncolumns)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if rand.Float64() < 0.3 {
+				A.SetQuick(r, c, rand.Float64())
+			}
+		}
+	}
+	return A
+}
+
+func TestSparseRCMatrixSetQuick(t *testing.T) {
+	A := NewSparseRCMatrix(3, 4)
+	B := NewMatrix(3, 4)
+	cells := [][3]float64{
+		{1, 3, 5}, {1, 0, 2}, {0, 2, 1}, {2, 1, 7}, {1, 2, 4}, {1, 0, 3}, {0, 2, 0},
+	}
+	for _, cell := range cells {
+		A.SetQuick(int(cell[0]), int(cell[1]), cell[2])
+		B.SetQuick(int(cell[0]), int(cell[1]), cell[2])
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if A.GetQuick(r, c) != B.GetQuick(r, c) {
+				t.Errorf("expected:%g actual:%g", B.GetQuick(r, c), A.GetQuick(r, c))
+			}
+		}
+	}
+	m := A.Mat.(*SparseRCMat)
+	if m.NonZeroCount() != 4 {
+		t.Errorf("expected:%d actual:%d", 4, m.NonZeroCount())
+	}
+	columns, values := m.RowNonZeros(1)
+	expectedColumns := []int{0, 2, 3}
+	expectedValues := []float64{3, 4, 5}
+	for i := range expectedColumns {
+		if columns[i] != expectedColumns[i] || values[i] != expectedValues[i] {
+			t.Errorf("expected:(%d,%g) actual:(%d,%g)", expectedColumns[i], expectedValues[i], columns[i], values[i])
+		}
+	}
+}
+
+func TestSparseRCMatrixForEachNonZero(t *testing.T) {
+	A := makeSparseRCMatrix()
+	Acopy := A.Mat.(*SparseRCMat).Dense()
+	A.ForEachNonZero(func(r, c int, value float64) float64 {
+		if c == 0 {
+			return 0
+		}
+		return math.Sqrt(value)
+	})
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := math.Sqrt(Acopy.GetQuick(r, c))
+			if c == 0 {
+				expected = 0
+			}
+			if math.Abs(expected-A.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", expected, A.GetQuick(r, c))
+			}
+		}
+	}
+	if A.Cardinality() != A.Mat.(*SparseRCMat).NonZeroCount() {
+		t.Errorf("expected:%d actual:%d", A.Cardinality(), A.Mat.(*SparseRCMat).NonZeroCount())
+	}
+}
+
+func TestSparseRCMatrixZMult(t *testing.T) {
+	A := makeSparseRCMatrix()
+	D := A.Mat.(*SparseRCMat).Dense()
+	y := NewVector(A.Columns())
+	for i := 0; i < y.Size(); i++ {
+		y.SetQuick(i, rand.Float64())
+	}
+	z := NewVector(A.Rows())
+	z.Assign(1)
+	alpha := 3.0
+	beta := 2.0
+	actual, err := A.ZMultConst(y, z.Copy(), alpha, beta, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := D.ZMultConst(y, z.Copy(), alpha, beta, false)
+	for i := 0; i < expected.Size(); i++ {
+		if math.Abs(expected.GetQuick(i)-actual.GetQuick(i)) > tol {
+			t.Errorf("expected:%g actual:%g", expected.GetQuick(i), actual.GetQuick(i))
+		}
+	}
+
+	// transposeA
+	y = NewVector(A.Rows())
+	for i := 0; i < y.Size(); i++ {
+		y.SetQuick(i, rand.Float64())
+	}
+	z = NewVector(A.Columns())
+	z.Assign(1)
+	actual, err = A.ZMultConst(y, z.Copy(), alpha, beta, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ = D.ZMultConst(y, z.Copy(), alpha, beta, true)
+	for i := 0; i < expected.Size(); i++ {
+		if math.Abs(expected.GetQuick(i)-actual.GetQuick(i)) > tol {
+			t.Errorf("expected:%g actual:%g", expected.GetQuick(i), actual.GetQuick(i))
+		}
+	}
+
+	if _, err = A.ZMult(y, nil); err == nil {
+		t.Errorf("expected error for incompatible args")
+	}
+}
+
+func TestSparseRCMatrixConvert(t *testing.T) {
+	S := makeSparseMatrix()
+	A := NewSparseRCMatrixMat(S)
+	if !A.EqualsMatrix(S) {
+		t.Errorf("expected:%s actual:%s", S, A)
+	}
+	m := A.Mat.(*SparseRCMat)
+	if !m.Sparse().EqualsMatrix(S) {
+		t.Errorf("expected:%s actual:%s", S, m.Sparse())
+	}
+	if !m.Dense().EqualsMatrix(S) {
+		t.Errorf("expected:%s actual:%s", S, m.Dense())
+	}
+	if _, ok := A.Like(2, 3).(*SparseRCMat); !ok {
+		t.Errorf("expected Like to return a compressed row matrix")
+	}
+}
+
+func TestSparseRCMatrixForEachNonZeroProcedure(t *testing.T) {
+	A := makeSparseRCMatrix()
+	m := A.Mat.(*SparseRCMat)
+	values := m.Values()
+	count := 0
+	completed := m.ForEachNonZeroProcedure(func(r, c int, value float64) bool {
+		if value != A.GetQuick(r, c) {
+			t.Errorf("expected:%g actual:%g", A.GetQuick(r, c), value)
+		}
+		count++
+		return true
+	})
+	if !completed {
+		t.Errorf("expected:%t actual:%t", true, completed)
+	}
+	if count != m.NonZeroCount() {
+		t.Errorf("expected:%d actual:%d", m.NonZeroCount(), count)
+	}
+	if len(values) > 0 && &values[0] != &m.Values()[0] {
+		t.Errorf("storage reallocated by a read-only iteration")
+	}
+	count = 0
+	completed = m.ForEachNonZeroProcedure(func(r, c int, value float64) bool {
+		count++
+		return false
+	})
+	if m.NonZeroCount() > 0 && (completed || count != 1) {
+		t.Errorf("expected:%d actual:%d", 1, count)
+	}
+}
+
+// View tests.
+
+func TestSparseRCMatrixViewColumn(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewColumn(t, A)
+}
+
+func TestSparseRCMatrixViewColumnFlip(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewColumnFlip(t, A)
+}
+
+func TestSparseRCMatrixViewDice(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewDice(t, A)
+}
+
+func TestSparseRCMatrixViewPart(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewPart(t, A)
+}
+
+func TestSparseRCMatrixViewRow(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewRow(t, A)
+}
+
+func TestSparseRCMatrixViewRowFlip(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewRowFlip(t, A)
+}
+
+func TestSparseRCMatrixViewSelectionProcedure(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewSelectionVectorProcedure(t, A)
+}
+
+func TestSparseRCMatrixViewSelection(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewSelection(t, A)
+}
+
+func TestSparseRCMatrixViewStrides(t *testing.T) {
+	A := makeSparseRCMatrix()
+	testMatrixViewStrides(t, A)
+}
@@ -83,3 +83,118 @@ func TestSparseMatrixAssignProcedureFunc(t *testing.T) {
 	A := makeSparseMatrix()
 	testMatrixAssignProcedureFunc(t, A)
 }
+
+// Matrix tests.
+
+func TestSparseMatrixCardinality(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixCardinality(t, A)
+}
+
+func TestSparseMatrixEquals(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixEquals(t, A)
+}
+
+func TestSparseMatrixEqualsMatrix(t *testing.T) {
+	A := makeSparseMatrix()
+	B := makeSparseMatrix()
+	testMatrixEqualsMatrix(t, A, B)
+}
+
+func TestSparseMatrixForEachNonZero(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixForEachNonZero(t, A)
+}
+
+func TestSparseMatrixMaxLocation(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixMaxLocation(t, A)
+}
+
+func TestSparseMatrixMinLocation(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixMinLocation(t, A)
+}
+
+func TestSparseMatrixNegativeValues(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixNegativeValues(t, A)
+}
+
+func TestSparseMatrixNonZeros(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixNonZeros(t, A)
+}
+
+func TestSparseMatrixPositiveValues(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixPositiveValues(t, A)
+}
+
+func TestSparseMatrixToArray(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixToArray(t, A)
+}
+
+func TestSparseMatrixZMult(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixZMult(t, A)
+}
+
+func TestSparseMatrixZMultMatrix(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixZMultMatrix(t, A)
+}
+
+func TestSparseMatrixZSum(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixZSum(t, A)
+}
+
+// View tests.
+
+func TestSparseMatrixViewColumn(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewColumn(t, A)
+}
+
+func TestSparseMatrixViewColumnFlip(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewColumnFlip(t, A)
+}
+
+func TestSparseMatrixViewDice(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewDice(t, A)
+}
+
+func TestSparseMatrixViewPart(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewPart(t, A)
+}
+
+func TestSparseMatrixViewRow(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewRow(t, A)
+}
+
+func TestSparseMatrixViewRowFlip(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewRowFlip(t, A)
+}
+
+func TestSparseMatrixViewSelectionProcedure(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewSelectionVectorProcedure(t, A)
+}
+
+func TestSparseMatrixViewSelection(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewSelection(t, A)
+}
+
+func TestSparseMatrixViewStrides(t *testing.T) {
+	A := makeSparseMatrix()
+	testMatrixViewStrides(t, A)
+}
@@ -4,6 +4,7 @@ package tfloat64
 import (
 	"testing"
 	"math"
+	"math/rand"
 	"github.com/rwl/goshawk/common"
 )
 
@@ -15,7 +16,7 @@ type cardinalityMatrix interface {
 func testMatrixCardinality(t *testing.T, A cardinalityMatrix) {
 	card := A.Cardinality()
 	if A.Rows() * A.Columns() != card {
-		t.Errorf("expected:%g actual:%g", A.Rows() * A.Columns(), card)
+		t.Errorf("expected:%d actual:%d", A.Rows() * A.Columns(), card)
 	}
 }
 
@@ -53,6 +54,7 @@ func testMatrixEqualsMatrix(t *testing.T, A, B equalsMatrixMatrix) {
 
 type forEachNonZeroMatrix interface {
 	Mat
+	Copy() *Matrix
 	ForEachNonZero(IntIntFloat64Func) *Matrix
 }
 
@@ -63,7 +65,7 @@ func testMatrixForEachNonZero(t *testing.T, A forEachNonZeroMatrix) {
 	}
 	A.ForEachNonZero(function)
 	for r := 0; r < A.Rows(); r++ {
-		for c = 0; c < A.Columns(); c++ {
+		for c := 0; c < A.Columns(); c++ {
 			if math.Abs(math.Sqrt(Acopy.GetQuick(r, c)) - A.GetQuick(r, c)) > tol {
 				t.Errorf("expected:%g actual:%g", math.Sqrt(Acopy.GetQuick(r, c)), A.GetQuick(r, c))
 			}
@@ -81,7 +83,7 @@ func testMatrixMaxLocation(t *testing.T, A maxLocationMatrix) {
 	A.Assign(0)
 	A.SetQuick(A.Rows() / 3, A.Columns() / 3, 0.7)
 	A.SetQuick(A.Rows() / 2, A.Columns() / 2, 0.1)
-	v, r, c = A.MaxLocation()
+	v, r, c := A.MaxLocation()
 	if math.Abs(0.7 - v) > tol {
 		t.Errorf("expected:%g actual:%g", 0.7, v)
 	}
@@ -102,10 +104,10 @@ type minLocationMatrix interface {
 func testMatrixMinLocation(t *testing.T, A minLocationMatrix) {
 	A.Assign(0)
 	A.SetQuick(A.Rows() / 3, A.Columns() / 3, -0.7)
-	A.SetQuick(A.rows() / 2, A.Columns() / 2, -0.1)
+	A.SetQuick(A.Rows() / 2, A.Columns() / 2, -0.1)
 	v, r, c := A.MinLocation()
-	if math.Abs(0.7 - v) > tol {
-		t.Errorf("expected:%g actual:%g", 0.7, v)
+	if math.Abs(-0.7 - v) > tol {
+		t.Errorf("expected:%g actual:%g", -0.7, v)
 	}
 	if A.Rows() / 3 != r {
 		t.Errorf("expected:%d actual:%d", A.Rows()/3, r)
@@ -144,10 +146,10 @@ func testMatrixNegativeValues(t *testing.T, A negativeValuesMatrix) {
 	if !common.ContainsInt(rowList, A.Rows()/2) {
 		t.Errorf("missing:%d", A.Rows()/2)
 	}
-	if !common.ContainsInt(rowList, A.Columns()/3) {
+	if !common.ContainsInt(columnList, A.Columns()/3) {
 		t.Errorf("missing:%d", A.Columns()/3)
 	}
-	if !common.ContainsInt(rowList, A.Columns()/2) {
+	if !common.ContainsInt(columnList, A.Columns()/2) {
 		t.Errorf("missing:%d", A.Columns()/2)
 	}
 	if !common.ContainsFloat(valueList, -0.7, tol) {
@@ -187,10 +189,10 @@ func testMatrixNonZeros(t *testing.T, A nonZerosMatrix) {
 	if !common.ContainsInt(rowList, A.Rows()/2) {
 		t.Errorf("missing:%d", A.Rows()/2)
 	}
-	if !common.ContainsInt(rowList, A.Columns()/3) {
+	if !common.ContainsInt(columnList, A.Columns()/3) {
 		t.Errorf("missing:%d", A.Columns()/3)
 	}
-	if !common.ContainsInt(rowList, A.Columns()/2) {
+	if !common.ContainsInt(columnList, A.Columns()/2) {
 		t.Errorf("missing:%d", A.Columns()/2)
 	}
 	if !common.ContainsFloat(valueList, 0.7, tol) {
@@ -221,7 +223,7 @@ func testMatrixPositiveValues(t *testing.T, A positiveValuesMatrix) {
 	if len(columnList) != 2 {
 		t.Errorf("expected:%d actual:%d", 2, len(columnList))
 	}
-	if !common.ContainsInt(rowsList, A.Rows()/3) {
+	if !common.ContainsInt(rowList, A.Rows()/3) {
 		t.Errorf("missing:%d", A.Rows()/3)
 	}
 	if !common.ContainsInt(rowList, A.Rows()/2) {
@@ -274,7 +276,7 @@ func testMatrixVectorize(t *testing.T, A vectorizeMatrix) {
 	Avec := A.Vectorize()
 	idx := 0
 	for c := 0; c < A.Columns(); c++ {
-		for r = 0; r < A.Rows(); r++ {
+		for r := 0; r < A.Rows(); r++ {
 			expected := A.GetQuick(r, c)
 			result := Avec.GetQuick(idx)
 			if math.Abs(expected - result) > tol {
@@ -287,26 +289,23 @@ func testMatrixVectorize(t *testing.T, A vectorizeMatrix) {
 
 type zMultMatrix interface {
 	Mat
+	ZMultConst(y, z *Vector, alpha, beta float64, transposeA bool) (*Vector, error)
 }
 
 func testMatrixZMult(t *testing.T, A zMultMatrix) {
-	y := NewVector(A.Columns())
-	for i := 0; i < y.Size(); i++ {
-		y.SetQuick(i, random.Float64())
-	}
+	y := NewRandomVector(A.Columns())
 	alpha := 3.0
 	beta := 5.0
-	z := RandomVector(A.Rows())
+	z := NewRandomVector(A.Rows())
 	expected := z.ToArray()
-	z = A.ZMult(y, z, alpha, beta, false)
+	z, _ = A.ZMultConst(y, z, alpha, beta, false)
 	for r := 0; r < A.Rows(); r++ {
 		s := 0.0
-		for c = 0; c < A.Columns(); c++ {
+		for c := 0; c < A.Columns(); c++ {
 			s += A.GetQuick(r, c) * y.GetQuick(c)
 		}
 		expected[r] = s * alpha + expected[r] * beta
 	}
-
 	for r := 0; r < A.Rows(); r++ {
 		actual := z.GetQuick(r)
 		if math.Abs(expected[r] - actual) > tol {
@@ -314,8 +313,7 @@ func testMatrixZMult(t *testing.T, A zMultMatrix) {
 		}
 	}
 	//---
-	z = nil
-	z = A.ZMult(y, z, alpha, beta, false)
+	z, _ = A.ZMultConst(y, nil, alpha, beta, false)
 	expected = make([]float64, A.Rows())
 	for r := 0; r < A.Rows(); r++ {
 		s := 0.0
@@ -324,7 +322,7 @@ func testMatrixZMult(t *testing.T, A zMultMatrix) {
 		}
 		expected[r] = s * alpha
 	}
-	for r = 0; r < A.Rows(); r++ {
+	for r := 0; r < A.Rows(); r++ {
 		actual := z.GetQuick(r)
 		if math.Abs(expected[r] - actual) > tol {
 			t.Errorf("expected:%g actual:%g", expected[r], actual)
@@ -332,13 +330,10 @@ func testMatrixZMult(t *testing.T, A zMultMatrix) {
 	}
 
 	//transpose
-	y = NewVector(A.Rows())
-	for i := 0; i < y.Size(); i++ {
-		y.SetQuick(i, random.Float64())
-	}
-	z = RandomVector(A.Columns())
+	y = NewRandomVector(A.Rows())
+	z = NewRandomVector(A.Columns())
 	expected = z.ToArray()
-	z = A.ZMult(y, z, alpha, beta, true)
+	z, _ = A.ZMultConst(y, z, alpha, beta, true)
 	for r := 0; r < A.Columns(); r++ {
 		s := 0.0
 		for c := 0; c < A.Rows(); c++ {
@@ -346,15 +341,14 @@ func testMatrixZMult(t *testing.T, A zMultMatrix) {
 		}
 		expected[r] = s * alpha + expected[r] * beta
 	}
-	for r = 0; r < A.Columns(); r++ {
+	for r := 0; r < A.Columns(); r++ {
 		actual := z.GetQuick(r)
 		if math.Abs(expected[r] - actual) > tol {
 			t.Errorf("expected:%g actual:%g", expected[r], actual)
 		}
 	}
 	//---
-	z = nil
-	z = A.ZMult(y, z, alpha, beta, true)
+	z, _ = A.ZMultConst(y, nil, alpha, beta, true)
 	expected = make([]float64, A.Columns())
 	for r := 0; r < A.Columns(); r++ {
 		s := 0.0
@@ -373,15 +367,28 @@ func testMatrixZMult(t *testing.T, A zMultMatrix) {
 
 type zMultMatrixMatrix interface {
 	Mat
-	ZMultMatrix(*Matrix, *Matrix, float64, float64, bool, bool)
+	ZMultMatrixConst(B, C *Matrix, alpha, beta float64, transposeA, transposeB bool) (*Matrix, error)
+}
+
+// Returns a rows x columns dense matrix with uniformly distributed cells.
+func randomMatrix(rows, columns int) *Matrix {
+	A := NewMatrix(rows, columns)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < columns; c++ {
+			A.SetQuick(r, c, rand.Float64())
+		}
+	}
+	return A
 }
 
 func testMatrixZMultMatrix(t *testing.T, A zMultMatrixMatrix) {
 	alpha := 3.0
 	beta := 5.0
-	C := RandomMatrix(A.Rows(), A.Rows())
+	B := randomMatrix(A.Rows(), A.Columns())
+	Bt := randomMatrix(A.Columns(), A.Rows())
+	C := randomMatrix(A.Rows(), A.Rows())
 	expected := C.ToArray()
-	C = A.ZMultMatrix(Bt, C, alpha, beta, false, false)
+	C, _ = A.ZMultMatrixConst(Bt, C, alpha, beta, false, false)
 	for j := 0; j < A.Rows(); j++ {
 		for i := 0; i < A.Rows(); i++ {
 			s := 0.0
@@ -401,9 +408,8 @@ func testMatrixZMultMatrix(t *testing.T, A zMultMatrixMatrix) {
 	}
 
 	//---
-	C = nil
-	C = A.ZMultMatrix(Bt, C, alpha, beta, false, false)
-	expected = make([][]float64, A.Rows(), A.Rows())
+	C, _ = A.ZMultMatrixConst(Bt, nil, alpha, beta, false, false)
+	expected = NewMatrix(A.Rows(), A.Rows()).ToArray()
 	for j := 0; j < A.Rows(); j++ {
 		for i := 0; i < A.Rows(); i++ {
 			s := 0.0
@@ -423,9 +429,9 @@ func testMatrixZMultMatrix(t *testing.T, A zMultMatrixMatrix) {
 	}
 
 	//transposeA
-	C = RandomMatrix(A.Columns(), A.Columns())
+	C = randomMatrix(A.Columns(), A.Columns())
 	expected = C.ToArray()
-	C = A.ZMultMatrix(B, C, alpha, beta, true, false)
+	C, _ = A.ZMultMatrixConst(B, C, alpha, beta, true, false)
 	for j := 0; j < A.Columns(); j++ {
 		for i := 0; i < A.Columns(); i++ {
 			s := 0.0
@@ -444,9 +450,8 @@ func testMatrixZMultMatrix(t *testing.T, A zMultMatrixMatrix) {
 		}
 	}
 	//---
-	C = nil
-	C = A.ZMult(B, C, alpha, beta, true, false)
-	expected = make([][]float64, A.Columns(), A.Columns())
+	C, _ = A.ZMultMatrixConst(B, nil, alpha, beta, true, false)
+	expected = NewMatrix(A.Columns(), A.Columns()).ToArray()
 	for j := 0; j < A.Columns(); j++ {
 		for i := 0; i < A.Columns(); i++ {
 			s := 0.0
@@ -466,9 +471,9 @@ func testMatrixZMultMatrix(t *testing.T, A zMultMatrixMatrix) {
 	}
 
 	//transposeB
-	C = RandomMatrix(A.Rows(), A.Rows())
+	C = randomMatrix(A.Rows(), A.Rows())
 	expected = C.ToArray()
-	C = A.ZMultMatrix(B, C, alpha, beta, false, true)
+	C, _ = A.ZMultMatrixConst(B, C, alpha, beta, false, true)
 	for j := 0; j < A.Rows(); j++ {
 		for i := 0; i < A.Rows(); i++ {
 			s := 0.0
@@ -487,9 +492,8 @@ func testMatrixZMultMatrix(t *testing.T, A zMultMatrixMatrix) {
 		}
 	}
 	//---
-	C = nil
-	C = A.ZMultMatrix(B, C, alpha, beta, false, true)
-	expected = make([][]float64, A.Rows(), A.Rows())
+	C, _ = A.ZMultMatrixConst(B, nil, alpha, beta, false, true)
+	expected = NewMatrix(A.Rows(), A.Rows()).ToArray()
 	for j := 0; j < A.Rows(); j++ {
 		for i := 0; i < A.Rows(); i++ {
 			s := 0.0
@@ -508,9 +512,9 @@ func testMatrixZMultMatrix(t *testing.T, A zMultMatrixMatrix) {
 		}
 	}
 	//transposeA and transposeB
-	C = RandomMatrix(A.Columns(), A.Columns())
+	C = randomMatrix(A.Columns(), A.Columns())
 	expected = C.ToArray()
-	C = A.ZMultMatrix(Bt, C, alpha, beta, true, true)
+	C, _ = A.ZMultMatrixConst(Bt, C, alpha, beta, true, true)
 	for j := 0; j < A.Columns(); j++ {
 		for i := 0; i < A.Columns(); i++ {
 			s := 0.0
@@ -529,9 +533,8 @@ func testMatrixZMultMatrix(t *testing.T, A zMultMatrixMatrix) {
 		}
 	}
 	//---
-	C = nil
-	C = A.ZMultMatrix(Bt, C, alpha, beta, true, true)
-	expected = make([][]float64, A.Columns(), A.Columns())
+	C, _ = A.ZMultMatrixConst(Bt, nil, alpha, beta, true, true)
+	expected = NewMatrix(A.Columns(), A.Columns()).ToArray()
 	for j := 0; j < A.Columns(); j++ {
 		for i := 0; i < A.Columns(); i++ {
 			s := 0.0
@@ -558,7 +561,7 @@ type zSumMatrix interface {
 
 func testMatrixZSum(t *testing.T, A zSumMatrix) {
 	sum := A.ZSum()
-	expected := 0
+	expected := 0.0
 	for r := 0; r < A.Rows(); r++ {
 		for c := 0; c < A.Columns(); c++ {
 			expected += A.GetQuick(r, c)
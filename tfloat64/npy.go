@@ -0,0 +1,498 @@
+package tfloat64
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rwl/goshawk/common"
+)
+
+// NumPy data types supported by NpyWriter. Arrays of any size of signed
+// and unsigned integers, of either byte order, may also be read.
+const (
+	NpyFloat64 = "<f8" // Little-endian 64-bit floating point.
+	NpyFloat32 = "<f4" // Little-endian 32-bit floating point.
+	NpyInt64   = "<i8" // Little-endian 64-bit signed integer.
+	NpyInt32   = "<i4" // Little-endian 32-bit signed integer.
+	NpyBool    = "|b1" // Boolean, one byte per cell.
+)
+
+const npyMagic = "\x93NUMPY"
+
+// The element type of a NumPy array.
+type npyDtype struct {
+	order binary.ByteOrder
+	kind  byte // One of f, i, u or b.
+	size  int  // Number of bytes per element.
+}
+
+// Parses a NumPy array-protocol type string, such as "<f8".
+func parseNpyDtype(descr string) (*npyDtype, error) {
+	if len(descr) < 3 {
+		return nil, fmt.Errorf("Unsupported NumPy data type: %q", descr)
+	}
+	d := &npyDtype{order: binary.LittleEndian, kind: descr[1]}
+	if descr[0] == '>' {
+		d.order = binary.BigEndian
+	} else if descr[0] != '<' && descr[0] != '|' && descr[0] != '=' {
+		return nil, fmt.Errorf("Unsupported NumPy data type: %q", descr)
+	}
+	size, err := strconv.Atoi(descr[2:])
+	if err != nil {
+		return nil, fmt.Errorf("Unsupported NumPy data type: %q", descr)
+	}
+	d.size = size
+	switch {
+	case d.kind == 'f' && (size == 4 || size == 8):
+	case (d.kind == 'i' || d.kind == 'u') && (size == 1 || size == 2 || size == 4 || size == 8):
+	case d.kind == 'b' && size == 1:
+	default:
+		return nil, fmt.Errorf("Unsupported NumPy data type: %q", descr)
+	}
+	return d, nil
+}
+
+// Returns the value of the element held in b.
+func (d *npyDtype) decode(b []byte) float64 {
+	var bits uint64
+	switch d.size {
+	case 1:
+		bits = uint64(b[0])
+	case 2:
+		bits = uint64(d.order.Uint16(b))
+	case 4:
+		bits = uint64(d.order.Uint32(b))
+	case 8:
+		bits = d.order.Uint64(b)
+	}
+	switch d.kind {
+	case 'f':
+		if d.size == 4 {
+			return float64(math.Float32frombits(uint32(bits)))
+		}
+		return math.Float64frombits(bits)
+	case 'i':
+		shift := uint(64 - 8*d.size)
+		return float64(int64(bits<<shift) >> shift)
+	case 'b':
+		if bits != 0 {
+			return 1
+		}
+		return 0
+	}
+	return float64(bits)
+}
+
+// Stores value in b as an element of this type. Returns an error if an
+// integer type cannot hold value exactly.
+func (d *npyDtype) encode(b []byte, value float64) error {
+	var bits uint64
+	switch d.kind {
+	case 'f':
+		if d.size == 4 {
+			bits = uint64(math.Float32bits(float32(value)))
+		} else {
+			bits = math.Float64bits(value)
+		}
+	case 'i', 'u':
+		min, max := -math.Ldexp(1, 8*d.size-1), math.Ldexp(1, 8*d.size-1)
+		if d.kind == 'u' {
+			min, max = 0, math.Ldexp(1, 8*d.size)
+		}
+		if value != math.Trunc(value) || value < min || value >= max {
+			return fmt.Errorf("Value does not fit %c%d: %g", d.kind, 8*d.size, value)
+		}
+		if d.kind == 'i' {
+			bits = uint64(int64(value))
+		} else {
+			bits = uint64(value)
+		}
+	case 'b':
+		if value != 0 {
+			bits = 1
+		}
+	}
+	switch d.size {
+	case 1:
+		b[0] = byte(bits)
+	case 2:
+		d.order.PutUint16(b, uint16(bits))
+	case 4:
+		d.order.PutUint32(b, uint32(bits))
+	case 8:
+		d.order.PutUint64(b, bits)
+	}
+	return nil
+}
+
+var (
+	npyDescrPattern   = regexp.MustCompile(`['"]descr['"]\s*:\s*['"]([^'"]*)['"]`)
+	npyFortranPattern = regexp.MustCompile(`['"]fortran_order['"]\s*:\s*(True|False)`)
+	npyShapePattern   = regexp.MustCompile(`['"]shape['"]\s*:\s*\(([^)]*)\)`)
+)
+
+// Reads a NumPy array from r and returns its shape and its cells in
+// row-major order.
+func readNpy(r io.Reader) ([]int, []float64, error) {
+	br := bufio.NewReader(r)
+	preamble := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(br, preamble); err != nil || string(preamble[:len(npyMagic)]) != npyMagic {
+		return nil, nil, fmt.Errorf("Missing NumPy magic string")
+	}
+	var headerLength int
+	switch major := preamble[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(br, binary.LittleEndian, &n); err != nil {
+			return nil, nil, err
+		}
+		headerLength = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(br, binary.LittleEndian, &n); err != nil {
+			return nil, nil, err
+		}
+		headerLength = int(n)
+	default:
+		return nil, nil, fmt.Errorf("Unsupported NumPy format version: %d", major)
+	}
+	header := make([]byte, headerLength)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, nil, err
+	}
+
+	descr := npyDescrPattern.FindSubmatch(header)
+	fortran := npyFortranPattern.FindSubmatch(header)
+	shapeMatch := npyShapePattern.FindSubmatch(header)
+	if descr == nil || fortran == nil || shapeMatch == nil {
+		return nil, nil, fmt.Errorf("Invalid NumPy header: %q", header)
+	}
+	dtype, err := parseNpyDtype(string(descr[1]))
+	if err != nil {
+		return nil, nil, err
+	}
+	var shape []int
+	size := 1
+	for _, f := range strings.Split(string(shapeMatch[1]), ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(f, "L"))
+		if err != nil || n < 0 {
+			return nil, nil, fmt.Errorf("Invalid NumPy shape: %q", shapeMatch[1])
+		}
+		shape = append(shape, n)
+		size *= n
+	}
+
+	values := make([]float64, size)
+	chunk := make([]byte, 4096*dtype.size)
+	for k := 0; k < size; {
+		n := size - k
+		if n > 4096 {
+			n = 4096
+		}
+		if _, err := io.ReadFull(br, chunk[:n*dtype.size]); err != nil {
+			return nil, nil, fmt.Errorf("Expected %d NumPy array elements, found %d", size, k)
+		}
+		for i := 0; i < n; i++ {
+			values[k+i] = dtype.decode(chunk[i*dtype.size:])
+		}
+		k += n
+	}
+	if string(fortran[1]) == "True" && len(shape) > 1 {
+		values = npyTranspose(values, shape)
+	}
+	return shape, values, nil
+}
+
+// Returns the cells of an array of the given shape in row-major order,
+// given its cells in column-major order.
+func npyTranspose(values []float64, shape []int) []float64 {
+	transposed := make([]float64, len(values))
+	index := make([]int, len(shape))
+	for k := range transposed {
+		offset := 0
+		for axis := len(shape) - 1; axis >= 0; axis-- {
+			offset = offset*shape[axis] + index[axis]
+		}
+		transposed[k] = values[offset]
+		for axis := len(shape) - 1; axis >= 0; axis-- {
+			if index[axis]++; index[axis] < shape[axis] {
+				break
+			}
+			index[axis] = 0
+		}
+	}
+	return transposed
+}
+
+// Returns a dense vector, matrix or cube holding the given cells.
+func newNpyArray(shape []int, values []float64) (interface{}, error) {
+	switch len(shape) {
+	case 1:
+		return &Vector{&DenseVec{common.NewCoreVec(false, shape[0], 0, 1), values}}, nil
+	case 2:
+		rows, columns := shape[0], shape[1]
+		return &Matrix{&DenseMat{common.NewCoreMat(false, rows, columns, columns, 1, 0, 0), values}}, nil
+	case 3:
+		slices, rows, columns := shape[0], shape[1], shape[2]
+		return &Cube{&DenseCub{common.NewCoreCub(false, slices, rows, columns, rows*columns, columns, 1, 0, 0, 0), values}}, nil
+	}
+	return nil, fmt.Errorf("Unsupported NumPy array with %d dimensions", len(shape))
+}
+
+// Reads a one-dimensional array in NumPy .npy format from r and returns
+// it as a dense vector. Arrays of floating point, integer and boolean
+// types, in C or Fortran order, are supported.
+func ReadNpyVector(r io.Reader) (*Vector, error) {
+	shape, values, err := readNpy(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 1 {
+		return nil, fmt.Errorf("Expected 1 dimension, found %d", len(shape))
+	}
+	v, _ := newNpyArray(shape, values)
+	return v.(*Vector), nil
+}
+
+// Reads a two-dimensional array in NumPy .npy format from r and returns
+// it as a dense matrix. Supported types are as for ReadNpyVector.
+func ReadNpyMatrix(r io.Reader) (*Matrix, error) {
+	shape, values, err := readNpy(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 2 {
+		return nil, fmt.Errorf("Expected 2 dimensions, found %d", len(shape))
+	}
+	A, _ := newNpyArray(shape, values)
+	return A.(*Matrix), nil
+}
+
+// Reads a three-dimensional array in NumPy .npy format from r and returns
+// it as a dense cube. Supported types are as for ReadNpyVector.
+func ReadNpyCube(r io.Reader) (*Cube, error) {
+	shape, values, err := readNpy(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(shape) != 3 {
+		return nil, fmt.Errorf("Expected 3 dimensions, found %d", len(shape))
+	}
+	A, _ := newNpyArray(shape, values)
+	return A.(*Cube), nil
+}
+
+// Writes vectors, matrices and cubes in NumPy .npy format, one array per
+// call. The fields may be changed between calls. The cells of views are
+// written in the order of the view, not of the underlying elements.
+type NpyWriter struct {
+	Dtype        string // Type of the written cells, such as NpyFloat64 or NpyInt32; NpyFloat64 by default.
+	FortranOrder bool   // Whether cells are written in column-major instead of row-major order.
+
+	w io.Writer
+}
+
+// Constructs and returns a new writer of NumPy arrays to w.
+func NewNpyWriter(w io.Writer) *NpyWriter {
+	return &NpyWriter{Dtype: NpyFloat64, w: w}
+}
+
+// Writes v as a one-dimensional array.
+func (e *NpyWriter) WriteVector(v Vec) error {
+	return e.write([]int{v.Size()}, func(index []int) float64 {
+		return v.GetQuick(index[0])
+	})
+}
+
+// Writes A as a two-dimensional array.
+func (e *NpyWriter) WriteMatrix(A Mat) error {
+	return e.write([]int{A.Rows(), A.Columns()}, func(index []int) float64 {
+		return A.GetQuick(index[0], index[1])
+	})
+}
+
+// Writes A as a three-dimensional array.
+func (e *NpyWriter) WriteCube(A Cub) error {
+	return e.write([]int{A.Slices(), A.Rows(), A.Columns()}, func(index []int) float64 {
+		return A.GetQuick(index[0], index[1], index[2])
+	})
+}
+
+// Writes the header of an array of the given shape, followed by the cells
+// returned by get for each index, with the last axis varying fastest in
+// C order and the first in Fortran order.
+func (e *NpyWriter) write(shape []int, get func([]int) float64) error {
+	dtype, err := parseNpyDtype(e.Dtype)
+	if err != nil {
+		return err
+	}
+	dims := make([]string, len(shape))
+	size := 1
+	for i, n := range shape {
+		dims[i] = strconv.Itoa(n)
+		size *= n
+	}
+	shapeString := strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		shapeString += ","
+	}
+	fortran := "False"
+	if e.FortranOrder {
+		fortran = "True"
+	}
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': (%s), }", e.Dtype, fortran, shapeString)
+	// The header is padded so that the data is 64 byte aligned.
+	padding := 63 - (len(npyMagic)+4+len(header))%64
+	header += strings.Repeat(" ", padding) + "\n"
+
+	bw := bufio.NewWriter(e.w)
+	bw.WriteString(npyMagic)
+	bw.Write([]byte{1, 0})
+	binary.Write(bw, binary.LittleEndian, uint16(len(header)))
+	bw.WriteString(header)
+
+	b := make([]byte, dtype.size)
+	index := make([]int, len(shape))
+	for k := 0; k < size; k++ {
+		if err := dtype.encode(b, get(index)); err != nil {
+			return err
+		}
+		bw.Write(b)
+		if e.FortranOrder {
+			for axis := 0; axis < len(shape); axis++ {
+				if index[axis]++; index[axis] < shape[axis] {
+					break
+				}
+				index[axis] = 0
+			}
+		} else {
+			for axis := len(shape) - 1; axis >= 0; axis-- {
+				if index[axis]++; index[axis] < shape[axis] {
+					break
+				}
+				index[axis] = 0
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// The arrays of a NumPy .npz archive, by name without the .npy suffix.
+// Names must be unique across vectors, matrices and cubes.
+type Npz struct {
+	Vectors    map[string]*Vector
+	Matrices   map[string]*Matrix
+	Cubes      map[string]*Cube
+	Compressed bool // Whether WriteNpz deflates the arrays, as numpy.savez_compressed does.
+}
+
+// Constructs and returns a new empty archive.
+func NewNpz() *Npz {
+	return &Npz{
+		Vectors:  make(map[string]*Vector),
+		Matrices: make(map[string]*Matrix),
+		Cubes:    make(map[string]*Cube),
+	}
+}
+
+// Reads a NumPy .npz archive of the given size from r. Each array must
+// have one, two or three dimensions and is returned as a dense vector,
+// matrix or cube respectively. Supported types are as for ReadNpyVector.
+func ReadNpz(r io.ReaderAt, size int64) (*Npz, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	z := NewNpz()
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".npy") {
+			return nil, fmt.Errorf("Unexpected file in NumPy archive: %s", f.Name)
+		}
+		name := strings.TrimSuffix(f.Name, ".npy")
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		shape, values, err := readNpy(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f.Name, err)
+		}
+		x, err := newNpyArray(shape, values)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f.Name, err)
+		}
+		switch x := x.(type) {
+		case *Vector:
+			z.Vectors[name] = x
+		case *Matrix:
+			z.Matrices[name] = x
+		case *Cube:
+			z.Cubes[name] = x
+		}
+	}
+	return z, nil
+}
+
+// Writes z to w as a NumPy .npz archive with the arrays in order of name
+// and cells of type NpyFloat64 in C order.
+func WriteNpz(w io.Writer, z *Npz) error {
+	arrays := make(map[string]func(*NpyWriter) error)
+	add := func(name string, write func(*NpyWriter) error) error {
+		if _, ok := arrays[name]; ok {
+			return fmt.Errorf("Duplicate NumPy array name: %s", name)
+		}
+		arrays[name] = write
+		return nil
+	}
+	for name, v := range z.Vectors {
+		v := v
+		if err := add(name, func(e *NpyWriter) error { return e.WriteVector(v) }); err != nil {
+			return err
+		}
+	}
+	for name, A := range z.Matrices {
+		A := A
+		if err := add(name, func(e *NpyWriter) error { return e.WriteMatrix(A) }); err != nil {
+			return err
+		}
+	}
+	for name, A := range z.Cubes {
+		A := A
+		if err := add(name, func(e *NpyWriter) error { return e.WriteCube(A) }); err != nil {
+			return err
+		}
+	}
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	method := zip.Store
+	if z.Compressed {
+		method = zip.Deflate
+	}
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: method})
+		if err != nil {
+			return err
+		}
+		if err := arrays[name](NewNpyWriter(fw)); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
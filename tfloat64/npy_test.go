@@ -0,0 +1,265 @@
+package tfloat64
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func npyBytes(descr, fortran, shape string, order binary.ByteOrder, data interface{}) []byte {
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': (%s), }\n", descr, fortran, shape)
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{1, 0})
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	binary.Write(&buf, order, data)
+	return buf.Bytes()
+}
+
+func TestReadNpy(t *testing.T) {
+	v, err := ReadNpyVector(bytes.NewReader(npyBytes("<u2", "False", "3,", binary.LittleEndian, []uint16{1, 65535, 7})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Size() != 3 || v.GetQuick(0) != 1 || v.GetQuick(1) != 65535 || v.GetQuick(2) != 7 {
+		t.Errorf("expected:%v actual:%s", []float64{1, 65535, 7}, v)
+	}
+
+	v, err = ReadNpyVector(bytes.NewReader(npyBytes("|b1", "False", "3,", binary.LittleEndian, []bool{true, false, true})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.GetQuick(0) != 1 || v.GetQuick(1) != 0 || v.GetQuick(2) != 1 {
+		t.Errorf("expected:%v actual:%s", []float64{1, 0, 1}, v)
+	}
+
+	expected := [][]float64{
+		{1, -2, 3},
+		{-4, 5, -6},
+	}
+	for _, b := range [][]byte{
+		npyBytes("<i4", "True", "2, 3", binary.LittleEndian, []int32{1, -4, -2, 5, 3, -6}),
+		npyBytes(">f8", "False", "2, 3", binary.BigEndian, []float64{1, -2, 3, -4, 5, -6}),
+		npyBytes("|i1", "False", "2, 3", binary.LittleEndian, []int8{1, -2, 3, -4, 5, -6}),
+	} {
+		A, err := ReadNpyMatrix(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if A.Rows() != 2 || A.Columns() != 3 {
+			t.Fatalf("expected:%d x %d actual:%d x %d", 2, 3, A.Rows(), A.Columns())
+		}
+		for r := range expected {
+			for c := range expected[r] {
+				if expected[r][c] != A.GetQuick(r, c) {
+					t.Errorf("expected:%g actual:%g", expected[r][c], A.GetQuick(r, c))
+				}
+			}
+		}
+	}
+
+	// A 2 x 3 x 4 cube with cell value 100*slice + 10*row + column.
+	values := make([]float32, 24)
+	k := 0
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 3; r++ {
+			for s := 0; s < 2; s++ {
+				values[k] = float32(100*s + 10*r + c)
+				k++
+			}
+		}
+	}
+	C, err := ReadNpyCube(bytes.NewReader(npyBytes("<f4", "True", "2, 3, 4", binary.LittleEndian, values)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for s := 0; s < 2; s++ {
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 4; c++ {
+				if value := C.GetQuick(s, r, c); value != float64(100*s+10*r+c) {
+					t.Errorf("expected:%d actual:%g", 100*s+10*r+c, value)
+				}
+			}
+		}
+	}
+}
+
+func TestReadNpyErrors(t *testing.T) {
+	matrix := npyBytes("<f8", "False", "2, 2", binary.LittleEndian, []float64{1, 2, 3, 4})
+	for _, b := range [][]byte{
+		[]byte("\x93NUMPX\x01\x00"),
+		matrix[:len(matrix)-1],
+		npyBytes("<c16", "False", "1,", binary.LittleEndian, []float64{1, 0}),
+		npyBytes("<f8", "False", "", binary.LittleEndian, []float64{1}),
+	} {
+		if _, err := ReadNpyMatrix(bytes.NewReader(b)); err == nil {
+			t.Errorf("expected error for %q", b)
+		}
+	}
+	if _, err := ReadNpyVector(bytes.NewReader(matrix)); err == nil {
+		t.Errorf("expected error for matrix read as vector")
+	}
+	if _, err := ReadNpyCube(bytes.NewReader(matrix)); err == nil {
+		t.Errorf("expected error for matrix read as cube")
+	}
+}
+
+func TestNpyWriter(t *testing.T) {
+	A := NewMatrix(2, 3)
+	A.AssignArray([][]float64{
+		{1, 0.5, -3},
+		{4, 1e-10, 7},
+	})
+	D := A.ViewDice()
+	for _, dtype := range []string{NpyFloat64, NpyFloat32, ">f8"} {
+		for _, fortran := range []bool{false, true} {
+			var buf bytes.Buffer
+			e := NewNpyWriter(&buf)
+			e.Dtype = dtype
+			e.FortranOrder = fortran
+			if err := e.WriteMatrix(D); err != nil {
+				t.Fatal(err)
+			}
+			if header := bytes.IndexByte(buf.Bytes(), '\n') + 1; header%64 != 0 {
+				t.Errorf("expected:%d actual:%d", 0, header%64)
+			}
+			B, err := ReadNpyMatrix(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if B.Rows() != 3 || B.Columns() != 2 {
+				t.Fatalf("expected:%d x %d actual:%d x %d", 3, 2, B.Rows(), B.Columns())
+			}
+			for r := 0; r < 3; r++ {
+				for c := 0; c < 2; c++ {
+					expected := D.GetQuick(r, c)
+					if dtype == NpyFloat32 {
+						expected = float64(float32(expected))
+					}
+					if B.GetQuick(r, c) != expected {
+						t.Errorf("expected:%g actual:%g", expected, B.GetQuick(r, c))
+					}
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	e := NewNpyWriter(&buf)
+	e.Dtype = NpyInt32
+	if err := e.WriteMatrix(A); err == nil {
+		t.Errorf("expected error for non-integer values")
+	}
+	buf.Reset()
+	v := NewVectorArray([]float64{3, -1, 0, 2})
+	if err := e.WriteVector(v.ViewFlip()); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "'descr': '<i4', 'fortran_order': False, 'shape': (4,), }") {
+		t.Errorf("unexpected header: %q", buf.String())
+	}
+	w, err := ReadNpyVector(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.EqualsVector(v.ViewFlip()) {
+		t.Errorf("expected:%s actual:%s", v.ViewFlip(), w)
+	}
+	e.Dtype = NpyBool
+	if err := e.WriteVector(v); err != nil {
+		t.Fatal(err)
+	}
+	w, err = ReadNpyVector(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.EqualsVector(NewVectorArray([]float64{1, 1, 0, 1})) {
+		t.Errorf("expected:%v actual:%s", []float64{1, 1, 0, 1}, w)
+	}
+	e.Dtype = "<c16"
+	if err := e.WriteVector(v); err == nil {
+		t.Errorf("expected error for complex type")
+	}
+}
+
+func TestNpyWriterCube(t *testing.T) {
+	C := NewCube(2, 3, 4)
+	for s := 0; s < 2; s++ {
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 4; c++ {
+				C.SetQuick(s, r, c, float64(100*s+10*r+c))
+			}
+		}
+	}
+	D, err := C.ViewDice(2, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fortran := range []bool{false, true} {
+		var buf bytes.Buffer
+		e := NewNpyWriter(&buf)
+		e.FortranOrder = fortran
+		if err := e.WriteCube(D); err != nil {
+			t.Fatal(err)
+		}
+		B, err := ReadNpyCube(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if B.Slices() != 4 || B.Rows() != 2 || B.Columns() != 3 {
+			t.Fatalf("expected:%d x %d x %d actual:%s", 4, 2, 3, B.StringShort())
+		}
+		for s := 0; s < 4; s++ {
+			for r := 0; r < 2; r++ {
+				for c := 0; c < 3; c++ {
+					if B.GetQuick(s, r, c) != D.GetQuick(s, r, c) {
+						t.Errorf("expected:%g actual:%g", D.GetQuick(s, r, c), B.GetQuick(s, r, c))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNpz(t *testing.T) {
+	A := NewMatrix(2, 2)
+	A.AssignArray([][]float64{{1, 2}, {3, 4}})
+	z := NewNpz()
+	z.Vectors["x"] = NewVectorArray([]float64{1.5, -2.5})
+	z.Matrices["A"] = A
+	z.Cubes["C"] = NewCube(2, 1, 3)
+	z.Cubes["C"].SetQuick(1, 0, 2, 9)
+	for _, compressed := range []bool{false, true} {
+		z.Compressed = compressed
+		var buf bytes.Buffer
+		if err := WriteNpz(&buf, z); err != nil {
+			t.Fatal(err)
+		}
+		read, err := ReadNpz(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(read.Vectors) != 1 || len(read.Matrices) != 1 || len(read.Cubes) != 1 {
+			t.Fatalf("expected:%d %d %d actual:%d %d %d", 1, 1, 1, len(read.Vectors), len(read.Matrices), len(read.Cubes))
+		}
+		if !read.Vectors["x"].EqualsVector(z.Vectors["x"]) {
+			t.Errorf("expected:%s actual:%s", z.Vectors["x"], read.Vectors["x"])
+		}
+		if !read.Matrices["A"].EqualsMatrix(A) {
+			t.Errorf("expected:%s actual:%s", A, read.Matrices["A"])
+		}
+		if C := read.Cubes["C"]; C.Slices() != 2 || C.Rows() != 1 || C.Columns() != 3 || C.GetQuick(1, 0, 2) != 9 {
+			t.Errorf("expected:%s actual:%s", z.Cubes["C"], C)
+		}
+	}
+	z.Vectors["A"] = NewVector(1)
+	if err := WriteNpz(&bytes.Buffer{}, z); err == nil {
+		t.Errorf("expected error for duplicate name")
+	}
+	if _, err := ReadNpz(bytes.NewReader([]byte("not a zip")), 9); err == nil {
+		t.Errorf("expected error for invalid archive")
+	}
+}
@@ -0,0 +1,112 @@
+package tfloat64
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Returns a reverse Cuthill-McKee (RCM) ordering of the square matrix A,
+// computed on the pattern of A+A'. The symmetric permutation A[p,p] of A,
+// where p is the returned permutation, typically has a much smaller
+// bandwidth and profile than A itself. Each connected component is
+// ordered starting from a pseudo-peripheral node.
+//
+// Reference: A. George and J. W. H. Liu, "Computer Solution of Large
+// Sparse Positive Definite Systems", Prentice-Hall, 1981.
+func RCMOrder(A *Matrix) ([]int, error) {
+	if A.Rows() != A.Columns() {
+		return nil, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	cc := columnCompressed(A.Mat)
+	Cp, Ci := symmetricPattern(cc)
+	return rcm(A.Rows(), Cp, Ci), nil
+}
+
+// Returns the reverse Cuthill-McKee ordering of the symmetric n x n
+// pattern C, given in compressed column form without the diagonal.
+func rcm(n int, Cp, Ci []int) []int {
+	degree := func(i int) int {
+		return Cp[i+1] - Cp[i]
+	}
+	visited := make([]bool, n)
+	level := make([]int, n)
+	for i := range level {
+		level[i] = -1
+	}
+	queue := make([]int, 0, n)
+	order := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if visited[i] {
+			continue
+		}
+		start := pseudoPeripheral(i, Cp, Ci, level, queue[:0])
+
+		// Cuthill-McKee: breadth first, neighbours by increasing degree.
+		visited[start] = true
+		order = append(order, start)
+		for head := len(order) - 1; head < len(order); head++ {
+			j := order[head]
+			first := len(order)
+			for p := Cp[j]; p < Cp[j+1]; p++ {
+				if k := Ci[p]; !visited[k] {
+					visited[k] = true
+					order = append(order, k)
+				}
+			}
+			adjacent := order[first:]
+			sort.SliceStable(adjacent, func(a, b int) bool {
+				return degree(adjacent[a]) < degree(adjacent[b])
+			})
+		}
+	}
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// Returns a pseudo-peripheral node of the connected component containing
+// root, found by repeatedly moving to a node of minimum degree in the
+// last level of the rooted level structure while its eccentricity grows.
+func pseudoPeripheral(root int, Cp, Ci []int, level, queue []int) int {
+	queue, eccentricity := levelStructure(root, Cp, Ci, level, queue)
+	for {
+		x := -1
+		for q := len(queue) - 1; q >= 0 && level[queue[q]] == eccentricity; q-- {
+			i := queue[q]
+			if x == -1 || Cp[i+1]-Cp[i] < Cp[x+1]-Cp[x] {
+				x = i
+			}
+		}
+		for _, i := range queue {
+			level[i] = -1
+		}
+		var e int
+		queue, e = levelStructure(x, Cp, Ci, level, queue[:0])
+		if e <= eccentricity {
+			for _, i := range queue {
+				level[i] = -1
+			}
+			return root
+		}
+		root, eccentricity = x, e
+	}
+}
+
+// Computes the level structure rooted at the given node by a breadth
+// first search, setting level[i] for each reached node. Returns the
+// reached nodes in breadth first order and the depth of the structure.
+func levelStructure(root int, Cp, Ci []int, level, queue []int) ([]int, int) {
+	level[root] = 0
+	queue = append(queue, root)
+	for head := 0; head < len(queue); head++ {
+		i := queue[head]
+		for p := Cp[i]; p < Cp[i+1]; p++ {
+			if j := Ci[p]; level[j] == -1 {
+				level[j] = level[i] + 1
+				queue = append(queue, j)
+			}
+		}
+	}
+	return queue, level[queue[len(queue)-1]]
+}
@@ -0,0 +1,106 @@
+package tfloat64
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Returns A[p,p] for a random permutation p as a new sparse matrix.
+func makeShuffledMatrix(A *Matrix) *Matrix {
+	n := A.Rows()
+	p := rand.Perm(n)
+	B := NewSparseMatrix(n, n)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			B.SetQuick(r, c, A.GetQuick(p[r], p[c]))
+		}
+	}
+	return B
+}
+
+func testPermutation(t *testing.T, p []int, n int) {
+	if len(p) != n {
+		t.Fatalf("expected:%d actual:%d", n, len(p))
+	}
+	seen := make([]bool, n)
+	for _, i := range p {
+		if i < 0 || i >= n || seen[i] {
+			t.Fatalf("not a permutation: %v", p)
+		}
+		seen[i] = true
+	}
+}
+
+func TestBandwidth(t *testing.T) {
+	A := NewMatrix(4, 4)
+	if k := prop.SemiBandwidth(A); k != 1 {
+		t.Errorf("expected:1 actual:%d", k)
+	}
+	A.SetQuick(3, 1, 1)
+	A.SetQuick(0, 3, 1e-12)
+	if k := prop.LowerBandwidth(A); k != 2 {
+		t.Errorf("expected:2 actual:%d", k)
+	}
+	if k := prop.UpperBandwidth(A); k != 0 {
+		t.Errorf("expected:0 actual:%d", k)
+	}
+	P := makePoisson2DMatrix(6)
+	for _, A := range []*Matrix{P, NewSparseRCMatrixMat(P)} {
+		if k := prop.UpperBandwidth(A); k != 6 {
+			t.Errorf("expected:6 actual:%d", k)
+		}
+		if k := prop.SemiBandwidth(A); k != 7 {
+			t.Errorf("expected:7 actual:%d", k)
+		}
+	}
+}
+
+func TestRCMOrder(t *testing.T) {
+	A := makeShuffledMatrix(makePoisson2DMatrix(12))
+	n := A.Rows()
+	p, err := RCMOrder(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testPermutation(t, p, n)
+
+	B, err := A.ViewPermuted(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			if B.GetQuick(r, c) != A.GetQuick(p[r], p[c]) {
+				t.Fatalf("expected:%g actual:%g", A.GetQuick(p[r], p[c]), B.GetQuick(r, c))
+			}
+		}
+	}
+	before := prop.SemiBandwidth(A)
+	after := prop.SemiBandwidth(B)
+	if after > 14 || after >= before {
+		t.Errorf("expected bandwidth less than %d actual:%d", before, after)
+	}
+
+	// Disconnected components and isolated nodes.
+	D := NewSparseMatrix(5, 5)
+	D.SetQuick(0, 3, 1)
+	D.SetQuick(3, 0, 1)
+	p, err = RCMOrder(D)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testPermutation(t, p, 5)
+}
+
+func TestViewPermutedErrors(t *testing.T) {
+	A := makePoisson2DMatrix(3)
+	if _, err := A.ViewPermuted([]int{0, 1, 2}); err == nil {
+		t.Error("expected permutation length error")
+	}
+	if _, err := A.ViewPermuted([]int{0, 1, 2, 3, 4, 5, 6, 7, 7}); err == nil {
+		t.Error("expected invalid permutation error")
+	}
+	if _, err := NewMatrix(2, 3).ViewPermuted([]int{0, 1}); err == nil {
+		t.Error("expected square matrix error")
+	}
+}
@@ -0,0 +1,488 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Returns the cells of the square matrix A in compressed row storage
+// together with the position of the diagonal cell of each row, or an error
+// if A is not square or a diagonal cell is zero.
+func rowCompressedDiagonal(A *Matrix) (*SparseRCMat, []int, error) {
+	if A.Rows() != A.Columns() {
+		return nil, nil, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	rc := rowCompressed(A.Mat)
+	diag := make([]int, rc.Rows())
+	for i := range diag {
+		k, found := rc.search(i, i)
+		if !found {
+			return nil, nil, fmt.Errorf("zero diagonal at row %d", i)
+		}
+		diag[i] = k
+	}
+	return rc, diag, nil
+}
+
+func checkApply(n int, b, x *Vector) error {
+	if n < 0 {
+		return fmt.Errorf("preconditioner matrix not set")
+	}
+	if b.Size() != n || x.Size() != n {
+		return fmt.Errorf("Incompatible args: %d, %s, %s", n, b.StringShort(), x.StringShort())
+	}
+	return nil
+}
+
+// Diagonal (Jacobi) preconditioner, M = diag(A).
+type JacobiPreconditioner struct {
+	invDiag []float64
+}
+
+// Constructs and returns a new Jacobi preconditioner. SetMatrix must be
+// called before Apply; the iterative solvers do so.
+func NewJacobiPreconditioner() *JacobiPreconditioner {
+	return &JacobiPreconditioner{}
+}
+
+func (p *JacobiPreconditioner) SetMatrix(A *Matrix) error {
+	if A.Rows() != A.Columns() {
+		return fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	p.invDiag = make([]float64, A.Rows())
+	for i := range p.invDiag {
+		d := A.GetQuick(i, i)
+		if d == 0 {
+			return fmt.Errorf("zero diagonal at row %d", i)
+		}
+		p.invDiag[i] = 1 / d
+	}
+	return nil
+}
+
+func (p *JacobiPreconditioner) Apply(b, x *Vector) error {
+	n := -1
+	if p.invDiag != nil {
+		n = len(p.invDiag)
+	}
+	if err := checkApply(n, b, x); err != nil {
+		return err
+	}
+	for i, d := range p.invDiag {
+		x.SetQuick(i, d*b.GetQuick(i))
+	}
+	return nil
+}
+
+// Symmetric successive over-relaxation preconditioner,
+// M = (D + omega*L) * D^-1 * (D + omega*U) / (omega*(2 - omega)),
+// where D, L and U are the diagonal, strictly lower and strictly upper
+// parts of A.
+type SSORPreconditioner struct {
+	omega float64
+	a     *SparseRCMat
+	diag  []int
+}
+
+// Constructs and returns a new SSOR preconditioner with the given
+// relaxation factor, which must lie in (0, 2). SetMatrix must be called
+// before Apply; the iterative solvers do so.
+func NewSSORPreconditioner(omega float64) (*SSORPreconditioner, error) {
+	if !(omega > 0 && omega < 2) {
+		return nil, fmt.Errorf("omega must lie in (0, 2): %g", omega)
+	}
+	return &SSORPreconditioner{omega: omega}, nil
+}
+
+// Returns the relaxation factor.
+func (p *SSORPreconditioner) Omega() float64 {
+	return p.omega
+}
+
+func (p *SSORPreconditioner) SetMatrix(A *Matrix) error {
+	a, diag, err := rowCompressedDiagonal(A)
+	if err != nil {
+		return err
+	}
+	p.a = a
+	p.diag = diag
+	return nil
+}
+
+func (p *SSORPreconditioner) Apply(b, x *Vector) error {
+	n := -1
+	if p.a != nil {
+		n = p.a.Rows()
+	}
+	if err := checkApply(n, b, x); err != nil {
+		return err
+	}
+	a := p.a
+	omega := p.omega
+	y := make([]float64, n)
+
+	// (D + omega*L) * y = omega*(2 - omega) * b
+	for i := 0; i < n; i++ {
+		s := 0.0
+		for k := a.rowPointers[i]; k < p.diag[i]; k++ {
+			s += a.values[k] * y[a.columnIndexes[k]]
+		}
+		y[i] = (omega*(2-omega)*b.GetQuick(i) - omega*s) / a.values[p.diag[i]]
+	}
+	// (D + omega*U) * x = D * y
+	for i := n - 1; i >= 0; i-- {
+		s := 0.0
+		for k := p.diag[i] + 1; k < a.rowPointers[i+1]; k++ {
+			s += a.values[k] * y[a.columnIndexes[k]]
+		}
+		d := a.values[p.diag[i]]
+		y[i] = (d*y[i] - omega*s) / d
+	}
+	x.AssignArray(y)
+	return nil
+}
+
+// Incomplete LU factors held in compressed row storage. The strictly lower
+// part of each row holds L, which has a unit diagonal, and the remainder
+// holds U.
+type incompleteLU struct {
+	lu   *SparseRCMat
+	diag []int // Position of the diagonal cell of each row.
+}
+
+// Computes x = (L*U)^-1 * b.
+func (f *incompleteLU) Apply(b, x *Vector) error {
+	n := -1
+	if f.lu != nil {
+		n = f.lu.Rows()
+	}
+	if err := checkApply(n, b, x); err != nil {
+		return err
+	}
+	lu := f.lu
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		s := b.GetQuick(i)
+		for k := lu.rowPointers[i]; k < f.diag[i]; k++ {
+			s -= lu.values[k] * y[lu.columnIndexes[k]]
+		}
+		y[i] = s
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := y[i]
+		for k := f.diag[i] + 1; k < lu.rowPointers[i+1]; k++ {
+			s -= lu.values[k] * y[lu.columnIndexes[k]]
+		}
+		y[i] = s / lu.values[f.diag[i]]
+	}
+	x.AssignArray(y)
+	return nil
+}
+
+// Returns the incomplete factors as a single matrix holding L - I + U.
+func (f *incompleteLU) LU() *Matrix {
+	return &Matrix{f.lu}
+}
+
+// Incomplete LU preconditioner with zero fill-in, ILU(0). The factors have
+// the same sparsity pattern as A.
+type ILU0Preconditioner struct {
+	incompleteLU
+}
+
+// Constructs and returns a new ILU(0) preconditioner. SetMatrix must be
+// called before Apply; the iterative solvers do so.
+func NewILU0Preconditioner() *ILU0Preconditioner {
+	return &ILU0Preconditioner{}
+}
+
+func (p *ILU0Preconditioner) SetMatrix(A *Matrix) error {
+	a, diag, err := rowCompressedDiagonal(A)
+	if err != nil {
+		return err
+	}
+	n := a.Rows()
+	lu := newSparseRCMat(n, n)
+	copy(lu.rowPointers, a.rowPointers)
+	lu.columnIndexes = append(lu.columnIndexes, a.columnIndexes...)
+	lu.values = append(lu.values, a.values...)
+
+	position := make([]int, n)
+	for i := range position {
+		position[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		low := lu.rowPointers[i]
+		high := lu.rowPointers[i+1]
+		for k := low; k < high; k++ {
+			position[lu.columnIndexes[k]] = k
+		}
+		for k := low; k < diag[i]; k++ {
+			j := lu.columnIndexes[k]
+			pivot := lu.values[diag[j]]
+			if pivot == 0 {
+				return fmt.Errorf("zero pivot at row %d", j)
+			}
+			l := lu.values[k] / pivot
+			lu.values[k] = l
+			for kk := diag[j] + 1; kk < lu.rowPointers[j+1]; kk++ {
+				if pos := position[lu.columnIndexes[kk]]; pos != -1 {
+					lu.values[pos] -= l * lu.values[kk]
+				}
+			}
+		}
+		for k := low; k < high; k++ {
+			position[lu.columnIndexes[k]] = -1
+		}
+	}
+	for i := 0; i < n; i++ {
+		if lu.values[diag[i]] == 0 {
+			return fmt.Errorf("zero pivot at row %d", i)
+		}
+	}
+	p.lu = lu
+	p.diag = diag
+	return nil
+}
+
+// Incomplete LU preconditioner with threshold dropping, ILUT. Entries of
+// row i smaller than dropTolerance * norm2(A[i,:]) are dropped and at
+// most fill of the largest entries are kept in each of the L and U parts
+// of a row.
+type ILUTPreconditioner struct {
+	incompleteLU
+	dropTolerance float64
+	fill          int
+}
+
+// Constructs and returns a new ILUT preconditioner with the given drop
+// tolerance and fill limit per row of each factor. A fill limit of zero
+// or less keeps all entries above the drop tolerance. SetMatrix must be
+// called before Apply; the iterative solvers do so.
+func NewILUTPreconditioner(dropTolerance float64, fill int) *ILUTPreconditioner {
+	return &ILUTPreconditioner{dropTolerance: math.Abs(dropTolerance), fill: fill}
+}
+
+// Returns the drop tolerance.
+func (p *ILUTPreconditioner) DropTolerance() float64 {
+	return p.dropTolerance
+}
+
+// Returns the maximum number of entries kept in each of the L and U parts
+// of a row.
+func (p *ILUTPreconditioner) Fill() int {
+	return p.fill
+}
+
+func (p *ILUTPreconditioner) SetMatrix(A *Matrix) error {
+	if A.Rows() != A.Columns() {
+		return fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	a := rowCompressed(A.Mat)
+	n := a.Rows()
+	lu := newSparseRCMat(n, n)
+	diag := make([]int, n)
+
+	w := make([]float64, n)
+	nonZero := make([]bool, n)
+	pattern := make([]int, 0)
+	for i := 0; i < n; i++ {
+		// Scatter row i into w.
+		norm := 0.0
+		pending := make([]int, 0)
+		for k := a.rowPointers[i]; k < a.rowPointers[i+1]; k++ {
+			j := a.columnIndexes[k]
+			w[j] = a.values[k]
+			nonZero[j] = true
+			pattern = append(pattern, j)
+			if j < i {
+				pending = append(pending, j)
+			}
+			norm += a.values[k] * a.values[k]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			return fmt.Errorf("zero row %d", i)
+		}
+		tol := p.dropTolerance * norm
+
+		// Eliminate the lower part in ascending column order.
+		for len(pending) > 0 {
+			k := pending[0]
+			pending = pending[1:]
+			w[k] /= lu.values[diag[k]]
+			if math.Abs(w[k]) < tol {
+				w[k] = 0
+				continue
+			}
+			for kk := diag[k] + 1; kk < lu.rowPointers[k+1]; kk++ {
+				j := lu.columnIndexes[kk]
+				if !nonZero[j] {
+					nonZero[j] = true
+					pattern = append(pattern, j)
+					if j < i {
+						at := sort.SearchInts(pending, j)
+						pending = append(pending, 0)
+						copy(pending[at+1:], pending[at:])
+						pending[at] = j
+					}
+				}
+				w[j] -= w[k] * lu.values[kk]
+			}
+		}
+
+		// Gather the largest entries of each part.
+		lower := make([]int, 0)
+		upper := make([]int, 0)
+		for _, j := range pattern {
+			if j < i && math.Abs(w[j]) >= tol && w[j] != 0 {
+				lower = append(lower, j)
+			} else if j > i && math.Abs(w[j]) >= tol && w[j] != 0 {
+				upper = append(upper, j)
+			}
+		}
+		lower = p.largest(lower, w)
+		upper = p.largest(upper, w)
+
+		d := w[i]
+		if d == 0 {
+			d = (1e-4 + p.dropTolerance) * norm
+		}
+		for _, j := range lower {
+			lu.columnIndexes = append(lu.columnIndexes, j)
+			lu.values = append(lu.values, w[j])
+		}
+		diag[i] = len(lu.values)
+		lu.columnIndexes = append(lu.columnIndexes, i)
+		lu.values = append(lu.values, d)
+		for _, j := range upper {
+			lu.columnIndexes = append(lu.columnIndexes, j)
+			lu.values = append(lu.values, w[j])
+		}
+		lu.rowPointers[i+1] = len(lu.values)
+
+		for _, j := range pattern {
+			w[j] = 0
+			nonZero[j] = false
+		}
+		pattern = pattern[:0]
+	}
+	p.lu = lu
+	p.diag = diag
+	return nil
+}
+
+// Returns the fill largest entries of w among the given columns, sorted by
+// column.
+func (p *ILUTPreconditioner) largest(columns []int, w []float64) []int {
+	if p.fill > 0 && len(columns) > p.fill {
+		sort.Slice(columns, func(a, b int) bool {
+			return math.Abs(w[columns[a]]) > math.Abs(w[columns[b]])
+		})
+		columns = columns[:p.fill]
+	}
+	sort.Ints(columns)
+	return columns
+}
+
+// Incomplete Cholesky preconditioner with zero fill-in, IC(0), for
+// symmetric positive definite matrices. M = L*L' where L has the sparsity
+// pattern of the lower triangle of A.
+type ICPreconditioner struct {
+	l *SparseRCMat // Lower triangular factor; the diagonal is the last cell of each row.
+}
+
+// Constructs and returns a new IC(0) preconditioner. SetMatrix must be
+// called before Apply; the iterative solvers do so.
+func NewICPreconditioner() *ICPreconditioner {
+	return &ICPreconditioner{}
+}
+
+// Returns the lower triangular factor L.
+func (p *ICPreconditioner) L() *Matrix {
+	return &Matrix{p.l}
+}
+
+func (p *ICPreconditioner) SetMatrix(A *Matrix) error {
+	a, diag, err := rowCompressedDiagonal(A)
+	if err != nil {
+		return err
+	}
+	n := a.Rows()
+	l := newSparseRCMat(n, n)
+	for i := 0; i < n; i++ {
+		start := len(l.values)
+		for k := a.rowPointers[i]; k <= diag[i]; k++ {
+			j := a.columnIndexes[k]
+
+			// s = a[i,j] - L[i,0:j] * L[j,0:j]'
+			s := a.values[k]
+			ki := start
+			kj := l.rowPointers[j]
+			endj := l.rowPointers[j+1]
+			if j < i {
+				endj-- // Exclude the diagonal of row j.
+			} else {
+				endj = len(l.values)
+			}
+			for ki < len(l.values) && kj < endj {
+				ci := l.columnIndexes[ki]
+				cj := l.columnIndexes[kj]
+				if ci == cj {
+					s -= l.values[ki] * l.values[kj]
+					ki++
+					kj++
+				} else if ci < cj {
+					ki++
+				} else {
+					kj++
+				}
+			}
+
+			if j < i {
+				s /= l.values[l.rowPointers[j+1]-1]
+			} else {
+				if s <= 0 {
+					return fmt.Errorf("Matrix is not positive definite at row %d", i)
+				}
+				s = math.Sqrt(s)
+			}
+			l.columnIndexes = append(l.columnIndexes, j)
+			l.values = append(l.values, s)
+		}
+		l.rowPointers[i+1] = len(l.values)
+	}
+	p.l = l
+	return nil
+}
+
+// Computes x = (L*L')^-1 * b.
+func (p *ICPreconditioner) Apply(b, x *Vector) error {
+	n := -1
+	if p.l != nil {
+		n = p.l.Rows()
+	}
+	if err := checkApply(n, b, x); err != nil {
+		return err
+	}
+	l := p.l
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		s := b.GetQuick(i)
+		last := l.rowPointers[i+1] - 1
+		for k := l.rowPointers[i]; k < last; k++ {
+			s -= l.values[k] * y[l.columnIndexes[k]]
+		}
+		y[i] = s / l.values[last]
+	}
+	for i := n - 1; i >= 0; i-- {
+		last := l.rowPointers[i+1] - 1
+		y[i] /= l.values[last]
+		for k := l.rowPointers[i]; k < last; k++ {
+			y[l.columnIndexes[k]] -= l.values[k] * y[i]
+		}
+	}
+	x.AssignArray(y)
+	return nil
+}
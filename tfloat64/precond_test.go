@@ -0,0 +1,131 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns the matrix of the two dimensional Poisson equation on an m x m
+// grid using the five point stencil.
+func makePoisson2DMatrix(m int) *Matrix {
+	A := NewSparseMatrix(m*m, m*m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			k := i*m + j
+			A.SetQuick(k, k, 4)
+			if i > 0 {
+				A.SetQuick(k, k-m, -1)
+			}
+			if i < m-1 {
+				A.SetQuick(k, k+m, -1)
+			}
+			if j > 0 {
+				A.SetQuick(k, k-1, -1)
+			}
+			if j < m-1 {
+				A.SetQuick(k, k+1, -1)
+			}
+		}
+	}
+	return A
+}
+
+// Returns a right hand side with components in all eigenvectors of the
+// Poisson matrix.
+func makePoisson2DRHS(n int) *Vector {
+	b := NewVector(n)
+	for i := 0; i < n; i++ {
+		b.SetQuick(i, float64(i%7))
+	}
+	return b
+}
+
+func testPreconditioner(t *testing.T, s iterativeSolverTest, unpreconditioned int) {
+	testIterativeSolver(t, s, makePoisson2DMatrix(15))
+	A := makePoisson2DMatrix(15)
+	b := makePoisson2DRHS(A.Rows())
+	if _, err := s.Solve(A, b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s.Iterations() >= unpreconditioned {
+		t.Errorf("expected fewer than %d iterations actual:%d", unpreconditioned, s.Iterations())
+	}
+}
+
+func TestPreconditioners(t *testing.T) {
+	A := makePoisson2DMatrix(15)
+	b := makePoisson2DRHS(A.Rows())
+	cg := NewConjugateGradient()
+	cg.Solve(A, b, nil)
+	unpreconditioned := cg.Iterations()
+
+	ssor, err := NewSSORPreconditioner(1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []Preconditioner{ssor, NewICPreconditioner()} {
+		s := NewConjugateGradient()
+		s.SetPreconditioner(p)
+		testPreconditioner(t, s, unpreconditioned)
+	}
+	for _, p := range []Preconditioner{NewILU0Preconditioner(), NewILUTPreconditioner(1e-3, 10)} {
+		s := NewBiCGStab()
+		s.SetPreconditioner(p)
+		testPreconditioner(t, s, unpreconditioned)
+		g := NewGMRES(20)
+		g.SetPreconditioner(p)
+		testPreconditioner(t, g, unpreconditioned)
+	}
+
+	// Jacobi is equivalent to scaling, which is the identity for a
+	// constant diagonal; test it on a matrix with a varying diagonal.
+	s := NewBiCGStab()
+	s.SetPreconditioner(NewJacobiPreconditioner())
+	testIterativeSolver(t, s, makeSquareMatrix(NewSparseMatrix(nsquare, nsquare)))
+}
+
+func TestIncompleteFactorsExact(t *testing.T) {
+	// Factorizations of a tridiagonal matrix have no fill-in, so the
+	// incomplete factors are exact.
+	A := makePoissonMatrix(NewSparseRCMatrix(20, 20))
+	x := NewVector(20)
+	for i := 0; i < x.Size(); i++ {
+		x.SetQuick(i, rand.Float64())
+	}
+	b, _ := A.ZMult(x, nil)
+	for _, p := range []Preconditioner{NewILU0Preconditioner(), NewILUTPreconditioner(0, 0), NewICPreconditioner()} {
+		if err := p.SetMatrix(A); err != nil {
+			t.Fatal(err)
+		}
+		y := NewVector(20)
+		if err := p.Apply(b, y); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < x.Size(); i++ {
+			if math.Abs(x.GetQuick(i)-y.GetQuick(i)) > tol {
+				t.Errorf("expected:%g actual:%g", x.GetQuick(i), y.GetQuick(i))
+			}
+		}
+	}
+}
+
+func TestPreconditionerErrors(t *testing.T) {
+	if _, err := NewSSORPreconditioner(2); err == nil {
+		t.Errorf("expected error for omega out of range")
+	}
+	A := NewSparseMatrix(3, 3)
+	A.SetQuick(0, 0, 1)
+	A.SetQuick(1, 1, -1)
+	A.SetQuick(2, 2, 1)
+	if err := NewICPreconditioner().SetMatrix(A); err == nil {
+		t.Errorf("expected error for indefinite matrix")
+	}
+	A.SetQuick(1, 1, 0)
+	if err := NewJacobiPreconditioner().SetMatrix(A); err == nil {
+		t.Errorf("expected error for zero diagonal")
+	}
+	if err := NewJacobiPreconditioner().Apply(NewVector(3), NewVector(3)); err == nil {
+		t.Errorf("expected error for unset matrix")
+	}
+}
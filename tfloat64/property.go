@@ -1,11 +1,30 @@
 package tfloat64
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 type Property struct {
 	tolerance float64
 }
 
+// Constructs and returns a new property object with the given tolerance.
+// The absolute value is used.
+func NewProperty(tolerance float64) *Property {
+	return &Property{math.Abs(tolerance)}
+}
+
+// Returns the current tolerance.
+func (p *Property) Tolerance() float64 {
+	return p.tolerance
+}
+
+// Sets the tolerance to math.Abs(tolerance).
+func (p *Property) SetTolerance(tolerance float64) {
+	p.tolerance = math.Abs(tolerance)
+}
+
 // Returns whether all cells of the given matrix A are equal to the
 // given value. The result is true if and only if
 // A != nil and !(math.Abs(value - A[i]) > tolerance)
@@ -110,3 +129,278 @@ func (p *Property) MatrixEqualsMatrix(A, B Mat) bool {
 	}
 	return true
 }
+
+// Returns whether all cells of the given cube A are equal to the given
+// value. The result is true if and only if A != nil and
+// !(math.Abs(value - A[slice,row,col]) > tolerance) holds for all
+// coordinates.
+func (p *Property) CubeEqualsValue(A Cub, value float64) bool {
+	if A == nil {
+		return false
+	}
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				x := A.GetQuick(s, r, c)
+				diff := math.Abs(value - x)
+				if (diff != diff) && ((value != value && x != x) || value == x) {
+					diff = 0
+				}
+				if !(diff <= p.tolerance) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Returns whether both given cubes A and B are equal. The result is true
+// if A==B. Otherwise, the result is true if and only if both arguments
+// are != nil, have the same number of slices, rows and columns and
+// !(math.Abs(A[slice,row,col] - B[slice,row,col]) > tolerance) holds for
+// all coordinates.
+func (p *Property) CubeEqualsCube(A, B Cub) bool {
+	if A == B {
+		return true
+	}
+	if !(A != nil && B != nil) {
+		return false
+	}
+	if A.Slices() != B.Slices() || A.Rows() != B.Rows() || A.Columns() != B.Columns() {
+		return false
+	}
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				x := A.GetQuick(s, r, c)
+				value := B.GetQuick(s, r, c)
+				diff := math.Abs(value - x)
+				if (diff != diff) && ((value != value && x != x) || value == x) {
+					diff = 0
+				}
+				if !(diff <= p.tolerance) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Returns the lower bandwidth of the given matrix A: the largest k such
+// that A[i+k,i] is not zero for some i. A cell is considered zero if its
+// absolute value is not greater than the tolerance. A diagonal matrix has
+// a lower bandwidth of 0.
+func (p *Property) LowerBandwidth(A Mat) int {
+	lower, _ := p.bandwidths(A)
+	return lower
+}
+
+// Returns the upper bandwidth of the given matrix A: the largest k such
+// that A[i,i+k] is not zero for some i. A lower triangular matrix has an
+// upper bandwidth of 0.
+func (p *Property) UpperBandwidth(A Mat) int {
+	_, upper := p.bandwidths(A)
+	return upper
+}
+
+// Returns the semi-bandwidth of the given matrix A:
+// 1 + max(LowerBandwidth(A), UpperBandwidth(A)). All non-zero cells of A
+// lie within the band of width 2*SemiBandwidth(A)-1 about the diagonal;
+// a diagonal matrix has a semi-bandwidth of 1 and a tridiagonal matrix
+// one of 2.
+func (p *Property) SemiBandwidth(A Mat) int {
+	lower, upper := p.bandwidths(A)
+	if upper > lower {
+		return upper + 1
+	}
+	return lower + 1
+}
+
+// Returns the lower and upper bandwidths of A.
+func (p *Property) bandwidths(A Mat) (int, int) {
+	lower, upper := 0, 0
+	forEachNonZero(A, func(r, c int, value float64) {
+		if !(math.Abs(value) <= p.tolerance) {
+			if r-c > lower {
+				lower = r - c
+			}
+			if c-r > upper {
+				upper = c - r
+			}
+		}
+	})
+	return lower, upper
+}
+
+// Applies the given function to each non-zero cell of A, visiting only
+// the non-zero cells if the backend supports it.
+func forEachNonZero(A Mat, function func(r, c int, value float64)) {
+	if m, ok := A.(*Matrix); ok {
+		A = m.Mat
+	}
+	if nz, ok := A.(nonZeroProcedureMat); ok {
+		nz.ForEachNonZeroProcedure(func(r, c int, value float64) bool {
+			function(r, c, value)
+			return true
+		})
+		return
+	}
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if value := A.GetQuick(r, c); value != 0 {
+				function(r, c, value)
+			}
+		}
+	}
+}
+
+// Returns whether all cells of A below the main diagonal are zero.
+func (p *Property) IsUpperTriangular(A Mat) bool {
+	lower, _ := p.bandwidths(A)
+	return lower == 0
+}
+
+// Returns whether all cells of A above the main diagonal are zero.
+func (p *Property) IsLowerTriangular(A Mat) bool {
+	_, upper := p.bandwidths(A)
+	return upper == 0
+}
+
+// Returns whether all cells of A off the main diagonal are zero.
+func (p *Property) IsDiagonal(A Mat) bool {
+	lower, upper := p.bandwidths(A)
+	return lower == 0 && upper == 0
+}
+
+// Returns whether all cells of A off the main diagonal and the diagonals
+// directly above and below it are zero.
+func (p *Property) IsTridiagonal(A Mat) bool {
+	lower, upper := p.bandwidths(A)
+	return lower <= 1 && upper <= 1
+}
+
+// Returns whether A is square and A[i,j] equals A[j,i] for all
+// coordinates.
+func (p *Property) IsSymmetric(A Mat) bool {
+	return p.isSymmetric(A, 1)
+}
+
+// Returns whether A is square and A[i,j] equals -A[j,i] for all
+// coordinates. The diagonal of a skew-symmetric matrix is zero.
+func (p *Property) IsSkewSymmetric(A Mat) bool {
+	return p.isSymmetric(A, -1)
+}
+
+// Returns whether A is square and A[i,j] equals sign*A[j,i] for all
+// coordinates. Pairs of cells that are both zero need not be compared,
+// so only the non-zero cells are visited.
+func (p *Property) isSymmetric(A Mat, sign float64) bool {
+	if A.Rows() != A.Columns() {
+		return false
+	}
+	symmetric := true
+	forEachNonZero(A, func(r, c int, value float64) {
+		if symmetric && !(math.Abs(value-sign*A.GetQuick(c, r)) <= p.tolerance) {
+			symmetric = false
+		}
+	})
+	return symmetric
+}
+
+// Returns whether A is an identity matrix: square with ones on the
+// diagonal and zeros elsewhere.
+func (p *Property) IsIdentity(A Mat) bool {
+	if A.Rows() != A.Columns() || !p.IsDiagonal(A) {
+		return false
+	}
+	for i := 0; i < A.Rows(); i++ {
+		if !(math.Abs(A.GetQuick(i, i)-1) <= p.tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether A is orthogonal: square with A*A' equal to the
+// identity matrix.
+func (p *Property) IsOrthogonal(A Mat) bool {
+	if A.Rows() != A.Columns() {
+		return false
+	}
+	M, ok := A.(*Matrix)
+	if !ok {
+		M = &Matrix{A}
+	}
+	AAt, err := M.ZMultMatrixConst(M, nil, 1, 0, false, true)
+	if err != nil {
+		return false
+	}
+	return p.IsIdentity(AAt)
+}
+
+// Returns whether A is strictly diagonally dominant by row: the absolute
+// value of each diagonal cell is greater than the sum of the absolute
+// values of the other cells in its row, less the tolerance.
+func (p *Property) IsDiagonallyDominantByRow(A Mat) bool {
+	sums := make([]float64, A.Rows())
+	forEachNonZero(A, func(r, c int, value float64) {
+		if r != c {
+			sums[r] += math.Abs(value)
+		}
+	})
+	return p.isDiagonallyDominant(A, sums)
+}
+
+// Returns whether A is strictly diagonally dominant by column: the
+// absolute value of each diagonal cell is greater than the sum of the
+// absolute values of the other cells in its column, less the tolerance.
+func (p *Property) IsDiagonallyDominantByColumn(A Mat) bool {
+	sums := make([]float64, A.Columns())
+	forEachNonZero(A, func(r, c int, value float64) {
+		if r != c {
+			sums[c] += math.Abs(value)
+		}
+	})
+	return p.isDiagonallyDominant(A, sums)
+}
+
+// Returns whether |A[i,i]| > sums[i] - tolerance for all i less than
+// min(rows, columns).
+func (p *Property) isDiagonallyDominant(A Mat, sums []float64) bool {
+	min := A.Rows()
+	if A.Columns() < min {
+		min = A.Columns()
+	}
+	for i := 0; i < min; i++ {
+		if !(math.Abs(A.GetQuick(i, i)) > sums[i]-p.tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+// Modifies the diagonal of the square matrix A so that it is strictly
+// diagonally dominant by row and by column, and therefore non-singular.
+// The diagonal cell i is set to max(rowSum, columnSum) + i + 1, where the
+// sums are of the absolute values of the off-diagonal cells. Returns an
+// error if A is not square.
+func (p *Property) GenerateNonSingular(A Mat) error {
+	n := A.Rows()
+	if A.Columns() != n {
+		return fmt.Errorf("Matrix must be square: %d x %d matrix", n, A.Columns())
+	}
+	rowSums := make([]float64, n)
+	columnSums := make([]float64, n)
+	forEachNonZero(A, func(r, c int, value float64) {
+		if r != c {
+			rowSums[r] += math.Abs(value)
+			columnSums[c] += math.Abs(value)
+		}
+	})
+	for i := 0; i < n; i++ {
+		A.SetQuick(i, i, math.Max(rowSums[i], columnSums[i])+float64(i+1))
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package tfloat64
+
+import (
+	"math"
+	"testing"
+)
+
+func testPropertyShape(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{4, 1, 0, 1e-12},
+		{1, 4, 1, 0},
+		{0, 1, 4, 1},
+		{0, 0, 1, 4},
+	})
+	if !prop.IsSymmetric(A) || prop.IsSkewSymmetric(A) {
+		t.Error("expected symmetric")
+	}
+	if !prop.IsTridiagonal(A) || prop.IsDiagonal(A) {
+		t.Error("expected tridiagonal")
+	}
+	if prop.IsUpperTriangular(A) || prop.IsLowerTriangular(A) || prop.IsIdentity(A) {
+		t.Error("expected not triangular")
+	}
+	if !prop.IsDiagonallyDominantByRow(A) || !prop.IsDiagonallyDominantByColumn(A) {
+		t.Error("expected diagonally dominant")
+	}
+	if NewProperty(0).IsTridiagonal(A) {
+		t.Error("expected not tridiagonal with zero tolerance")
+	}
+
+	A.SetQuick(1, 0, 0)
+	A.SetQuick(2, 1, 0)
+	A.SetQuick(3, 2, 0)
+	if !prop.IsUpperTriangular(A) || prop.IsLowerTriangular(A) || prop.IsSymmetric(A) {
+		t.Error("expected upper triangular")
+	}
+	A.SetQuick(0, 1, 2)
+	A.SetQuick(0, 2, 2.5)
+	if prop.IsDiagonallyDominantByRow(A) {
+		t.Error("expected not diagonally dominant by row")
+	}
+	if !prop.IsDiagonallyDominantByColumn(A) {
+		t.Error("expected diagonally dominant by column")
+	}
+
+	A.AssignArray([][]float64{
+		{0, 2, -1, 0},
+		{-2, 0, 0, 3},
+		{1, 0, 0, 0},
+		{0, -3, 0, 0},
+	})
+	if !prop.IsSkewSymmetric(A) || prop.IsSymmetric(A) {
+		t.Error("expected skew-symmetric")
+	}
+}
+
+func testPropertyOrthogonal(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	})
+	if !prop.IsIdentity(A) || !prop.IsOrthogonal(A) || !prop.IsDiagonal(A) {
+		t.Error("expected identity")
+	}
+	c, s := math.Cos(0.3), math.Sin(0.3)
+	A.AssignArray([][]float64{
+		{c, -s, 0},
+		{s, c, 0},
+		{0, 0, 1},
+	})
+	if !prop.IsOrthogonal(A) || prop.IsIdentity(A) {
+		t.Error("expected orthogonal")
+	}
+	A.SetQuick(2, 2, 2)
+	if prop.IsOrthogonal(A) {
+		t.Error("expected not orthogonal")
+	}
+}
+
+func testPropertyGenerateNonSingular(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{1.0 / 3, 2.0 / 3, math.Pi, 0},
+		{3, 9, 0, 0},
+		{0, 2, 7, 0},
+		{0, 0, 3, 9},
+	})
+	if prop.IsDiagonallyDominantByRow(A) || prop.IsDiagonallyDominantByColumn(A) {
+		t.Error("expected not diagonally dominant")
+	}
+	if err := prop.GenerateNonSingular(A); err != nil {
+		t.Fatal(err)
+	}
+	if !prop.IsDiagonallyDominantByRow(A) || !prop.IsDiagonallyDominantByColumn(A) {
+		t.Error("expected diagonally dominant")
+	}
+	if det, _ := alg.Det(A); det == 0 {
+		t.Error("expected non-singular")
+	}
+	if err := prop.GenerateNonSingular(&Matrix{A.Like(2, 3)}); err == nil {
+		t.Error("expected square matrix error")
+	}
+}
+
+func testPropertyDiagonallyDominantTolerance(t *testing.T, A *Matrix) {
+	// Row 0 and column 2 fall short of strict dominance by 1e-12, which
+	// is within the default tolerance.
+	A.AssignArray([][]float64{
+		{1, 1 + 1e-12, 0},
+		{0, 3, 1 + 1e-12},
+		{0, 0, 1},
+	})
+	if !prop.IsDiagonallyDominantByRow(A) || !prop.IsDiagonallyDominantByColumn(A) {
+		t.Error("expected diagonally dominant within tolerance")
+	}
+	exact := NewProperty(0)
+	if exact.IsDiagonallyDominantByRow(A) || exact.IsDiagonallyDominantByColumn(A) {
+		t.Error("expected not diagonally dominant with zero tolerance")
+	}
+
+	// A shortfall beyond the tolerance is not dominant.
+	A.SetQuick(0, 1, 1+1e-6)
+	A.SetQuick(1, 2, 1+1e-6)
+	if prop.IsDiagonallyDominantByRow(A) || prop.IsDiagonallyDominantByColumn(A) {
+		t.Error("expected not diagonally dominant")
+	}
+}
+
+func TestDensePropertyShape(t *testing.T) {
+	testPropertyShape(t, NewMatrix(4, 4))
+}
+
+func TestDensePropertyOrthogonal(t *testing.T) {
+	testPropertyOrthogonal(t, NewMatrix(3, 3))
+}
+
+func TestDensePropertyGenerateNonSingular(t *testing.T) {
+	testPropertyGenerateNonSingular(t, NewMatrix(4, 4))
+}
+
+func TestDensePropertyDiagonallyDominantTolerance(t *testing.T) {
+	testPropertyDiagonallyDominantTolerance(t, NewMatrix(3, 3))
+}
+
+func TestSparsePropertyShape(t *testing.T) {
+	testPropertyShape(t, NewSparseMatrix(4, 4))
+}
+
+func TestSparsePropertyOrthogonal(t *testing.T) {
+	testPropertyOrthogonal(t, NewSparseMatrix(3, 3))
+}
+
+func TestSparsePropertyGenerateNonSingular(t *testing.T) {
+	testPropertyGenerateNonSingular(t, NewSparseMatrix(4, 4))
+}
+
+func TestSparsePropertyDiagonallyDominantTolerance(t *testing.T) {
+	testPropertyDiagonallyDominantTolerance(t, NewSparseMatrix(3, 3))
+}
+
+func TestSparseRCPropertyShape(t *testing.T) {
+	testPropertyShape(t, NewSparseRCMatrix(4, 4))
+}
@@ -0,0 +1,227 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+)
+
+// QR decomposition of a matrix, computed by Householder reflections.
+//
+// For an m x n matrix A with m >= n, the QR decomposition is an m x n
+// orthogonal matrix Q and an n x n upper triangular matrix R so that
+// A = Q*R.
+//
+// The QR decomposition always exists, even if the matrix does not have
+// full rank, so the constructor will never fail for m >= n. The primary
+// use of the QR decomposition is in the least squares solution of
+// non-square systems of simultaneous linear equations. Solving will fail
+// if IsFullRank() returns false.
+type DenseQRDecomposition struct {
+	qr        *Matrix   // Storage for the Householder vectors and the upper part of R.
+	rdiag     []float64 // The diagonal of R.
+	tolerance float64   // Threshold below which a diagonal element of R is treated as zero.
+}
+
+// Constructs and returns a new QR decomposition of a copy of the given
+// matrix, with the default tolerance of the package Property. Returns an
+// error if A has fewer rows than columns.
+func NewDenseQRDecomposition(A *Matrix) (*DenseQRDecomposition, error) {
+	return NewDenseQRDecompositionTolerance(A, prop.tolerance)
+}
+
+// Constructs and returns a new QR decomposition of a copy of the given
+// matrix which uses the given tolerance to determine whether A has full
+// rank. Returns an error if A has fewer rows than columns.
+func NewDenseQRDecompositionTolerance(A *Matrix, tolerance float64) (*DenseQRDecomposition, error) {
+	m := A.Rows()
+	n := A.Columns()
+	if m < n {
+		return nil, fmt.Errorf("Matrix must not have fewer rows than columns: %s", A.StringShort())
+	}
+	QR := A.Copy()
+	rdiag := make([]float64, n)
+
+	// Main loop.
+	for k := 0; k < n; k++ {
+		// Compute 2-norm of k-th column without under/overflow.
+		nrm := 0.0
+		for i := k; i < m; i++ {
+			nrm = math.Hypot(nrm, QR.GetQuick(i, k))
+		}
+
+		if nrm != 0 {
+			// Form k-th Householder vector.
+			if QR.GetQuick(k, k) < 0 {
+				nrm = -nrm
+			}
+			for i := k; i < m; i++ {
+				QR.SetQuick(i, k, QR.GetQuick(i, k)/nrm)
+			}
+			QR.SetQuick(k, k, QR.GetQuick(k, k)+1)
+
+			// Apply transformation to remaining columns.
+			for j := k + 1; j < n; j++ {
+				s := 0.0
+				for i := k; i < m; i++ {
+					s += QR.GetQuick(i, k) * QR.GetQuick(i, j)
+				}
+				s = -s / QR.GetQuick(k, k)
+				for i := k; i < m; i++ {
+					QR.SetQuick(i, j, QR.GetQuick(i, j)+s*QR.GetQuick(i, k))
+				}
+			}
+		}
+		rdiag[k] = -nrm
+	}
+	return &DenseQRDecomposition{QR, rdiag, tolerance}, nil
+}
+
+// Returns the Householder vectors H. The returned matrix is lower
+// trapezoidal whose columns define the reflections.
+func (d *DenseQRDecomposition) H() *Matrix {
+	m := d.qr.Rows()
+	n := d.qr.Columns()
+	H := &Matrix{d.qr.Like(m, n)}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n && j <= i; j++ {
+			H.SetQuick(i, j, d.qr.GetQuick(i, j))
+		}
+	}
+	return H
+}
+
+// Returns the (economy-sized) orthogonal factor Q.
+func (d *DenseQRDecomposition) Q() *Matrix {
+	m := d.qr.Rows()
+	n := d.qr.Columns()
+	Q := &Matrix{d.qr.Like(m, n)}
+	for k := n - 1; k >= 0; k-- {
+		Q.SetQuick(k, k, 1)
+		qrkk := d.qr.GetQuick(k, k)
+		if qrkk == 0 {
+			continue
+		}
+		for j := k; j < n; j++ {
+			s := 0.0
+			for i := k; i < m; i++ {
+				s += d.qr.GetQuick(i, k) * Q.GetQuick(i, j)
+			}
+			s = -s / qrkk
+			for i := k; i < m; i++ {
+				Q.SetQuick(i, j, Q.GetQuick(i, j)+s*d.qr.GetQuick(i, k))
+			}
+		}
+	}
+	return Q
+}
+
+// Returns the upper triangular factor R.
+func (d *DenseQRDecomposition) R() *Matrix {
+	n := d.qr.Columns()
+	R := &Matrix{d.qr.Like(n, n)}
+	for i := 0; i < n; i++ {
+		R.SetQuick(i, i, d.rdiag[i])
+		for j := i + 1; j < n; j++ {
+			R.SetQuick(i, j, d.qr.GetQuick(i, j))
+		}
+	}
+	return R
+}
+
+// Returns whether the matrix A has full rank, i.e. whether no diagonal
+// element of R is smaller than the tolerance in magnitude.
+func (d *DenseQRDecomposition) IsFullRank() bool {
+	for _, r := range d.rdiag {
+		if math.Abs(r) <= d.tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *DenseQRDecomposition) checkSolve(rows int) error {
+	if rows != d.qr.Rows() {
+		return fmt.Errorf("Matrix row dimensions must agree: %s, %d", d.qr.StringShort(), rows)
+	}
+	if !d.IsFullRank() {
+		return fmt.Errorf("Matrix is rank deficient")
+	}
+	return nil
+}
+
+// Least squares solution of A*x = b. Returns x that minimizes the two norm
+// of Q*R*x - b. The vector b is left unchanged.
+func (d *DenseQRDecomposition) Solve(b *Vector) (*Vector, error) {
+	err := d.checkSolve(b.Size())
+	if err != nil {
+		return nil, err
+	}
+	m := d.qr.Rows()
+	n := d.qr.Columns()
+	y := b.ToArray()
+
+	// Compute Y = transpose(Q)*b
+	for k := 0; k < n; k++ {
+		s := 0.0
+		for i := k; i < m; i++ {
+			s += d.qr.GetQuick(i, k) * y[i]
+		}
+		s = -s / d.qr.GetQuick(k, k)
+		for i := k; i < m; i++ {
+			y[i] += s * d.qr.GetQuick(i, k)
+		}
+	}
+	// Solve R*x = Y
+	for k := n - 1; k >= 0; k-- {
+		y[k] /= d.rdiag[k]
+		for i := 0; i < k; i++ {
+			y[i] -= y[k] * d.qr.GetQuick(i, k)
+		}
+	}
+	x := &Vector{b.Like(n)}
+	x.AssignArray(y[:n])
+	return x, nil
+}
+
+// Least squares solution of A*X = B. Returns X that minimizes the two norm
+// of Q*R*X - B. The matrix B is left unchanged.
+func (d *DenseQRDecomposition) SolveMatrix(B *Matrix) (*Matrix, error) {
+	err := d.checkSolve(B.Rows())
+	if err != nil {
+		return nil, err
+	}
+	m := d.qr.Rows()
+	n := d.qr.Columns()
+	nx := B.Columns()
+	X := B.Copy()
+
+	// Compute Y = transpose(Q)*B
+	for k := 0; k < n; k++ {
+		for j := 0; j < nx; j++ {
+			s := 0.0
+			for i := k; i < m; i++ {
+				s += d.qr.GetQuick(i, k) * X.GetQuick(i, j)
+			}
+			s = -s / d.qr.GetQuick(k, k)
+			for i := k; i < m; i++ {
+				X.SetQuick(i, j, X.GetQuick(i, j)+s*d.qr.GetQuick(i, k))
+			}
+		}
+	}
+	// Solve R*X = Y
+	for k := n - 1; k >= 0; k-- {
+		for j := 0; j < nx; j++ {
+			X.SetQuick(k, j, X.GetQuick(k, j)/d.rdiag[k])
+		}
+		for i := 0; i < k; i++ {
+			for j := 0; j < nx; j++ {
+				X.SetQuick(i, j, X.GetQuick(i, j)-X.GetQuick(k, j)*d.qr.GetQuick(i, k))
+			}
+		}
+	}
+	part, err := X.ViewPart(0, 0, n, nx)
+	if err != nil {
+		return nil, err
+	}
+	return part.Copy(), nil
+}
@@ -0,0 +1,116 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func testQRDecomposition(t *testing.T, A *Matrix) {
+	qr, err := NewDenseQRDecomposition(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !qr.IsFullRank() {
+		t.Fatal("expected full rank")
+	}
+	Q := qr.Q()
+	R := qr.R()
+
+	// A == Q*R
+	QR, _ := Q.ZMultMatrix(R, nil)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if math.Abs(A.GetQuick(r, c)-QR.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", A.GetQuick(r, c), QR.GetQuick(r, c))
+			}
+		}
+	}
+
+	// transpose(Q)*Q == I
+	QtQ, _ := Q.ZMultMatrixConst(Q, nil, 1, 0, true, false)
+	for r := 0; r < QtQ.Rows(); r++ {
+		for c := 0; c < QtQ.Columns(); c++ {
+			expected := 0.0
+			if r == c {
+				expected = 1
+			}
+			if math.Abs(expected-QtQ.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", expected, QtQ.GetQuick(r, c))
+			}
+		}
+	}
+
+	// A consistent overdetermined system is solved exactly.
+	x := NewVector(A.Columns())
+	for i := 0; i < x.Size(); i++ {
+		x.SetQuick(i, rand.Float64())
+	}
+	b, _ := A.ZMult(x, nil)
+	xx, err := qr.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < x.Size(); i++ {
+		if math.Abs(x.GetQuick(i)-xx.GetQuick(i)) > tol {
+			t.Errorf("expected:%g actual:%g", x.GetQuick(i), xx.GetQuick(i))
+		}
+	}
+}
+
+func testQRDecompositionLeastSquares(t *testing.T, A *Matrix) {
+	// Fit a straight line through four points.
+	A.AssignArray([][]float64{
+		{1, 0},
+		{1, 1},
+		{1, 2},
+		{1, 3},
+	})
+	b := NewVectorArray([]float64{1, 2, 2, 4})
+	qr, err := NewDenseQRDecomposition(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := qr.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{0.9, 0.9}
+	for i, e := range expected {
+		if math.Abs(e-x.GetQuick(i)) > tol {
+			t.Errorf("expected:%g actual:%g", e, x.GetQuick(i))
+		}
+	}
+
+	A.AssignArray([][]float64{
+		{1, 2},
+		{2, 4},
+		{3, 6},
+		{4, 8},
+	})
+	qr, _ = NewDenseQRDecomposition(A)
+	if qr.IsFullRank() {
+		t.Errorf("expected rank deficient")
+	}
+	if _, err := qr.Solve(b); err == nil {
+		t.Errorf("expected error solving rank deficient system")
+	}
+}
+
+func TestDenseQRDecomposition(t *testing.T) {
+	A := makeDenseMatrix().ViewDice()
+	testQRDecomposition(t, A)
+}
+
+func TestDenseQRDecompositionLeastSquares(t *testing.T) {
+	testQRDecompositionLeastSquares(t, NewMatrix(4, 2))
+}
+
+func TestSparseQRDecomposition(t *testing.T) {
+	A := makeSparseMatrix().ViewDice()
+	testQRDecomposition(t, A)
+}
+
+func TestSparseQRDecompositionLeastSquares(t *testing.T) {
+	testQRDecompositionLeastSquares(t, NewSparseMatrix(4, 2))
+}
@@ -0,0 +1,320 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+)
+
+// Symbolic analysis of a sparse Cholesky factorization: the fill-reducing
+// permutation, the elimination tree and the column counts of L. The
+// analysis depends only on the sparsity pattern of the matrix and may be
+// reused to factor any number of matrices with the same pattern.
+type SparseCholeskySymbolic struct {
+	n      int
+	pinv   []int // Inverse permutation, or nil.
+	parent []int // Elimination tree of A[p,p].
+	cp     []int // Column pointers of L; len == n+1.
+}
+
+// Returns the symbolic analysis for the sparse Cholesky factorization of
+// the symmetric matrix A, using the given ordering. Only the upper
+// triangular part of A is used.
+func NewSparseCholeskySymbolic(A *Matrix, ordering ColumnOrdering) (*SparseCholeskySymbolic, error) {
+	n := A.Rows()
+	if A.Columns() != n {
+		return nil, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	cc := columnCompressed(A.Mat)
+	S := &SparseCholeskySymbolic{n: n}
+	if ordering == AMDOrdering {
+		Cp, Ci := symmetricPattern(cc)
+		p := amd(n, Cp, Ci)
+		S.pinv = make([]int, n)
+		for k, i := range p {
+			S.pinv[i] = k
+		}
+	}
+	Cp, Ci, _ := symmetricUpper(cc, S.pinv)
+	S.parent = eliminationTree(Cp, Ci)
+
+	// Count the non-zeros of each column of L from the row patterns.
+	counts := make([]int, n)
+	s := make([]int, n)
+	marked := make([]bool, n)
+	for k := 0; k < n; k++ {
+		counts[k]++
+		top := elimReach(Cp, Ci, k, S.parent, s, marked)
+		for p := top; p < n; p++ {
+			counts[s[p]]++
+		}
+	}
+	S.cp = make([]int, n+1)
+	for k := 0; k < n; k++ {
+		S.cp[k+1] = S.cp[k] + counts[k]
+	}
+	return S, nil
+}
+
+// Returns the permutation p, such that L*L' = A[p,p]; the identity if no
+// ordering is used.
+func (S *SparseCholeskySymbolic) Permutation() []int {
+	p := make([]int, S.n)
+	for k := range p {
+		if S.pinv != nil {
+			p[S.pinv[k]] = k
+		} else {
+			p[k] = k
+		}
+	}
+	return p
+}
+
+// Returns the number of non-zeros of the factor L.
+func (S *SparseCholeskySymbolic) NonZeroCount() int {
+	return S.cp[S.n]
+}
+
+// Returns the upper triangular part of A[p,p] in compressed column form,
+// where pinv is the inverse of p, or nil for the identity. Only the upper
+// triangular part of A is read.
+func symmetricUpper(A *SparseCCMat, pinv []int) ([]int, []int, []float64) {
+	n := A.Columns()
+	Ap := A.columnPointers
+	Ai := A.rowIndexes
+	Ax := A.values
+	perm := func(i int) int {
+		if pinv != nil {
+			return pinv[i]
+		}
+		return i
+	}
+	// Each cell is stored in the column of the larger permuted index.
+	column := func(i, j int) (int, int) {
+		i2, j2 := perm(i), perm(j)
+		if i2 > j2 {
+			return j2, i2
+		}
+		return i2, j2
+	}
+	w := make([]int, n+1)
+	for j := 0; j < n; j++ {
+		for p := Ap[j]; p < Ap[j+1]; p++ {
+			if i := Ai[p]; i <= j {
+				_, j2 := column(i, j)
+				w[j2+1]++
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		w[k+1] += w[k]
+	}
+	Cp := make([]int, n+1)
+	copy(Cp, w)
+	nz := Cp[n]
+	Ci := make([]int, nz)
+	Cx := make([]float64, nz)
+	for j := 0; j < n; j++ {
+		for p := Ap[j]; p < Ap[j+1]; p++ {
+			if i := Ai[p]; i <= j {
+				i2, j2 := column(i, j)
+				q := w[j2]
+				w[j2]++
+				Ci[q] = i2
+				Cx[q] = Ax[p]
+			}
+		}
+	}
+	return Cp, Ci, Cx
+}
+
+// Returns the elimination tree of the symmetric matrix whose upper
+// triangular part is C; parent[k] is -1 for a root.
+func eliminationTree(Cp, Ci []int) []int {
+	n := len(Cp) - 1
+	parent := make([]int, n)
+	ancestor := make([]int, n)
+	for k := 0; k < n; k++ {
+		parent[k] = -1
+		ancestor[k] = -1
+		for p := Cp[k]; p < Cp[k+1]; p++ {
+			for i := Ci[p]; i != -1 && i < k; {
+				next := ancestor[i]
+				ancestor[i] = k
+				if next == -1 {
+					parent[i] = k
+				}
+				i = next
+			}
+		}
+	}
+	return parent
+}
+
+// Computes the pattern of row k of L, excluding the diagonal, by walking
+// up the elimination tree from the non-zeros of column k of C. The
+// pattern is stored in topological order in s[top:n]. Returns top, or -1
+// if a path leaves the tree before reaching k, which happens only if C
+// does not match the pattern the tree was computed for.
+func elimReach(Cp, Ci []int, k int, parent, s []int, marked []bool) int {
+	n := len(marked)
+	top := n
+	marked[k] = true
+	i := 0
+	for p := Cp[k]; p < Cp[k+1]; p++ {
+		i = Ci[p]
+		if i > k {
+			continue
+		}
+		length := 0
+		for ; i != -1 && !marked[i]; i = parent[i] {
+			s[length] = i
+			length++
+			marked[i] = true
+		}
+		for length > 0 {
+			length--
+			top--
+			s[top] = s[length]
+		}
+		if i == -1 {
+			break
+		}
+	}
+	for p := top; p < n; p++ {
+		marked[s[p]] = false
+	}
+	marked[k] = false
+	if i == -1 {
+		return -1
+	}
+	return top
+}
+
+// Sparse Cholesky decomposition of a symmetric positive definite matrix.
+// For a symmetric positive definite matrix A this computes the lower
+// triangular matrix L, held in compressed column storage, so that
+// L*L' = A[p,p], where p is the fill-reducing permutation of the symbolic
+// analysis.
+//
+// The factorization is up-looking: row k of L is computed by a sparse
+// triangular solve whose pattern is given by the elimination tree.
+type SparseCholeskyDecomposition struct {
+	symbolic *SparseCholeskySymbolic
+	l        *SparseCCMat
+}
+
+// Computes the sparse Cholesky decomposition of A using the given symbolic
+// analysis, which must have been computed for a matrix with the same
+// dimensions and sparsity pattern. Only the upper triangular part of A is
+// used. Returns an error if A is not positive definite.
+func NewSparseCholeskyDecomposition(A *Matrix, S *SparseCholeskySymbolic) (*SparseCholeskyDecomposition, error) {
+	n := S.n
+	if A.Rows() != n || A.Columns() != n {
+		return nil, fmt.Errorf("Matrix does not match symbolic analysis: %s, n=%d", A.StringShort(), n)
+	}
+	Cp, Ci, Cx := symmetricUpper(columnCompressed(A.Mat), S.pinv)
+
+	L := newSparseCCMat(n, n)
+	copy(L.columnPointers, S.cp)
+	L.rowIndexes = make([]int, S.cp[n])
+	L.values = make([]float64, S.cp[n])
+	Lp := L.columnPointers
+	Li := L.rowIndexes
+	Lx := L.values
+
+	c := make([]int, n) // Next free position in each column of L.
+	copy(c, S.cp[:n])
+	x := make([]float64, n)
+	s := make([]int, n)
+	marked := make([]bool, n)
+
+	for k := 0; k < n; k++ {
+		// Solve L[0:k,0:k] * x = C[0:k,k] for row k of L.
+		top := elimReach(Cp, Ci, k, S.parent, s, marked)
+		if top < 0 {
+			return nil, fmt.Errorf("Matrix does not match symbolic analysis at column %d", k)
+		}
+		x[k] = 0
+		for p := Cp[k]; p < Cp[k+1]; p++ {
+			if Ci[p] <= k {
+				x[Ci[p]] = Cx[p]
+			}
+		}
+		d := x[k]
+		x[k] = 0
+		for ; top < n; top++ {
+			i := s[top]
+			lki := x[i] / Lx[Lp[i]]
+			x[i] = 0
+			for p := Lp[i] + 1; p < c[i]; p++ {
+				x[Li[p]] -= Lx[p] * lki
+			}
+			d -= lki * lki
+			if c[i] >= Lp[i+1] {
+				return nil, fmt.Errorf("Matrix does not match symbolic analysis at column %d", k)
+			}
+			Li[c[i]] = k
+			Lx[c[i]] = lki
+			c[i]++
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("Matrix is not positive definite at column %d", k)
+		}
+		Li[c[k]] = k
+		Lx[c[k]] = math.Sqrt(d)
+		c[k]++
+	}
+	return &SparseCholeskyDecomposition{S, L}, nil
+}
+
+// Returns the symbolic analysis used by this decomposition.
+func (d *SparseCholeskyDecomposition) Symbolic() *SparseCholeskySymbolic {
+	return d.symbolic
+}
+
+// Returns the lower triangular factor L.
+func (d *SparseCholeskyDecomposition) L() *Matrix {
+	return &Matrix{d.l}
+}
+
+// Solves A*x = b.
+func (d *SparseCholeskyDecomposition) Solve(b *Vector) (*Vector, error) {
+	n := d.symbolic.n
+	if b.Size() != n {
+		return nil, fmt.Errorf("Incompatible args: %d, %s", n, b.StringShort())
+	}
+	pinv := d.symbolic.pinv
+	x := make([]float64, n)
+	for k := 0; k < n; k++ {
+		if pinv != nil {
+			x[pinv[k]] = b.GetQuick(k)
+		} else {
+			x[k] = b.GetQuick(k)
+		}
+	}
+	Lp := d.l.columnPointers
+	Li := d.l.rowIndexes
+	Lx := d.l.values
+	// The diagonal is the first cell of each column.
+	for j := 0; j < n; j++ {
+		x[j] /= Lx[Lp[j]]
+		for p := Lp[j] + 1; p < Lp[j+1]; p++ {
+			x[Li[p]] -= Lx[p] * x[j]
+		}
+	}
+	for j := n - 1; j >= 0; j-- {
+		for p := Lp[j] + 1; p < Lp[j+1]; p++ {
+			x[j] -= Lx[p] * x[Li[p]]
+		}
+		x[j] /= Lx[Lp[j]]
+	}
+	X := &Vector{b.Like(n)}
+	for k := 0; k < n; k++ {
+		if pinv != nil {
+			X.SetQuick(k, x[pinv[k]])
+		} else {
+			X.SetQuick(k, x[k])
+		}
+	}
+	return X, nil
+}
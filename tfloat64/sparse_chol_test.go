@@ -0,0 +1,92 @@
+package tfloat64
+
+import (
+	"math"
+	"testing"
+)
+
+func testSparseCholeskyDecomposition(t *testing.T, A *Matrix, ordering ColumnOrdering) {
+	S, err := NewSparseCholeskySymbolic(A, ordering)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chol, err := NewSparseCholeskyDecomposition(A, S)
+	if err != nil {
+		t.Fatal(err)
+	}
+	L := chol.L().Mat.(*SparseCCMat)
+	if L.NonZeroCount() != S.NonZeroCount() {
+		t.Errorf("expected:%d actual:%d", S.NonZeroCount(), L.NonZeroCount())
+	}
+
+	// A(p,p) == L*L'
+	LLt, _ := L.Dense().ZMultMatrixConst(L.Dense(), nil, 1, 0, false, true)
+	p := S.Permutation()
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := A.GetQuick(p[r], p[c])
+			actual := LLt.GetQuick(r, c)
+			if math.Abs(expected-actual) > 1e-8 {
+				t.Errorf("expected:%g actual:%g", expected, actual)
+			}
+		}
+	}
+
+	b := makePoisson2DRHS(A.Rows())
+	x, err := chol.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testSolution(t, A, x, b)
+
+	// Reuse the symbolic analysis for a matrix with the same pattern.
+	B := A.Copy()
+	B.ForEachNonZero(func(r, c int, value float64) float64 {
+		if r == c {
+			return 2 * value
+		}
+		return value
+	})
+	chol, err = NewSparseCholeskyDecomposition(B, S)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err = chol.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testSolution(t, B, x, b)
+}
+
+func TestDenseSparseCholeskyDecomposition(t *testing.T) {
+	A := makeSPDMatrix(NewMatrix(nsquare, nsquare))
+	testSparseCholeskyDecomposition(t, A, NaturalOrdering)
+	testSparseCholeskyDecomposition(t, A, AMDOrdering)
+}
+
+func TestSparseSparseCholeskyDecomposition(t *testing.T) {
+	A := makePoisson2DMatrix(10)
+	testSparseCholeskyDecomposition(t, A, NaturalOrdering)
+	testSparseCholeskyDecomposition(t, A, AMDOrdering)
+}
+
+func TestSparseCholeskyDecompositionErrors(t *testing.T) {
+	A := makePoisson2DMatrix(5)
+	S, err := NewSparseCholeskySymbolic(A, AMDOrdering)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	B := A.Copy()
+	B.SetQuick(12, 12, -4)
+	if _, err := NewSparseCholeskyDecomposition(B, S); err == nil {
+		t.Error("expected not positive definite error")
+	}
+
+	B = A.Copy()
+	B.SetQuick(0, 24, -1)
+	B.SetQuick(24, 0, -1)
+	if _, err := NewSparseCholeskyDecomposition(B, S); err == nil {
+		t.Error("expected symbolic analysis mismatch error")
+	}
+}
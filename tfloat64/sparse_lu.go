@@ -0,0 +1,399 @@
+package tfloat64
+
+import (
+	"fmt"
+	"math"
+)
+
+// Fill-reducing column ordering used by the symbolic analysis of the
+// sparse direct solvers.
+type ColumnOrdering int
+
+const (
+	// The natural ordering; no permutation.
+	NaturalOrdering ColumnOrdering = iota
+
+	// Approximate minimum degree ordering, computed on A+A' for Cholesky
+	// and on A'*A for LU.
+	AMDOrdering
+)
+
+// Symbolic analysis of a sparse LU factorization. The analysis depends
+// only on the sparsity pattern of the matrix and may be reused to factor
+// any number of matrices with the same pattern, e.g. the Jacobians of
+// successive Newton iterations.
+//
+// It holds the fill-reducing column ordering and estimates of the sizes
+// of the factors. The patterns of L and U depend on the pivots that the
+// numeric factorization chooses, so they are not part of the analysis;
+// Refactor reuses the pivots and patterns of an existing decomposition.
+type SparseLUSymbolic struct {
+	n        int
+	q        []int // Column permutation, or nil.
+	lnz, unz int   // Estimated number of non-zeros of L and U.
+}
+
+// Returns the symbolic analysis for the sparse LU factorization of the
+// square matrix A, using the given column ordering.
+func NewSparseLUSymbolic(A *Matrix, ordering ColumnOrdering) (*SparseLUSymbolic, error) {
+	n := A.Rows()
+	if A.Columns() != n {
+		return nil, fmt.Errorf("Matrix must be square: %s", A.StringShort())
+	}
+	cc := columnCompressed(A.Mat)
+	S := &SparseLUSymbolic{n: n}
+	if ordering == AMDOrdering {
+		Cp, Ci := normalPattern(cc)
+		S.q = amd(n, Cp, Ci)
+	}
+	S.lnz = 4*cc.NonZeroCount() + n
+	S.unz = S.lnz
+	return S, nil
+}
+
+// Returns the column permutation; the identity if no ordering is used.
+func (S *SparseLUSymbolic) ColumnPermutation() []int {
+	q := make([]int, S.n)
+	for k := range q {
+		if S.q != nil {
+			q[k] = S.q[k]
+		} else {
+			q[k] = k
+		}
+	}
+	return q
+}
+
+// Sparse LU decomposition with partial pivoting. For a square matrix A
+// this computes L*U = A[p,q], where q is the fill-reducing column
+// permutation of the symbolic analysis and p the row permutation chosen
+// by pivoting. L is unit lower triangular and U is upper triangular; both
+// are held in compressed column storage.
+//
+// The factorization is left-looking: each column of L and U is computed
+// by a sparse triangular solve with the columns already computed
+// (Gilbert-Peierls).
+type SparseLUDecomposition struct {
+	symbolic *SparseLUSymbolic
+	l, u     *SparseCCMat
+	pinv     []int // Inverse row permutation.
+}
+
+// Computes the sparse LU decomposition of A using the given symbolic
+// analysis, which must have been computed for a matrix with the same
+// dimensions and sparsity pattern. A diagonal entry is preferred as pivot
+// if its magnitude is at least tolerance times that of the largest
+// candidate; a tolerance of 1 gives partial pivoting and smaller values
+// preserve more of the fill-reducing ordering.
+func NewSparseLUDecomposition(A *Matrix, S *SparseLUSymbolic, tolerance float64) (*SparseLUDecomposition, error) {
+	n := S.n
+	if A.Rows() != n || A.Columns() != n {
+		return nil, fmt.Errorf("Matrix does not match symbolic analysis: %s, n=%d", A.StringShort(), n)
+	}
+	a := columnCompressed(A.Mat)
+	Ap := a.columnPointers
+	Ai := a.rowIndexes
+	Ax := a.values
+
+	L := newSparseCCMat(n, n)
+	U := newSparseCCMat(n, n)
+	L.rowIndexes = make([]int, 0, S.lnz)
+	L.values = make([]float64, 0, S.lnz)
+	U.rowIndexes = make([]int, 0, S.unz)
+	U.values = make([]float64, 0, S.unz)
+	pinv := make([]int, n)
+	for i := range pinv {
+		pinv[i] = -1
+	}
+	x := make([]float64, n)
+	xi := make([]int, 2*n)
+	marked := make([]bool, n)
+
+	for k := 0; k < n; k++ {
+		L.columnPointers[k] = len(L.values)
+		U.columnPointers[k] = len(U.values)
+		col := k
+		if S.q != nil {
+			col = S.q[k]
+		}
+
+		// x = L \ A[:,col]
+		top := sparseReach(L, Ap, Ai, col, xi, pinv, marked)
+		for p := top; p < n; p++ {
+			x[xi[p]] = 0
+		}
+		for p := Ap[col]; p < Ap[col+1]; p++ {
+			x[Ai[p]] = Ax[p]
+		}
+		for px := top; px < n; px++ {
+			j := xi[px]
+			J := pinv[j]
+			if J < 0 {
+				continue
+			}
+			x[j] /= L.values[L.columnPointers[J]]
+			for p := L.columnPointers[J] + 1; p < L.columnPointers[J+1]; p++ {
+				x[L.rowIndexes[p]] -= L.values[p] * x[j]
+			}
+		}
+
+		// Find the pivot and store U[:,k].
+		ipiv := -1
+		amax := -1.0
+		for p := top; p < n; p++ {
+			i := xi[p]
+			if pinv[i] < 0 {
+				if t := math.Abs(x[i]); t > amax {
+					amax = t
+					ipiv = i
+				}
+			} else {
+				U.rowIndexes = append(U.rowIndexes, pinv[i])
+				U.values = append(U.values, x[i])
+			}
+		}
+		if ipiv == -1 || amax <= 0 {
+			return nil, fmt.Errorf("Matrix is singular at column %d", k)
+		}
+		if pinv[col] < 0 && math.Abs(x[col]) >= amax*tolerance {
+			ipiv = col
+		}
+		pivot := x[ipiv]
+		U.rowIndexes = append(U.rowIndexes, k)
+		U.values = append(U.values, pivot)
+		pinv[ipiv] = k
+
+		// Store L[:,k], with the unit diagonal first.
+		L.rowIndexes = append(L.rowIndexes, ipiv)
+		L.values = append(L.values, 1)
+		for p := top; p < n; p++ {
+			i := xi[p]
+			if pinv[i] < 0 {
+				L.rowIndexes = append(L.rowIndexes, i)
+				L.values = append(L.values, x[i]/pivot)
+			}
+			x[i] = 0
+		}
+	}
+	L.columnPointers[n] = len(L.values)
+	U.columnPointers[n] = len(U.values)
+	for p := range L.rowIndexes {
+		L.rowIndexes[p] = pinv[L.rowIndexes[p]]
+	}
+	sortColumns(L)
+	sortColumns(U)
+	return &SparseLUDecomposition{S, L, U, pinv}, nil
+}
+
+// Computes the set of nodes reachable in the graph of L from the non-zeros
+// of column k of B, in topological order, stored in xi[top:n]. Returns
+// top.
+func sparseReach(L *SparseCCMat, Bp, Bi []int, k int, xi, pinv []int, marked []bool) int {
+	n := len(marked)
+	top := n
+	for p := Bp[k]; p < Bp[k+1]; p++ {
+		if !marked[Bi[p]] {
+			top = sparseDFS(Bi[p], L, top, xi, xi[n:], pinv, marked)
+		}
+	}
+	for p := top; p < n; p++ {
+		marked[xi[p]] = false
+	}
+	return top
+}
+
+// Depth-first search of the graph of L starting at node j, using xi as
+// the recursion stack and pushing finished nodes onto xi[top:].
+func sparseDFS(j int, L *SparseCCMat, top int, xi, pstack, pinv []int, marked []bool) int {
+	head := 0
+	xi[0] = j
+	for head >= 0 {
+		j = xi[head]
+		jnew := pinv[j]
+		if !marked[j] {
+			marked[j] = true
+			if jnew < 0 {
+				pstack[head] = 0
+			} else {
+				pstack[head] = L.columnPointers[jnew]
+			}
+		}
+		done := true
+		p2 := 0
+		if jnew >= 0 {
+			p2 = L.columnPointers[jnew+1]
+		}
+		for p := pstack[head]; p < p2; p++ {
+			i := L.rowIndexes[p]
+			if marked[i] {
+				continue
+			}
+			pstack[head] = p
+			head++
+			xi[head] = i
+			done = false
+			break
+		}
+		if done {
+			head--
+			top--
+			xi[top] = j
+		}
+	}
+	return top
+}
+
+// Sorts the row indexes of each column of A in ascending order.
+func sortColumns(A *SparseCCMat) {
+	for c := 0; c < A.Columns(); c++ {
+		low := A.columnPointers[c]
+		high := A.columnPointers[c+1]
+		sortIndexed(A.rowIndexes[low:high], A.values[low:high])
+	}
+}
+
+// Sorts indexes in ascending order, permuting values alike. Insertion sort
+// is used as the columns of sparse factors are short.
+func sortIndexed(indexes []int, values []float64) {
+	for i := 1; i < len(indexes); i++ {
+		index := indexes[i]
+		value := values[i]
+		j := i - 1
+		for ; j >= 0 && indexes[j] > index; j-- {
+			indexes[j+1] = indexes[j]
+			values[j+1] = values[j]
+		}
+		indexes[j+1] = index
+		values[j+1] = value
+	}
+}
+
+// Recomputes the factors for a matrix A with the same sparsity pattern
+// as the matrix factored before, reusing its row permutation and the
+// patterns of L and U. No graph or pivot search is done, which makes this
+// much cheaper than a new decomposition when the values change moderately,
+// as for the Jacobians of successive Newton iterations. Returns an error if
+// A has a non-zero outside the pattern or a reused pivot becomes zero; the
+// factors are then undefined and a new decomposition is needed.
+func (d *SparseLUDecomposition) Refactor(A *Matrix) error {
+	n := d.symbolic.n
+	if A.Rows() != n || A.Columns() != n {
+		return fmt.Errorf("Matrix does not match symbolic analysis: %s, n=%d", A.StringShort(), n)
+	}
+	a := columnCompressed(A.Mat)
+	L := d.l
+	U := d.u
+	x := make([]float64, n)
+	mark := make([]int, n)
+	for i := range mark {
+		mark[i] = -1
+	}
+
+	for k := 0; k < n; k++ {
+		col := k
+		if d.symbolic.q != nil {
+			col = d.symbolic.q[k]
+		}
+
+		// Scatter A[p,col] into x, which is indexed in pivot order.
+		for p := U.columnPointers[k]; p < U.columnPointers[k+1]; p++ {
+			mark[U.rowIndexes[p]] = k
+		}
+		for p := L.columnPointers[k]; p < L.columnPointers[k+1]; p++ {
+			mark[L.rowIndexes[p]] = k
+		}
+		for p := a.columnPointers[col]; p < a.columnPointers[col+1]; p++ {
+			i := d.pinv[a.rowIndexes[p]]
+			if mark[i] != k {
+				return fmt.Errorf("Matrix does not match the pattern of the factors at [%d,%d]",
+					a.rowIndexes[p], col)
+			}
+			x[i] = a.values[p]
+		}
+
+		// x = L \ x over the pattern of U[:,k], in ascending row order.
+		last := U.columnPointers[k+1] - 1
+		for p := U.columnPointers[k]; p < last; p++ {
+			j := U.rowIndexes[p]
+			ujk := x[j]
+			U.values[p] = ujk
+			x[j] = 0
+			for q := L.columnPointers[j] + 1; q < L.columnPointers[j+1]; q++ {
+				x[L.rowIndexes[q]] -= L.values[q] * ujk
+			}
+		}
+		pivot := x[k]
+		x[k] = 0
+		if pivot == 0 {
+			return fmt.Errorf("Matrix is singular at column %d", k)
+		}
+		U.values[last] = pivot
+		for p := L.columnPointers[k] + 1; p < L.columnPointers[k+1]; p++ {
+			i := L.rowIndexes[p]
+			L.values[p] = x[i] / pivot
+			x[i] = 0
+		}
+	}
+	return nil
+}
+
+// Returns the symbolic analysis used by this decomposition.
+func (d *SparseLUDecomposition) Symbolic() *SparseLUSymbolic {
+	return d.symbolic
+}
+
+// Returns the unit lower triangular factor L.
+func (d *SparseLUDecomposition) L() *Matrix {
+	return &Matrix{d.l}
+}
+
+// Returns the upper triangular factor U.
+func (d *SparseLUDecomposition) U() *Matrix {
+	return &Matrix{d.u}
+}
+
+// Returns the row permutation p, such that L*U = A[p,q].
+func (d *SparseLUDecomposition) Pivot() []int {
+	p := make([]int, len(d.pinv))
+	for i, k := range d.pinv {
+		p[k] = i
+	}
+	return p
+}
+
+// Solves A*x = b.
+func (d *SparseLUDecomposition) Solve(b *Vector) (*Vector, error) {
+	n := d.symbolic.n
+	if b.Size() != n {
+		return nil, fmt.Errorf("Incompatible args: %d, %s", n, b.StringShort())
+	}
+	x := make([]float64, n)
+	for k := 0; k < n; k++ {
+		x[d.pinv[k]] = b.GetQuick(k)
+	}
+	L := d.l
+	for j := 0; j < n; j++ {
+		// The unit diagonal is the first cell of each column.
+		for p := L.columnPointers[j] + 1; p < L.columnPointers[j+1]; p++ {
+			x[L.rowIndexes[p]] -= L.values[p] * x[j]
+		}
+	}
+	U := d.u
+	for j := n - 1; j >= 0; j-- {
+		// The diagonal is the last cell of each column.
+		last := U.columnPointers[j+1] - 1
+		x[j] /= U.values[last]
+		for p := U.columnPointers[j]; p < last; p++ {
+			x[U.rowIndexes[p]] -= U.values[p] * x[j]
+		}
+	}
+	X := &Vector{b.Like(n)}
+	for k := 0; k < n; k++ {
+		if d.symbolic.q != nil {
+			X.SetQuick(d.symbolic.q[k], x[k])
+		} else {
+			X.SetQuick(k, x[k])
+		}
+	}
+	return X, nil
+}
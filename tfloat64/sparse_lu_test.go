@@ -0,0 +1,167 @@
+package tfloat64
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns a random sparse, unsymmetric and diagonally dominant n x n
+// matrix with about density*n*n non-zeros.
+func makeSparseSquareMatrix(n int, density float64) *Matrix {
+	A := NewSparseMatrix(n, n)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			if r != c && rand.Float64() < density {
+				A.SetQuick(r, c, rand.Float64()-0.5)
+			}
+		}
+		A.SetQuick(r, r, float64(n)*density+1)
+	}
+	return A
+}
+
+// Checks that x solves A*x = b.
+func testSolution(t *testing.T, A *Matrix, x, b *Vector) {
+	Ax, _ := A.ZMult(x, nil)
+	for i := 0; i < b.Size(); i++ {
+		if math.Abs(Ax.GetQuick(i)-b.GetQuick(i)) > 1e-8 {
+			t.Errorf("expected:%g actual:%g", b.GetQuick(i), Ax.GetQuick(i))
+		}
+	}
+}
+
+func testSparseLUDecomposition(t *testing.T, A *Matrix, ordering ColumnOrdering) {
+	S, err := NewSparseLUSymbolic(A, ordering)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lu, err := NewSparseLUDecomposition(A, S, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A(p,q) == L*U
+	LU, _ := lu.L().Mat.(*SparseCCMat).Dense().ZMultMatrix(lu.U().Mat.(*SparseCCMat).Dense(), nil)
+	p := lu.Pivot()
+	q := S.ColumnPermutation()
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			expected := A.GetQuick(p[r], q[c])
+			actual := LU.GetQuick(r, c)
+			if math.Abs(expected-actual) > tol {
+				t.Errorf("expected:%g actual:%g", expected, actual)
+			}
+		}
+	}
+
+	b := makePoisson2DRHS(A.Rows())
+	x, err := lu.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testSolution(t, A, x, b)
+
+	// Reuse the symbolic analysis for a matrix with the same pattern.
+	B := A.Copy()
+	B.ForEachNonZero(func(r, c int, value float64) float64 {
+		if r == c {
+			return 2 * value
+		}
+		return value + 0.25
+	})
+	lu, err = NewSparseLUDecomposition(B, S, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err = lu.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testSolution(t, B, x, b)
+
+	// Reuse the pivots and factor patterns as well.
+	if err := lu.Refactor(A); err != nil {
+		t.Fatal(err)
+	}
+	x, err = lu.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testSolution(t, A, x, b)
+}
+
+func TestDenseSparseLUDecomposition(t *testing.T) {
+	A := makeSquareMatrix(NewMatrix(nsquare, nsquare))
+	testSparseLUDecomposition(t, A, NaturalOrdering)
+	testSparseLUDecomposition(t, A, AMDOrdering)
+}
+
+func TestSparseSparseLUDecomposition(t *testing.T) {
+	for _, A := range []*Matrix{makePoisson2DMatrix(10), makeSparseSquareMatrix(60, 0.05)} {
+		testSparseLUDecomposition(t, A, NaturalOrdering)
+		testSparseLUDecomposition(t, A, AMDOrdering)
+	}
+}
+
+func TestSparseLUDecompositionSingular(t *testing.T) {
+	A := makeSparseSquareMatrix(20, 0.1)
+	for r := 0; r < A.Rows(); r++ {
+		A.SetQuick(r, 7, 0)
+	}
+	S, err := NewSparseLUSymbolic(A, AMDOrdering)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSparseLUDecomposition(A, S, 1); err == nil {
+		t.Error("expected singular matrix error")
+	}
+}
+
+func TestSparseLUDecompositionRefactorPattern(t *testing.T) {
+	A := NewSparseMatrix(3, 3)
+	A.AssignArray([][]float64{
+		{2, 1, 0},
+		{0, 3, 0},
+		{0, 0, 4},
+	})
+	S, _ := NewSparseLUSymbolic(A, NaturalOrdering)
+	lu, err := NewSparseLUDecomposition(A, S, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	B := A.Copy()
+	B.SetQuick(2, 0, 1)
+	if err := lu.Refactor(B); err == nil {
+		t.Error("expected error for a non-zero outside the pattern")
+	}
+	B = A.Copy()
+	B.SetQuick(1, 1, 0)
+	if err := lu.Refactor(B); err == nil {
+		t.Error("expected singular matrix error")
+	}
+}
+
+func TestAMDOrder(t *testing.T) {
+	A := makePoisson2DMatrix(10)
+	p, err := AMDOrder(A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make([]bool, A.Rows())
+	for _, i := range p {
+		if i < 0 || i >= len(seen) || seen[i] {
+			t.Fatalf("not a permutation: %v", p)
+		}
+		seen[i] = true
+	}
+	if len(p) != A.Rows() {
+		t.Fatalf("expected:%d actual:%d", A.Rows(), len(p))
+	}
+
+	natural, _ := NewSparseCholeskySymbolic(A, NaturalOrdering)
+	amd, _ := NewSparseCholeskySymbolic(A, AMDOrdering)
+	if amd.NonZeroCount() >= natural.NonZeroCount() {
+		t.Errorf("expected less fill than %d actual:%d", natural.NonZeroCount(), amd.NonZeroCount())
+	}
+}
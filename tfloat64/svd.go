@@ -0,0 +1,522 @@
+package tfloat64
+
+import (
+	"math"
+)
+
+// Singular value decomposition of a matrix.
+//
+// For an m x n matrix A, the singular value decomposition is an
+// m x min(m,n) orthogonal matrix U, a vector S of min(m,n) singular values
+// and an n x min(m,n) orthogonal matrix V so that A = U*diag(S)*V'.
+//
+// The singular values are ordered so that S[0] >= S[1] >= ... >= 0.
+//
+// The singular value decomposition always exists, so the constructor will
+// never fail. The matrix condition number and the effective numerical rank
+// can be computed from this decomposition.
+type DenseSingularValueDecomposition struct {
+	u, v [][]float64 // Arrays for internal storage of U and V.
+	s    []float64   // Array for internal storage of singular values.
+	m, n int         // Row and column dimensions of the decomposed matrix.
+	like Mat         // Backend used to construct result matrices.
+}
+
+// Constructs and returns a new singular value decomposition of the given
+// matrix. The matrix is left unchanged.
+func NewDenseSingularValueDecomposition(A *Matrix) *DenseSingularValueDecomposition {
+	m := A.Rows()
+	n := A.Columns()
+	// The bidiagonalization below requires at least as many rows as
+	// columns, so decompose the transpose and swap U and V otherwise.
+	transpose := m < n
+	var a [][]float64
+	if transpose {
+		a = A.ViewDice().ToArray()
+	} else {
+		a = A.ToArray()
+	}
+	u, s, v := svd(a)
+	if transpose {
+		u, v = v, u
+	}
+	return &DenseSingularValueDecomposition{u, v, s, m, n, A.Mat}
+}
+
+// Returns the left singular vectors U.
+func (d *DenseSingularValueDecomposition) U() *Matrix {
+	return d.newMatrix(d.u)
+}
+
+// Returns the right singular vectors V.
+func (d *DenseSingularValueDecomposition) V() *Matrix {
+	return d.newMatrix(d.v)
+}
+
+// Returns the vector of singular values, in descending order.
+func (d *DenseSingularValueDecomposition) S() *Vector {
+	S := &Vector{d.like.LikeVector(len(d.s))}
+	S.AssignArray(d.s)
+	return S
+}
+
+// Returns the singular values as a diagonal matrix.
+func (d *DenseSingularValueDecomposition) SMatrix() *Matrix {
+	k := len(d.s)
+	S := &Matrix{d.like.Like(k, k)}
+	for i, s := range d.s {
+		S.SetQuick(i, i, s)
+	}
+	return S
+}
+
+// Returns the two norm, which is max(S).
+func (d *DenseSingularValueDecomposition) Norm2() float64 {
+	if len(d.s) == 0 {
+		return 0
+	}
+	return d.s[0]
+}
+
+// Returns the two norm condition number, which is max(S)/min(S).
+func (d *DenseSingularValueDecomposition) Cond() float64 {
+	if len(d.s) == 0 {
+		return math.NaN()
+	}
+	return d.s[0] / d.s[len(d.s)-1]
+}
+
+// Returns the effective numerical matrix rank, which is the number of
+// non-negligible singular values.
+func (d *DenseSingularValueDecomposition) Rank() int {
+	tol := d.threshold()
+	r := 0
+	for _, s := range d.s {
+		if s > tol {
+			r++
+		}
+	}
+	return r
+}
+
+// Returns the Moore-Penrose pseudo-inverse, V*diag(1/S)*U', where
+// singular values below the rank threshold are treated as zero.
+func (d *DenseSingularValueDecomposition) PseudoInverse() *Matrix {
+	tol := d.threshold()
+	X := &Matrix{d.like.Like(d.n, d.m)}
+	for k, s := range d.s {
+		if s <= tol {
+			break // ordered, so all remaining values are negligible
+		}
+		inv := 1 / s
+		for i := 0; i < d.n; i++ {
+			vik := d.v[i][k] * inv
+			if vik == 0 {
+				continue
+			}
+			for j := 0; j < d.m; j++ {
+				X.SetQuick(i, j, X.GetQuick(i, j)+vik*d.u[j][k])
+			}
+		}
+	}
+	return X
+}
+
+func (d *DenseSingularValueDecomposition) threshold() float64 {
+	if len(d.s) == 0 {
+		return 0
+	}
+	mn := d.m
+	if d.n > mn {
+		mn = d.n
+	}
+	return float64(mn) * d.s[0] * math.Pow(2, -52)
+}
+
+func (d *DenseSingularValueDecomposition) newMatrix(a [][]float64) *Matrix {
+	columns := 0
+	if len(a) > 0 {
+		columns = len(a[0])
+	}
+	M := &Matrix{d.like.Like(len(a), columns)}
+	M.AssignArray(a)
+	return M
+}
+
+// Computes the thin singular value decomposition of the m x n array a,
+// with m >= n, by Householder bidiagonalization followed by implicit
+// shifted QR iterations. The array a is overridden.
+func svd(a [][]float64) (U [][]float64, S []float64, V [][]float64) {
+	m := len(a)
+	n := 0
+	if m > 0 {
+		n = len(a[0])
+	}
+	nu := n
+	s := make([]float64, n)
+	U = make([][]float64, m)
+	for i := range U {
+		U[i] = make([]float64, nu)
+	}
+	V = make([][]float64, n)
+	for i := range V {
+		V[i] = make([]float64, n)
+	}
+	if n == 0 {
+		return U, s, V
+	}
+	e := make([]float64, n)
+	work := make([]float64, m)
+
+	// Reduce A to bidiagonal form, storing the diagonal elements
+	// in s and the super-diagonal elements in e.
+	nct := m - 1
+	if n < nct {
+		nct = n
+	}
+	nrt := n - 2
+	if m < nrt {
+		nrt = m
+	}
+	if nrt < 0 {
+		nrt = 0
+	}
+	kmax := nct
+	if nrt > kmax {
+		kmax = nrt
+	}
+	for k := 0; k < kmax; k++ {
+		if k < nct {
+			// Compute the transformation for the k-th column and
+			// place the k-th diagonal in s[k].
+			s[k] = 0
+			for i := k; i < m; i++ {
+				s[k] = math.Hypot(s[k], a[i][k])
+			}
+			if s[k] != 0 {
+				if a[k][k] < 0 {
+					s[k] = -s[k]
+				}
+				for i := k; i < m; i++ {
+					a[i][k] /= s[k]
+				}
+				a[k][k] += 1
+			}
+			s[k] = -s[k]
+		}
+		for j := k + 1; j < n; j++ {
+			if k < nct && s[k] != 0 {
+				// Apply the transformation.
+				t := 0.0
+				for i := k; i < m; i++ {
+					t += a[i][k] * a[i][j]
+				}
+				t = -t / a[k][k]
+				for i := k; i < m; i++ {
+					a[i][j] += t * a[i][k]
+				}
+			}
+			// Place the k-th row of A into e for the
+			// subsequent calculation of the row transformation.
+			e[j] = a[k][j]
+		}
+		if k < nct {
+			// Place the transformation in U for subsequent back
+			// multiplication.
+			for i := k; i < m; i++ {
+				U[i][k] = a[i][k]
+			}
+		}
+		if k < nrt {
+			// Compute the k-th row transformation and place the
+			// k-th super-diagonal in e[k].
+			e[k] = 0
+			for i := k + 1; i < n; i++ {
+				e[k] = math.Hypot(e[k], e[i])
+			}
+			if e[k] != 0 {
+				if e[k+1] < 0 {
+					e[k] = -e[k]
+				}
+				for i := k + 1; i < n; i++ {
+					e[i] /= e[k]
+				}
+				e[k+1] += 1
+			}
+			e[k] = -e[k]
+			if k+1 < m && e[k] != 0 {
+				// Apply the transformation.
+				for i := k + 1; i < m; i++ {
+					work[i] = 0
+				}
+				for j := k + 1; j < n; j++ {
+					for i := k + 1; i < m; i++ {
+						work[i] += e[j] * a[i][j]
+					}
+				}
+				for j := k + 1; j < n; j++ {
+					t := -e[j] / e[k+1]
+					for i := k + 1; i < m; i++ {
+						a[i][j] += t * work[i]
+					}
+				}
+			}
+			// Place the transformation in V for subsequent
+			// back multiplication.
+			for i := k + 1; i < n; i++ {
+				V[i][k] = e[i]
+			}
+		}
+	}
+
+	// Set up the final bidiagonal matrix of order p.
+	p := n
+	if nct < n {
+		s[nct] = a[nct][nct]
+	}
+	if m < p {
+		s[p-1] = 0
+	}
+	if nrt+1 < p {
+		e[nrt] = a[nrt][p-1]
+	}
+	e[p-1] = 0
+
+	// Generate U.
+	for j := nct; j < nu; j++ {
+		for i := 0; i < m; i++ {
+			U[i][j] = 0
+		}
+		U[j][j] = 1
+	}
+	for k := nct - 1; k >= 0; k-- {
+		if s[k] != 0 {
+			for j := k + 1; j < nu; j++ {
+				t := 0.0
+				for i := k; i < m; i++ {
+					t += U[i][k] * U[i][j]
+				}
+				t = -t / U[k][k]
+				for i := k; i < m; i++ {
+					U[i][j] += t * U[i][k]
+				}
+			}
+			for i := k; i < m; i++ {
+				U[i][k] = -U[i][k]
+			}
+			U[k][k] = 1 + U[k][k]
+			for i := 0; i < k-1; i++ {
+				U[i][k] = 0
+			}
+		} else {
+			for i := 0; i < m; i++ {
+				U[i][k] = 0
+			}
+			U[k][k] = 1
+		}
+	}
+
+	// Generate V.
+	for k := n - 1; k >= 0; k-- {
+		if k < nrt && e[k] != 0 {
+			for j := k + 1; j < nu; j++ {
+				t := 0.0
+				for i := k + 1; i < n; i++ {
+					t += V[i][k] * V[i][j]
+				}
+				t = -t / V[k+1][k]
+				for i := k + 1; i < n; i++ {
+					V[i][j] += t * V[i][k]
+				}
+			}
+		}
+		for i := 0; i < n; i++ {
+			V[i][k] = 0
+		}
+		V[k][k] = 1
+	}
+
+	// Main iteration loop for the singular values.
+	pp := p - 1
+	eps := math.Pow(2, -52)
+	tiny := math.Pow(2, -966)
+	for p > 0 {
+		var k, kase int
+
+		// This section of the program inspects for negligible
+		// elements in the s and e arrays. On completion the
+		// variables kase and k are set as follows.
+		//
+		// kase = 1  if s(p) and e[k-1] are negligible and k<p
+		// kase = 2  if s(k) is negligible and k<p
+		// kase = 3  if e[k-1] is negligible, k<p, and
+		//           s(k), ..., s(p) are not negligible (qr step).
+		// kase = 4  if e(p-1) is negligible (convergence).
+		for k = p - 2; k >= 0; k-- {
+			if math.Abs(e[k]) <= tiny+eps*(math.Abs(s[k])+math.Abs(s[k+1])) {
+				e[k] = 0
+				break
+			}
+		}
+		if k == p-2 {
+			kase = 4
+		} else {
+			var ks int
+			for ks = p - 1; ks > k; ks-- {
+				t := 0.0
+				if ks != p {
+					t += math.Abs(e[ks])
+				}
+				if ks != k+1 {
+					t += math.Abs(e[ks-1])
+				}
+				if math.Abs(s[ks]) <= tiny+eps*t {
+					s[ks] = 0
+					break
+				}
+			}
+			if ks == k {
+				kase = 3
+			} else if ks == p-1 {
+				kase = 1
+			} else {
+				kase = 2
+				k = ks
+			}
+		}
+		k++
+
+		switch kase {
+		case 1:
+			// Deflate negligible s(p).
+			f := e[p-2]
+			e[p-2] = 0
+			for j := p - 2; j >= k; j-- {
+				t := math.Hypot(s[j], f)
+				cs := s[j] / t
+				sn := f / t
+				s[j] = t
+				if j != k {
+					f = -sn * e[j-1]
+					e[j-1] = cs * e[j-1]
+				}
+				for i := 0; i < n; i++ {
+					t = cs*V[i][j] + sn*V[i][p-1]
+					V[i][p-1] = -sn*V[i][j] + cs*V[i][p-1]
+					V[i][j] = t
+				}
+			}
+		case 2:
+			// Split at negligible s(k).
+			f := e[k-1]
+			e[k-1] = 0
+			for j := k; j < p; j++ {
+				t := math.Hypot(s[j], f)
+				cs := s[j] / t
+				sn := f / t
+				s[j] = t
+				f = -sn * e[j]
+				e[j] = cs * e[j]
+				for i := 0; i < m; i++ {
+					t = cs*U[i][j] + sn*U[i][k-1]
+					U[i][k-1] = -sn*U[i][j] + cs*U[i][k-1]
+					U[i][j] = t
+				}
+			}
+		case 3:
+			// Perform one qr step.
+
+			// Calculate the shift.
+			scale := math.Max(math.Max(math.Max(math.Max(
+				math.Abs(s[p-1]), math.Abs(s[p-2])), math.Abs(e[p-2])),
+				math.Abs(s[k])), math.Abs(e[k]))
+			sp := s[p-1] / scale
+			spm1 := s[p-2] / scale
+			epm1 := e[p-2] / scale
+			sk := s[k] / scale
+			ek := e[k] / scale
+			b := ((spm1+sp)*(spm1-sp) + epm1*epm1) / 2
+			c := (sp * epm1) * (sp * epm1)
+			shift := 0.0
+			if b != 0 || c != 0 {
+				shift = math.Sqrt(b*b + c)
+				if b < 0 {
+					shift = -shift
+				}
+				shift = c / (b + shift)
+			}
+			f := (sk+sp)*(sk-sp) + shift
+			g := sk * ek
+
+			// Chase zeros.
+			for j := k; j < p-1; j++ {
+				t := math.Hypot(f, g)
+				cs := f / t
+				sn := g / t
+				if j != k {
+					e[j-1] = t
+				}
+				f = cs*s[j] + sn*e[j]
+				e[j] = cs*e[j] - sn*s[j]
+				g = sn * s[j+1]
+				s[j+1] = cs * s[j+1]
+				for i := 0; i < n; i++ {
+					t = cs*V[i][j] + sn*V[i][j+1]
+					V[i][j+1] = -sn*V[i][j] + cs*V[i][j+1]
+					V[i][j] = t
+				}
+				t = math.Hypot(f, g)
+				cs = f / t
+				sn = g / t
+				s[j] = t
+				f = cs*e[j] + sn*s[j+1]
+				s[j+1] = -sn*e[j] + cs*s[j+1]
+				g = sn * e[j+1]
+				e[j+1] = cs * e[j+1]
+				if j < m-1 {
+					for i := 0; i < m; i++ {
+						t = cs*U[i][j] + sn*U[i][j+1]
+						U[i][j+1] = -sn*U[i][j] + cs*U[i][j+1]
+						U[i][j] = t
+					}
+				}
+			}
+			e[p-2] = f
+		case 4:
+			// Convergence.
+
+			// Make the singular values positive.
+			if s[k] <= 0 {
+				if s[k] < 0 {
+					s[k] = -s[k]
+				} else {
+					s[k] = 0
+				}
+				for i := 0; i <= pp; i++ {
+					V[i][k] = -V[i][k]
+				}
+			}
+
+			// Order the singular values.
+			for k < pp {
+				if s[k] >= s[k+1] {
+					break
+				}
+				s[k], s[k+1] = s[k+1], s[k]
+				if k < n-1 {
+					for i := 0; i < n; i++ {
+						V[i][k+1], V[i][k] = V[i][k], V[i][k+1]
+					}
+				}
+				if k < m-1 {
+					for i := 0; i < m; i++ {
+						U[i][k+1], U[i][k] = U[i][k], U[i][k+1]
+					}
+				}
+				k++
+			}
+			p--
+		}
+	}
+	return U, s, V
+}
@@ -0,0 +1,102 @@
+package tfloat64
+
+import (
+	"math"
+	"testing"
+)
+
+func testSingularValueDecomposition(t *testing.T, A *Matrix) {
+	svd := NewDenseSingularValueDecomposition(A)
+	U := svd.U()
+	S := svd.S()
+	V := svd.V()
+
+	for i := 1; i < S.Size(); i++ {
+		if S.GetQuick(i-1) < S.GetQuick(i) {
+			t.Errorf("singular values not ordered: %g < %g", S.GetQuick(i-1), S.GetQuick(i))
+		}
+	}
+
+	// A == U*diag(S)*V'
+	US, _ := U.ZMultMatrix(svd.SMatrix(), nil)
+	USV, _ := US.ZMultMatrixConst(V, nil, 1, 0, false, true)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if math.Abs(A.GetQuick(r, c)-USV.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", A.GetQuick(r, c), USV.GetQuick(r, c))
+			}
+		}
+	}
+
+	if svd.Norm2() != S.GetQuick(0) {
+		t.Errorf("expected:%g actual:%g", S.GetQuick(0), svd.Norm2())
+	}
+	if svd.Rank() != S.Size() {
+		t.Errorf("expected:%d actual:%d", S.Size(), svd.Rank())
+	}
+
+	// A*pinv(A)*A == A
+	X := svd.PseudoInverse()
+	if X.Rows() != A.Columns() || X.Columns() != A.Rows() {
+		t.Fatalf("expected:%dx%d actual:%dx%d", A.Columns(), A.Rows(), X.Rows(), X.Columns())
+	}
+	AX, _ := A.ZMultMatrix(X, nil)
+	AXA, _ := AX.ZMultMatrix(A, nil)
+	for r := 0; r < A.Rows(); r++ {
+		for c := 0; c < A.Columns(); c++ {
+			if math.Abs(A.GetQuick(r, c)-AXA.GetQuick(r, c)) > 1e-8 {
+				t.Errorf("expected:%g actual:%g", A.GetQuick(r, c), AXA.GetQuick(r, c))
+			}
+		}
+	}
+}
+
+func testSingularValueDecompositionRank(t *testing.T, A *Matrix) {
+	A.AssignArray([][]float64{
+		{1, 2, 3},
+		{2, 4, 6},
+		{1, 0, 1},
+		{0, 0, 0},
+	})
+	svd := NewDenseSingularValueDecomposition(A)
+	if svd.Rank() != 2 {
+		t.Errorf("expected:%d actual:%d", 2, svd.Rank())
+	}
+	if !math.IsInf(svd.Cond(), 1) && svd.Cond() < 1e15 {
+		t.Errorf("expected ill conditioned matrix, cond:%g", svd.Cond())
+	}
+
+	A.AssignArray([][]float64{
+		{3, 0, 0},
+		{0, -4, 0},
+		{0, 0, 2},
+		{0, 0, 0},
+	})
+	svd = NewDenseSingularValueDecomposition(A)
+	if math.Abs(4-svd.Norm2()) > tol {
+		t.Errorf("expected:%g actual:%g", 4.0, svd.Norm2())
+	}
+	if math.Abs(2-svd.Cond()) > tol {
+		t.Errorf("expected:%g actual:%g", 2.0, svd.Cond())
+	}
+}
+
+func TestDenseSingularValueDecomposition(t *testing.T) {
+	testSingularValueDecomposition(t, makeDenseMatrix())
+}
+
+func TestDenseSingularValueDecompositionTall(t *testing.T) {
+	testSingularValueDecomposition(t, makeDenseMatrix().ViewDice())
+}
+
+func TestDenseSingularValueDecompositionRank(t *testing.T) {
+	testSingularValueDecompositionRank(t, NewMatrix(4, 3))
+}
+
+func TestSparseSingularValueDecomposition(t *testing.T) {
+	testSingularValueDecomposition(t, makeSparseMatrix())
+}
+
+func TestSparseSingularValueDecompositionRank(t *testing.T) {
+	testSingularValueDecompositionRank(t, NewSparseMatrix(4, 3))
+}
@@ -0,0 +1,196 @@
+package tfloat64
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Builder for sparse matrices in coordinate (COO) format. Cells are
+// appended as (row, column, value) triplets in any order and the values
+// of triplets with the same coordinate are summed when the builder is
+// finalized into a matrix. Append and the finalizing methods may be
+// called concurrently from multiple goroutines.
+type TripletBuilder struct {
+	mu            sync.Mutex
+	rows, columns int
+	rowIndexes    []int
+	columnIndexes []int
+	values        []float64
+}
+
+// Constructs and returns a new triplet builder for a matrix with the given
+// number of rows and columns.
+func NewTripletBuilder(rows, columns int) *TripletBuilder {
+	return NewTripletBuilderCapacity(rows, columns, 0)
+}
+
+// Constructs and returns a new triplet builder for a matrix with the given
+// number of rows and columns, with room for the given number of triplets
+// before reallocation.
+func NewTripletBuilderCapacity(rows, columns, capacity int) *TripletBuilder {
+	return &TripletBuilder{
+		rows:          rows,
+		columns:       columns,
+		rowIndexes:    make([]int, 0, capacity),
+		columnIndexes: make([]int, 0, capacity),
+		values:        make([]float64, 0, capacity),
+	}
+}
+
+// Returns the number of rows of the matrix being built.
+func (b *TripletBuilder) Rows() int {
+	return b.rows
+}
+
+// Returns the number of columns of the matrix being built.
+func (b *TripletBuilder) Columns() int {
+	return b.columns
+}
+
+// Returns the number of triplets appended so far, including duplicates.
+func (b *TripletBuilder) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.values)
+}
+
+// Appends the given triplet. The value is added to any other values
+// appended at the same coordinate.
+func (b *TripletBuilder) Append(row, column int, value float64) error {
+	if row < 0 || row >= b.rows || column < 0 || column >= b.columns {
+		return fmt.Errorf("Attempted to access %d x %d matrix at row=%d, column=%d", b.rows, b.columns, row, column)
+	}
+	b.mu.Lock()
+	b.rowIndexes = append(b.rowIndexes, row)
+	b.columnIndexes = append(b.columnIndexes, column)
+	b.values = append(b.values, value)
+	b.mu.Unlock()
+	return nil
+}
+
+// Appends the triplets (rowIndexes[i], columnIndexes[i], values[i]). No
+// triplets are appended if any coordinate is out of bounds.
+func (b *TripletBuilder) AppendAll(rowIndexes, columnIndexes []int, values []float64) error {
+	if len(rowIndexes) != len(values) || len(columnIndexes) != len(values) {
+		return fmt.Errorf("Incompatible lengths: %d, %d, %d", len(rowIndexes), len(columnIndexes), len(values))
+	}
+	for i := range values {
+		if rowIndexes[i] < 0 || rowIndexes[i] >= b.rows || columnIndexes[i] < 0 || columnIndexes[i] >= b.columns {
+			return fmt.Errorf("Attempted to access %d x %d matrix at row=%d, column=%d", b.rows, b.columns, rowIndexes[i], columnIndexes[i])
+		}
+	}
+	b.mu.Lock()
+	b.rowIndexes = append(b.rowIndexes, rowIndexes...)
+	b.columnIndexes = append(b.columnIndexes, columnIndexes...)
+	b.values = append(b.values, values...)
+	b.mu.Unlock()
+	return nil
+}
+
+// Removes all triplets from this builder.
+func (b *TripletBuilder) Reset() {
+	b.mu.Lock()
+	b.rowIndexes = b.rowIndexes[:0]
+	b.columnIndexes = b.columnIndexes[:0]
+	b.values = b.values[:0]
+	b.mu.Unlock()
+}
+
+// Returns a new matrix in compressed row storage holding the summed
+// triplets. Coordinates whose values sum to zero are not stored.
+func (b *TripletBuilder) RowCompressed() *Matrix {
+	return &Matrix{b.compress()}
+}
+
+// Returns a new matrix in compressed column storage holding the summed
+// triplets. Coordinates whose values sum to zero are not stored.
+func (b *TripletBuilder) ColumnCompressed() *Matrix {
+	return b.compress().ColumnCompressed()
+}
+
+// Returns a new hash based sparse matrix holding the summed triplets.
+func (b *TripletBuilder) Sparse() *Matrix {
+	return b.compress().Sparse()
+}
+
+// Returns a new dense matrix holding the summed triplets.
+func (b *TripletBuilder) Dense() *Matrix {
+	D := NewMatrix(b.rows, b.columns)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, value := range b.values {
+		r := b.rowIndexes[i]
+		c := b.columnIndexes[i]
+		D.SetQuick(r, c, D.GetQuick(r, c)+value)
+	}
+	return D
+}
+
+// Sorts the triplets by row using a counting sort, then orders each row by
+// column and sums duplicate coordinates.
+func (b *TripletBuilder) compress() *SparseRCMat {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	m := newSparseRCMat(b.rows, b.columns)
+	nnz := len(b.values)
+	for _, r := range b.rowIndexes {
+		m.rowPointers[r+1]++
+	}
+	for r := 0; r < b.rows; r++ {
+		m.rowPointers[r+1] += m.rowPointers[r]
+	}
+	columnIndexes := make([]int, nnz)
+	values := make([]float64, nnz)
+	next := make([]int, b.rows)
+	copy(next, m.rowPointers[:b.rows])
+	for i, r := range b.rowIndexes {
+		columnIndexes[next[r]] = b.columnIndexes[i]
+		values[next[r]] = b.values[i]
+		next[r]++
+	}
+
+	k := 0
+	for r := 0; r < b.rows; r++ {
+		low := m.rowPointers[r]
+		high := m.rowPointers[r+1]
+		sort.Sort(&tripletRow{columnIndexes[low:high], values[low:high]})
+		m.rowPointers[r] = k
+		for i := low; i < high; {
+			c := columnIndexes[i]
+			sum := 0.0
+			for ; i < high && columnIndexes[i] == c; i++ {
+				sum += values[i]
+			}
+			if sum != 0 {
+				columnIndexes[k] = c
+				values[k] = sum
+				k++
+			}
+		}
+	}
+	m.rowPointers[b.rows] = k
+	m.columnIndexes = columnIndexes[:k]
+	m.values = values[:k]
+	return m
+}
+
+// Sorts the cells of a row by column index.
+type tripletRow struct {
+	columnIndexes []int
+	values        []float64
+}
+
+func (t *tripletRow) Len() int {
+	return len(t.values)
+}
+
+func (t *tripletRow) Less(i, j int) bool {
+	return t.columnIndexes[i] < t.columnIndexes[j]
+}
+
+func (t *tripletRow) Swap(i, j int) {
+	t.columnIndexes[i], t.columnIndexes[j] = t.columnIndexes[j], t.columnIndexes[i]
+	t.values[i], t.values[j] = t.values[j], t.values[i]
+}
@@ -0,0 +1,72 @@
+package tfloat64
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestTripletBuilder(t *testing.T) {
+	b := NewTripletBuilder(3, 4)
+	b.Append(2, 3, 1)
+	b.Append(0, 1, 2)
+	b.Append(2, 0, 5)
+	b.Append(0, 1, 3)
+	b.Append(1, 2, 4)
+	b.Append(1, 2, -4)
+	b.Append(2, 3, 0.5)
+	if err := b.Append(3, 0, 1); err == nil {
+		t.Errorf("expected error for row out of range")
+	}
+	if b.Len() != 7 {
+		t.Errorf("expected:%d actual:%d", 7, b.Len())
+	}
+	expected := [][]float64{
+		{0, 5, 0, 0},
+		{0, 0, 0, 0},
+		{5, 0, 0, 1.5},
+	}
+	for _, A := range []*Matrix{b.RowCompressed(), b.ColumnCompressed(), b.Sparse(), b.Dense()} {
+		for r := range expected {
+			for c := range expected[r] {
+				if expected[r][c] != A.GetQuick(r, c) {
+					t.Errorf("expected:%g actual:%g", expected[r][c], A.GetQuick(r, c))
+				}
+			}
+		}
+	}
+	if nnz := b.RowCompressed().Mat.(*SparseRCMat).NonZeroCount(); nnz != 3 {
+		t.Errorf("expected:%d actual:%d", 3, nnz)
+	}
+	b.Reset()
+	if b.Len() != 0 {
+		t.Errorf("expected:%d actual:%d", 0, b.Len())
+	}
+}
+
+func TestTripletBuilderConcurrent(t *testing.T) {
+	n := 8
+	b := NewTripletBuilder(n, n)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := 0; r < n; r++ {
+				for c := 0; c < n; c++ {
+					b.Append(r, c, float64(r*n+c))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	A := b.ColumnCompressed()
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			expected := 4 * float64(r*n+c)
+			if math.Abs(expected-A.GetQuick(r, c)) > tol {
+				t.Errorf("expected:%g actual:%g", expected, A.GetQuick(r, c))
+			}
+		}
+	}
+}
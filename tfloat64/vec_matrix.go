@@ -0,0 +1,97 @@
+package tfloat64
+
+import (
+	"fmt"
+
+	"github.com/rwl/goshawk/common"
+)
+
+// Vector view on the cells of a matrix backend whose elements cannot be
+// addressed directly, such as compressed row or column storage. Returned
+// by the row and column views of such a matrix.
+//
+// The index of a cell is its position in the row major layout of the
+// backing matrix. Cells are read from and written to the backing matrix
+// using GetQuick and SetQuick.
+type MatrixVec struct {
+	*common.CoreVec
+	matrix  Mat   // The backing matrix.
+	offsets []int // The offsets of visible indexes of a selection view, or nil.
+}
+
+func (v *MatrixVec) Index(rank int) int {
+	if v.offsets != nil {
+		return v.offsets[v.Zero()+rank*v.Stride()]
+	}
+	return v.Zero() + rank*v.Stride()
+}
+
+func (v *MatrixVec) GetQuick(index int) float64 {
+	i := v.Index(index)
+	columns := v.matrix.Columns()
+	return v.matrix.GetQuick(i/columns, i%columns)
+}
+
+func (v *MatrixVec) SetQuick(index int, value float64) {
+	i := v.Index(index)
+	columns := v.matrix.Columns()
+	v.matrix.SetQuick(i/columns, i%columns, value)
+}
+
+func (v *MatrixVec) Elements() interface{} {
+	return v.matrix.Elements()
+}
+
+func (v *MatrixVec) Like(size int) Vec {
+	return v.matrix.LikeVector(size)
+}
+
+func (v *MatrixVec) LikeMatrix(rows, columns int) Mat {
+	return v.matrix.Like(rows, columns)
+}
+
+func (v *MatrixVec) ViewSelectionLike(offsets []int) Vec {
+	return &MatrixVec{
+		common.NewCoreVec(true, len(offsets), 0, 1),
+		v.matrix, offsets,
+	}
+}
+
+func (v *MatrixVec) ViewVec() Vec {
+	return &MatrixVec{
+		common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+		v.matrix, v.offsets,
+	}
+}
+
+func (v *MatrixVec) ReshapeMatrix(rows, columns int) (*Matrix, error) {
+	if rows*columns != v.Size() {
+		return nil, fmt.Errorf("rows*columns != size")
+	}
+	M := &Matrix{v.matrix.Like(rows, columns)}
+	idx := 0
+	for c := 0; c < columns; c++ {
+		for r := 0; r < rows; r++ {
+			M.SetQuick(r, c, v.GetQuick(idx))
+			idx++
+		}
+	}
+	return M, nil
+}
+
+func (v *MatrixVec) ReshapeCube(slices, rows, columns int) (*Cube, error) {
+	if slices*rows*columns != v.Size() {
+		return nil, fmt.Errorf("slices*rows*columns != size")
+	}
+	M := NewSparseCube(slices, rows, columns)
+	idx := 0
+	for s := 0; s < slices; s++ {
+		for c := 0; c < columns; c++ {
+			for r := 0; r < rows; r++ {
+				M.SetQuick(s, r, c, v.GetQuick(idx))
+				idx++
+			}
+		}
+	}
+	return M, nil
+}
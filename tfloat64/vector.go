@@ -643,3 +643,11 @@ type vectorElement struct {
 	value float64
 	location int
 }
+
+func (v *Vector) checkSize(other common.Vec) error {
+	if v.Size() != other.Size() {
+		return fmt.Errorf("Incompatible sizes: %s and %s",
+			v.StringShort(), common.VectorShape(other))
+	}
+	return nil
+}
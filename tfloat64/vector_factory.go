@@ -21,8 +21,10 @@ func NewVectorArray(a []float64) *Vector {
 // matrices. Example: 0 1 append 3 4 --> 0 1 3 4.
 func AppendVectors(A, B Vec) *Vector {
 	v := NewVector(A.Size() + B.Size())
-	v.ViewPart(0, A.Size()).AssignVector(A)
-	v.ViewPart(A.Size(), B.Size()).AssignVector(B)
+	a, _ := v.ViewPart(0, A.Size())
+	a.AssignVector(A)
+	b, _ := v.ViewPart(A.Size(), B.Size())
+	b.AssignVector(B)
 	return v
 }
 
@@ -65,7 +67,8 @@ func NewVectorParts(parts []Vec) *Vector {
 	vector := NewVector(size)
 	size = 0
 	for _, part := range parts {
-		vector.ViewPart(size, part.Size()).AssignVector(part)
+		view, _ := vector.ViewPart(size, part.Size())
+		view.AssignVector(part)
 		size += part.Size()
 	}
 
@@ -95,7 +98,8 @@ func RepeatVector(A Vec, repeat int) *Vector {
 	size := A.Size()
 	v := NewVector(repeat*size)
 	for i := repeat - 1; i >= 0; i-- {
-		v.ViewPart(size*i, size).AssignVector(A)
+		view, _ := v.ViewPart(size*i, size)
+		view.AssignVector(A)
 	}
 	return v
 }
@@ -16,7 +16,7 @@ type cardinalityVector interface {
 func testCardinality(t *testing.T, A cardinalityVector) {
 	card := A.Cardinality()
 	if A.Size() != card {
-		t.Errorf("expected:%g actual:%g", A.Size(), card)
+		t.Errorf("expected:%d actual:%d", A.Size(), card)
 	}
 }
 
@@ -12,7 +12,7 @@ import (
 // matrix, and vice-versa.
 func (v *Vector) ViewFlip() *Vector {
 	view := v.ViewVector()
-	view.VectorFlip()
+	view.VFlip()
 	return view
 }
 
@@ -29,10 +29,13 @@ func (v *Vector) ViewFlip() *Vector {
 // view.size() == width. A view's legal coordinates are again zero
 // based, as usual. In other words, legal coordinates of the view are
 // 0 .. view.size()-1==width-1.
-func (v *Vector) ViewPart(index, width int) *Vector {
+func (v *Vector) ViewPart(index, width int) (*Vector, error) {
 	view := v.ViewVector()
-	view.VectorPart(index, width)
-	return view
+	err := view.VPart(index, width)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
 }
 
 // Constructs and returns a new "selection view" that is a vector
@@ -110,8 +113,11 @@ func (v *Vector) ViewSorted() *Vector {
 // consisting of every i-th cell. More specifically, the view has size
 // this.size()/stride holding cells this.get(i*stride) for
 // all i = 0..size()/stride - 1.
-func (v *Vector) ViewStrides(stride int) *Vector {
+func (v *Vector) ViewStrides(stride int) (*Vector, error) {
 	view := v.ViewVector()
-	view.VectorStrides(stride)
-	return view
+	err := view.VStrides(stride)
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
 }
@@ -26,11 +26,11 @@ func testViewFlip(t *testing.T, A viewFlipVector) {
 
 type viewPartVector interface {
 	Vec
-	ViewPart(int, int) *Vector
+	ViewPart(int, int) (*Vector, error)
 }
 
 func testViewPart(t *testing.T, A viewPartVector) {
-	b := A.ViewPart(15, 11)
+	b, _ := A.ViewPart(15, 11)
 	for i := 0; i < 11; i++ {
 		expected := A.GetQuick(15 + i)
 		result := b.GetQuick(i)
@@ -90,12 +90,12 @@ func testViewSorted(t *testing.T, A viewSortedVector) {
 
 type viewStridesVector interface {
 	Vec
-	ViewStrides(int) *Vector
+	ViewStrides(int) (*Vector, error)
 }
 
 func testViewStrides(t *testing.T, A viewStridesVector) {
 	stride := 3
-	b := A.ViewStrides(stride)
+	b, _ := A.ViewStrides(stride)
 	for i := 0; i < b.Size(); i++ {
 		expected := A.GetQuick(i*stride)
 		result := b.GetQuick(i)
@@ -0,0 +1,51 @@
+package tint
+
+import "github.com/rwl/goshawk/tfloat64"
+
+// Returns a new dense vector holding the values of v converted to
+// int, rounding halves away from zero.
+func NewVectorFloat64(v tfloat64.Vec) *Vector {
+	x := NewVector(v.Size())
+	x.AssignFloat64(v)
+	return x
+}
+
+// Returns a new sparse vector holding the values of v converted to
+// int, rounding halves away from zero.
+func NewSparseVectorFloat64(v tfloat64.Vec) *Vector {
+	x := NewSparseVector(v.Size())
+	x.AssignFloat64(v)
+	return x
+}
+
+// Returns a new dense matrix holding the values of A converted to
+// int, rounding halves away from zero.
+func NewMatrixFloat64(A tfloat64.Mat) *Matrix {
+	m := NewMatrix(A.Rows(), A.Columns())
+	m.AssignFloat64(A)
+	return m
+}
+
+// Returns a new sparse matrix holding the values of A converted to
+// int, rounding halves away from zero.
+func NewSparseMatrixFloat64(A tfloat64.Mat) *Matrix {
+	m := NewSparseMatrix(A.Rows(), A.Columns())
+	m.AssignFloat64(A)
+	return m
+}
+
+// Returns a new dense cube holding the values of A converted to
+// int, rounding halves away from zero.
+func NewCubeFloat64(A tfloat64.Cub) *Cube {
+	m := NewCube(A.Slices(), A.Rows(), A.Columns())
+	m.AssignFloat64(A)
+	return m
+}
+
+// Returns a new sparse cube holding the values of A converted to
+// int, rounding halves away from zero.
+func NewSparseCubeFloat64(A tfloat64.Cub) *Cube {
+	m := NewSparseCube(A.Slices(), A.Rows(), A.Columns())
+	m.AssignFloat64(A)
+	return m
+}
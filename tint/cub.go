@@ -0,0 +1,21 @@
+package tint
+
+import "github.com/rwl/goshawk/common"
+
+// Interface for all int cube backends.
+type Cub interface {
+	common.Cub
+
+	GetQuick(int, int, int) int
+	SetQuick(int, int, int, int)
+
+	Like(int, int, int) Cub
+
+	// Returns a rows x columns matrix view sharing the elements of the
+	// receiver, with the given zeros and strides into the elements.
+	Like2D(rows, columns, rowZero, columnZero, rowStride, columnStride int) Mat
+
+	// Returns a new backend sharing the elements of the receiver whose
+	// shape may be modified independently.
+	View() Cub
+}
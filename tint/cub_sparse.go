@@ -0,0 +1,40 @@
+package tint
+
+import "github.com/rwl/goshawk/common"
+
+type SparseCub struct {
+	*common.CoreCub
+	elements map[int]int // The non-zero elements of this cube.
+}
+
+func (m *SparseCub) GetQuick(slice, row, column int) int {
+	return m.elements[m.Index(slice, row, column)]
+}
+
+func (m *SparseCub) SetQuick(slice, row, column int, value int) {
+	index := m.Index(slice, row, column)
+	if value == 0 {
+		delete(m.elements, index)
+	} else {
+		m.elements[index] = value
+	}
+}
+
+func (m *SparseCub) Elements() interface{} {
+	return m.elements
+}
+
+func (m *SparseCub) Like(slices, rows, columns int) Cub {
+	return NewSparseCube(slices, rows, columns).Cub
+}
+
+func (m *SparseCub) Like2D(rows, columns, rowZero, columnZero, rowStride, columnStride int) Mat {
+	return &SparseMat{
+		common.NewCoreMat(true, rows, columns, rowStride, columnStride, rowZero, columnZero),
+		m.elements,
+	}
+}
+
+func (m *SparseCub) View() Cub {
+	return &SparseCub{m.CoreCub.View(), m.elements}
+}
@@ -0,0 +1,27 @@
+package tint
+
+import "testing"
+
+func makeDenseCube() *Cube {
+	return fillCube(NewCube(nslices, nrows, ncols))
+}
+
+func TestDenseCubeGetSet(t *testing.T) {
+	testCubeGetSet(t, makeDenseCube())
+}
+
+func TestDenseCubeAssign(t *testing.T) {
+	testCubeAssign(t, makeDenseCube())
+}
+
+func TestDenseCubeView(t *testing.T) {
+	testCubeView(t, makeDenseCube())
+}
+
+func TestDenseCubeAggregate(t *testing.T) {
+	testCubeAggregate(t, makeDenseCube())
+}
+
+func TestDenseCubeConvert(t *testing.T) {
+	testCubeConvert(t, makeDenseCube())
+}
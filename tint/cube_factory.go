@@ -0,0 +1,25 @@
+package tint
+
+import "github.com/rwl/goshawk/common"
+
+// Returns a new dense cube with the given number of slices, rows and
+// columns.
+func NewCube(slices, rows, columns int) *Cube {
+	return &Cube{
+		&DenseCub{
+			common.NewCoreCub(false, slices, rows, columns, rows*columns, columns, 1, 0, 0, 0),
+			make([]int, slices*rows*columns),
+		},
+	}
+}
+
+// Returns a new sparse cube with the given number of slices, rows and
+// columns.
+func NewSparseCube(slices, rows, columns int) *Cube {
+	return &Cube{
+		&SparseCub{
+			common.NewCoreCub(false, slices, rows, columns, rows*columns, columns, 1, 0, 0, 0),
+			make(map[int]int),
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package tint
+
+import "testing"
+
+const nslices = 5
+
+func fillCube(A *Cube) *Cube {
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				A.SetQuick(s, r, c, randValue())
+			}
+		}
+	}
+	return A
+}
+
+func testCubeGetSet(t *testing.T, A *Cube) {
+	value := next(A.GetQuick(1, 2, 3))
+	A.SetQuick(1, 2, 3, value)
+	if a, err := A.Get(1, 2, 3); err != nil || a != value {
+		t.Errorf("expected:%v actual:%v", value, a)
+	}
+	if err := A.Set(A.Slices(), 0, 0, value); err == nil {
+		t.Error("expected slice out of bounds error")
+	}
+	B := A.Copy()
+	if !B.EqualsCube(A) {
+		t.Error("expected copy to equal original")
+	}
+	if B.Cardinality() != A.Cardinality() {
+		t.Errorf("expected:%d actual:%d", A.Cardinality(), B.Cardinality())
+	}
+	B.AssignFunc(next)
+	if B.GetQuick(1, 2, 3) != next(value) || A.GetQuick(1, 2, 3) != value {
+		t.Error("expected copy to be independent of original")
+	}
+}
+
+func testCubeAssign(t *testing.T, A *Cube) {
+	B := A.Copy().AssignFunc(next)
+	if _, err := B.AssignCubeFunc(A, Plus); err != nil {
+		t.Fatal(err)
+	}
+	for s := 0; s < A.Slices(); s++ {
+		for r := 0; r < A.Rows(); r++ {
+			for c := 0; c < A.Columns(); c++ {
+				a := A.GetQuick(s, r, c)
+				if B.GetQuick(s, r, c) != Plus(next(a), a) {
+					t.Errorf("expected:%v actual:%v", Plus(next(a), a), B.GetQuick(s, r, c))
+				}
+			}
+		}
+	}
+	if _, err := B.AssignArray(A.ToArray()); err != nil {
+		t.Fatal(err)
+	}
+	if !B.EqualsCube(A) {
+		t.Error("expected cube assigned from array to equal original")
+	}
+	B.Assign(7)
+	if !B.Equals(7) {
+		t.Error("expected all cells to equal the assigned value")
+	}
+	if _, err := B.AssignCube(NewCube(1, 1, 1)); err == nil {
+		t.Error("expected shape mismatch error")
+	}
+}
+
+func testCubeView(t *testing.T, A *Cube) {
+	S, err := A.ViewSlice(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := next(A.GetQuick(3, 4, 5))
+	S.SetQuick(4, 5, value)
+	if A.GetQuick(3, 4, 5) != value {
+		t.Error("expected slice view to share cells with cube")
+	}
+	D, err := A.ViewDice(2, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if D.GetQuick(5, 3, 4) != value {
+		t.Errorf("expected:%v actual:%v", value, D.GetQuick(5, 3, 4))
+	}
+	P, err := A.ViewPart(1, 2, 3, 3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if P.GetQuick(2, 2, 2) != value {
+		t.Errorf("expected:%v actual:%v", value, P.GetQuick(2, 2, 2))
+	}
+	T, err := A.ViewStrides(3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if T.GetQuick(1, 1, 1) != value {
+		t.Errorf("expected:%v actual:%v", value, T.GetQuick(1, 1, 1))
+	}
+	if _, err := A.ViewSlice(A.Slices()); err == nil {
+		t.Error("expected slice out of bounds error")
+	}
+	if A.String() == "" {
+		t.Error("expected non-empty string")
+	}
+}
+
+func testCubeAggregate(t *testing.T, A *Cube) {
+	var sum int
+	for _, slice := range A.ToArray() {
+		for _, row := range slice {
+			for _, value := range row {
+				sum += value
+			}
+		}
+	}
+	if !near(A.ZSum(), sum) {
+		t.Errorf("expected:%v actual:%v", sum, A.ZSum())
+	}
+}
+
+func testCubeConvert(t *testing.T, A *Cube) {
+	X := A.Float64()
+	if X.GetQuick(4, 12, 16) != float64(A.GetQuick(4, 12, 16)) {
+		t.Errorf("expected:%v actual:%v", float64(A.GetQuick(4, 12, 16)), X.GetQuick(4, 12, 16))
+	}
+	if !NewCubeFloat64(X).EqualsCube(A) || !NewSparseCubeFloat64(X).EqualsCube(A) {
+		t.Error("expected round trip conversion to equal original")
+	}
+}
@@ -0,0 +1,142 @@
+package tint
+
+type IntFunc func(int) int
+
+type IntIntFunc func(int, int) int
+
+type IntProcedure func(int) bool
+
+type IntIntIntFunc func(int, int, int) int
+
+type VectorProcedure func(Vec) bool
+
+// Function that returns its argument.
+func Identity(a int) int {
+	return a
+}
+
+// Function that returns -a.
+func Neg(a int) int {
+	return -a
+}
+
+// Function that returns the absolute value of a.
+func Abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Function that returns a * a.
+func Square(a int) int {
+	return a * a
+}
+
+// Function that returns a + b.
+func Plus(a, b int) int {
+	return a + b
+}
+
+// Function that returns a - b.
+func Minus(a, b int) int {
+	return a - b
+}
+
+// Function that returns a * b.
+func Mult(a, b int) int {
+	return a * b
+}
+
+// Function that returns a / b, truncated towards zero.
+func Div(a, b int) int {
+	return a / b
+}
+
+// Function that returns the remainder a % b.
+func Mod(a, b int) int {
+	return a % b
+}
+
+// Function that returns the larger of a and b.
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Function that returns the smaller of a and b.
+func Min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Constructs a function that returns a + b. a is a
+// variable, b is fixed.
+func Add(b int) IntFunc {
+	return func(a int) int {
+		return a + b
+	}
+}
+
+// Constructs a function that returns a * b. a is a
+// variable, b is fixed.
+func Multiply(b int) IntFunc {
+	return func(a int) int {
+		return a * b
+	}
+}
+
+// Constructs a function that returns a / b, truncated towards zero. a
+// is a variable, b is fixed.
+func Divide(b int) IntFunc {
+	return func(a int) int {
+		return a / b
+	}
+}
+
+// Constructs a function that returns the constant c.
+func Constant(c int) IntFunc {
+	return func(_ int) int {
+		return c
+	}
+}
+
+// Constructs a function that returns a == b. a is a
+// variable, b is fixed.
+func IsEqualTo(b int) IntProcedure {
+	return func(a int) bool {
+		return a == b
+	}
+}
+
+// Constructs a function that returns a > b. a is a
+// variable, b is fixed.
+func IsGreaterThan(b int) IntProcedure {
+	return func(a int) bool {
+		return a > b
+	}
+}
+
+// Constructs a function that returns a < b. a is a
+// variable, b is fixed.
+func IsLessThan(b int) IntProcedure {
+	return func(a int) bool {
+		return a < b
+	}
+}
+
+// Constructs a function that returns f(g(a)).
+func ChainUnary(f, g IntFunc) IntFunc {
+	return func(a int) int {
+		return f(g(a))
+	}
+}
+
+// Returns whether a and b are equal; integer cells are compared exactly.
+func equals(a, b int) bool {
+	return a == b
+}
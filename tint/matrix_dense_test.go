@@ -0,0 +1,31 @@
+package tint
+
+import "testing"
+
+func makeDenseMatrix() *Matrix {
+	return fillMatrix(NewMatrix(nrows, ncols))
+}
+
+func TestDenseMatrixGetSet(t *testing.T) {
+	testMatrixGetSet(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixAssign(t *testing.T) {
+	testMatrixAssign(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixView(t *testing.T) {
+	testMatrixView(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixAggregate(t *testing.T) {
+	testMatrixAggregate(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixZMult(t *testing.T) {
+	testMatrixZMult(t, makeDenseMatrix())
+}
+
+func TestDenseMatrixConvert(t *testing.T) {
+	testMatrixConvert(t, makeDenseMatrix())
+}
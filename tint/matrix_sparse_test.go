@@ -0,0 +1,31 @@
+package tint
+
+import "testing"
+
+func makeSparseMatrix() *Matrix {
+	return fillMatrix(NewSparseMatrix(nrows, ncols))
+}
+
+func TestSparseMatrixGetSet(t *testing.T) {
+	testMatrixGetSet(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixAssign(t *testing.T) {
+	testMatrixAssign(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixView(t *testing.T) {
+	testMatrixView(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixAggregate(t *testing.T) {
+	testMatrixAggregate(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixZMult(t *testing.T) {
+	testMatrixZMult(t, makeSparseMatrix())
+}
+
+func TestSparseMatrixConvert(t *testing.T) {
+	testMatrixConvert(t, makeSparseMatrix())
+}
@@ -0,0 +1,37 @@
+package tint
+
+import "github.com/rwl/goshawk/common"
+
+// Interface for all int vector backends.
+type Vec interface {
+	common.Vec
+
+	// Returns the matrix cell value at coordinate "index".
+	//
+	// Provided with invalid parameters this method may cause a panic or
+	// return invalid values without causing an error. You should only
+	// use this method when you are absolutely sure that the coordinate
+	// is within bounds.
+	// Precondition (unchecked): index < 0 || index >= Size().
+	GetQuick(int) int
+
+	// Sets the matrix cell at coordinate "index" to the specified value.
+	//
+	// Provided with invalid parameters this method may cause a panic or
+	// access illegal indexes without causing an error. You should only use
+	// this method when you are absolutely sure that the coordinate is
+	// within bounds.
+	// Precondition (unchecked): index < 0 || index >= Size().
+	SetQuick(int, int)
+
+	Like(int) Vec
+	LikeMatrix(int, int) Mat
+
+	// Returns a new backend sharing the elements of the receiver whose
+	// shape may be modified independently.
+	ViewVec() Vec
+
+	// Returns a selection view sharing the elements of the receiver,
+	// where cell i of the view is the element at offsets[i].
+	ViewSelectionLike(offsets []int) Vec
+}
@@ -0,0 +1,36 @@
+package tint
+
+import "github.com/rwl/goshawk/common"
+
+// Selection view on dense 1-d matrices holding int elements.
+//
+// The zero and stride index into the offset array rather than into the
+// elements. Cell addressing overhead is 1 additional array index access
+// per get/set.
+type SelectedDenseVec struct {
+	*DenseVec
+	offsets []int // The offsets of visible indexes of this vector.
+	offset  int   // The offset.
+}
+
+func (v *SelectedDenseVec) GetQuick(index int) int {
+	return v.elements[v.Index(index)]
+}
+
+func (v *SelectedDenseVec) SetQuick(index int, value int) {
+	v.elements[v.Index(index)] = value
+}
+
+func (v *SelectedDenseVec) Index(rank int) int {
+	return v.offset + v.offsets[v.Zero()+rank*v.Stride()]
+}
+
+func (v *SelectedDenseVec) ViewVec() Vec {
+	return &SelectedDenseVec{
+		&DenseVec{
+			common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+			v.elements,
+		},
+		v.offsets, v.offset,
+	}
+}
@@ -0,0 +1,41 @@
+package tint
+
+import "github.com/rwl/goshawk/common"
+
+// Selection view on sparse 1-d matrices holding int elements.
+//
+// The zero and stride index into the offset array rather than into the
+// elements. Cell addressing overhead is 1 additional array index access
+// per get/set.
+type SelectedSparseVec struct {
+	*SparseVec
+	offsets []int // The offsets of visible indexes of this vector.
+	offset  int   // The offset.
+}
+
+func (v *SelectedSparseVec) GetQuick(index int) int {
+	return v.elements[v.Index(index)]
+}
+
+func (v *SelectedSparseVec) SetQuick(index int, value int) {
+	i := v.Index(index)
+	if value == 0 {
+		delete(v.elements, i)
+	} else {
+		v.elements[i] = value
+	}
+}
+
+func (v *SelectedSparseVec) Index(rank int) int {
+	return v.offset + v.offsets[v.Zero()+rank*v.Stride()]
+}
+
+func (v *SelectedSparseVec) ViewVec() Vec {
+	return &SelectedSparseVec{
+		&SparseVec{
+			common.NewCoreVec(true, v.Size(), v.Zero(), v.Stride()),
+			v.elements,
+		},
+		v.offsets, v.offset,
+	}
+}
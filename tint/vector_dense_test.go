@@ -0,0 +1,27 @@
+package tint
+
+import "testing"
+
+func makeDenseVector() *Vector {
+	return fillVector(NewVector(size))
+}
+
+func TestDenseVectorGetSet(t *testing.T) {
+	testVectorGetSet(t, makeDenseVector())
+}
+
+func TestDenseVectorAssign(t *testing.T) {
+	testVectorAssign(t, makeDenseVector())
+}
+
+func TestDenseVectorView(t *testing.T) {
+	testVectorView(t, makeDenseVector())
+}
+
+func TestDenseVectorAggregate(t *testing.T) {
+	testVectorAggregate(t, makeDenseVector())
+}
+
+func TestDenseVectorConvert(t *testing.T) {
+	testVectorConvert(t, makeDenseVector())
+}
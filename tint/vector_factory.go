@@ -0,0 +1,30 @@
+package tint
+
+import "github.com/rwl/goshawk/common"
+
+// Returns a new dense vector of the given size.
+func NewVector(size int) *Vector {
+	return &Vector{
+		&DenseVec{
+			common.NewCoreVec(false, size, 0, 1),
+			make([]int, size),
+		},
+	}
+}
+
+// Returns a new sparse vector of the given size.
+func NewSparseVector(size int) *Vector {
+	return &Vector{
+		&SparseVec{
+			common.NewCoreVec(false, size, 0, 1),
+			make(map[int]int),
+		},
+	}
+}
+
+// Returns a new dense vector holding the values of the given array.
+func NewVectorArray(a []int) *Vector {
+	v := NewVector(len(a))
+	v.AssignArray(a)
+	return v
+}